@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/cache"
+	_nr "github.com/rl404/fairy/log/newrelic"
+	nrCache "github.com/rl404/fairy/monitoring/newrelic/cache"
+	nrPS "github.com/rl404/fairy/monitoring/newrelic/pubsub"
+)
+
+func allInOne() error {
+	// Get config.
+	cfg, err := getConfig()
+	if err != nil {
+		return err
+	}
+	utils.Info("config initialized")
+
+	// Init newrelic.
+	nrApp, err := newrelic.NewApplication(
+		newrelic.ConfigAppName(cfg.Newrelic.Name),
+		newrelic.ConfigLicense(cfg.Newrelic.LicenseKey),
+		newrelic.ConfigDistributedTracerEnabled(true),
+		newrelic.ConfigAppLogForwardingEnabled(true),
+	)
+	if err != nil {
+		utils.Error(err.Error())
+	} else {
+		defer nrApp.Shutdown(10 * time.Second)
+		utils.AddLog(_nr.NewFromNewrelicApp(nrApp, _nr.LogLevel(cfg.Log.Level)))
+		utils.Info("newrelic initialized")
+	}
+
+	// Init tracer.
+	tp, err := newTracer(cfg.Tracer)
+	if err != nil {
+		return err
+	}
+	defer tp.Shutdown(context.Background())
+	utils.Info("tracer initialized")
+
+	// Init cache.
+	c, err := cache.New(cacheType[cfg.Cache.Dialect], cfg.Cache.Address, cfg.Cache.Password, cfg.Cache.Time)
+	if err != nil {
+		return err
+	}
+	c = nrCache.New(cfg.Cache.Dialect, cfg.Cache.Address, c)
+	utils.Info("cache initialized")
+	defer c.Close()
+
+	// Init atomic cache.
+	ac, err := newAtomicCache(cfg.Cache)
+	if err != nil {
+		return err
+	}
+	if ac != nil {
+		utils.Info("atomic cache initialized")
+		defer ac.Close()
+	}
+
+	// Init in-memory.
+	im, err := cache.New(cache.InMemory, "", "", 5*time.Second)
+	if err != nil {
+		return err
+	}
+	im = nrCache.New("inmemory", "inmemory", im)
+	utils.Info("in-memory initialized")
+	defer im.Close()
+
+	// Init db.
+	db, err := newDB(cfg.DB)
+	if err != nil {
+		return err
+	}
+	utils.Info("database initialized")
+	tmp, _ := db.DB()
+	defer tmp.Close()
+
+	// Init pubsub.
+	ps, err := newInProcessPubsub(cfg.PubSub, db)
+	if err != nil {
+		return err
+	}
+	ps = nrPS.New(cfg.PubSub.Dialect, ps, nrApp)
+	utils.Info("pubsub initialized")
+	defer ps.Close()
+
+	// Init service.
+	service, closeService, err := newService(cfg, db, c, ac, ps, true)
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	// Init consumer. Subscribe before serving so messages
+	// published by the first requests are not missed.
+	consumer := newConsumer(cfg.Consumer, service, ps)
+	utils.Info("consumer initialized")
+	defer consumer.Close()
+
+	// Start subscribe.
+	if err := consumer.Subscribe(nrApp); err != nil {
+		return err
+	}
+	utils.Info("consumer ready")
+
+	// Run outbox relay.
+	outboxRelay := newOutboxRelay(cfg.Outbox, service)
+	outboxRelay.Run()
+	defer outboxRelay.Close()
+	utils.Info("outbox relay running")
+
+	// Run scheduler.
+	scheduler, err := newScheduler(cfg, service, nrApp, db)
+	if err != nil {
+		return err
+	}
+	scheduler.Run()
+	defer scheduler.Close()
+	utils.Info("scheduler running")
+
+	// Init web server.
+	httpServer := newHTTPServer(cfg, service, nrApp)
+
+	// Run web server.
+	httpServerChan := httpServer.Run()
+	utils.Info("http server listening at :%s", cfg.HTTP.Port)
+
+	// Init GRPC.
+	grpcServer := newGRPCServer(cfg, service, nrApp)
+
+	// Run grpc server.
+	grpcServerChan := grpcServer.Run()
+	utils.Info("grpc server listening at :%s", cfg.GRPC.Port)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	select {
+	case err := <-httpServerChan:
+		if err != nil {
+			return err
+		}
+	case err := <-grpcServerChan:
+		if err != nil {
+			return err
+		}
+	case <-sigChan:
+	}
+
+	return nil
+}
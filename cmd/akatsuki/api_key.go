@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+)
+
+func apiKeyCreate(name, scopes string, rate float64, burst int) error {
+	s, cleanup, err := newCommandService(newInProcessPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	apiKey, _, err := s.CreateAPIKey(context.Background(), service.CreateAPIKeyRequest{
+		Name:   name,
+		Scopes: strings.Split(scopes, ","),
+		Rate:   rate,
+		Burst:  burst,
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.Info("api key %d created, save the key as it will not be shown again", apiKey.ID)
+	fmt.Println(apiKey.Key)
+	return nil
+}
+
+func apiKeyList(page, limit int) error {
+	s, cleanup, err := newCommandService(newInProcessPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	apiKeys, pagination, _, err := s.GetAPIKeys(context.Background(), service.GetAPIKeysRequest{
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSCOPES\tRATE\tBURST\tREQUEST\tLIMITED\tLAST USED AT")
+	for _, a := range apiKeys {
+		var lastUsedAt string
+		if a.LastUsedAt != nil {
+			lastUsedAt = a.LastUsedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%g\t%d\t%d\t%d\t%s\n", a.ID, a.Name, strings.Join(a.Scopes, ","), a.Rate, a.Burst, a.RequestCount, a.LimitedCount, lastUsedAt)
+	}
+	w.Flush()
+
+	utils.Info("page %d, showing %d of %d api keys", pagination.Page, len(apiKeys), pagination.Total)
+	return nil
+}
+
+func apiKeyDelete(arg string) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return errors.ErrInvalidAPIKeyID
+	}
+
+	s, cleanup, err := newCommandService(newInProcessPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, err := s.DeleteAPIKey(context.Background(), id); err != nil {
+		return err
+	}
+
+	utils.Info("done")
+	return nil
+}
@@ -1,31 +1,17 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
-	_consumer "github.com/rl404/akatsuki/internal/delivery/consumer"
-	animeRepository "github.com/rl404/akatsuki/internal/domain/anime/repository"
-	animeSQL "github.com/rl404/akatsuki/internal/domain/anime/repository/sql"
-	emptyIDRepository "github.com/rl404/akatsuki/internal/domain/empty_id/repository"
-	emptyIDSQL "github.com/rl404/akatsuki/internal/domain/empty_id/repository/sql"
-	genreRepository "github.com/rl404/akatsuki/internal/domain/genre/repository"
-	genreSQL "github.com/rl404/akatsuki/internal/domain/genre/repository/sql"
-	malRepository "github.com/rl404/akatsuki/internal/domain/mal/repository"
-	malClient "github.com/rl404/akatsuki/internal/domain/mal/repository/client"
-	publisherRepository "github.com/rl404/akatsuki/internal/domain/publisher/repository"
-	publisherPubsub "github.com/rl404/akatsuki/internal/domain/publisher/repository/pubsub"
-	studioRepository "github.com/rl404/akatsuki/internal/domain/studio/repository"
-	studioSQL "github.com/rl404/akatsuki/internal/domain/studio/repository/sql"
-	userAnimeRepository "github.com/rl404/akatsuki/internal/domain/user_anime/repository"
-	userAnimeSQL "github.com/rl404/akatsuki/internal/domain/user_anime/repository/sql"
-	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
-	"github.com/rl404/akatsuki/pkg/pubsub"
+	"github.com/rl404/akatsuki/pkg/cache"
 	_nr "github.com/rl404/fairy/log/newrelic"
+	nrCache "github.com/rl404/fairy/monitoring/newrelic/cache"
 	nrPS "github.com/rl404/fairy/monitoring/newrelic/pubsub"
 )
 
@@ -52,6 +38,33 @@ func consumer() error {
 		utils.Info("newrelic initialized")
 	}
 
+	// Init tracer.
+	tp, err := newTracer(cfg.Tracer)
+	if err != nil {
+		return err
+	}
+	defer tp.Shutdown(context.Background())
+	utils.Info("tracer initialized")
+
+	// Init cache.
+	c, err := cache.New(cacheType[cfg.Cache.Dialect], cfg.Cache.Address, cfg.Cache.Password, cfg.Cache.Time)
+	if err != nil {
+		return err
+	}
+	c = nrCache.New(cfg.Cache.Dialect, cfg.Cache.Address, c)
+	utils.Info("cache initialized")
+	defer c.Close()
+
+	// Init atomic cache.
+	ac, err := newAtomicCache(cfg.Cache)
+	if err != nil {
+		return err
+	}
+	if ac != nil {
+		utils.Info("atomic cache initialized")
+		defer ac.Close()
+	}
+
 	// Init db.
 	db, err := newDB(cfg.DB)
 	if err != nil {
@@ -62,7 +75,7 @@ func consumer() error {
 	defer tmp.Close()
 
 	// Init pubsub.
-	ps, err := pubsub.New(pubsubType[cfg.PubSub.Dialect], cfg.PubSub.Address, cfg.PubSub.Password)
+	ps, err := newPubsub(cfg.PubSub, db)
 	if err != nil {
 		return err
 	}
@@ -70,40 +83,15 @@ func consumer() error {
 	utils.Info("pubsub initialized")
 	defer ps.Close()
 
-	// Init anime.
-	var anime animeRepository.Repository = animeSQL.New(db, cfg.Cron.FinishedAge, cfg.Cron.ReleasingAge, cfg.Cron.NotYetAge)
-	utils.Info("repository anime initialized")
-
-	// Init genre.
-	var genre genreRepository.Repository = genreSQL.New(db)
-	utils.Info("repository genre initialized")
-
-	// Init studio.
-	var studio studioRepository.Repository = studioSQL.New(db)
-	utils.Info("repository studio initialized")
-
-	// Init user anime.
-	var userAnime userAnimeRepository.Repository = userAnimeSQL.New(db, cfg.Cron.UserAnimeAge)
-	utils.Info("repository user anime initialized")
-
-	// Init empty id.
-	var emptyID emptyIDRepository.Repository = emptyIDSQL.New(db)
-	utils.Info("repository empty id initialized")
-
-	// Init mal.
-	var mal malRepository.Repository = malClient.New(cfg.Mal.ClientID)
-	utils.Info("repository mal initialized")
-
-	// Init publisher.
-	var publisher publisherRepository.Repository = publisherPubsub.New(ps, pubsubTopic)
-	utils.Info("repository publisher initialized")
-
 	// Init service.
-	service := service.New(anime, genre, studio, userAnime, emptyID, publisher, mal)
-	utils.Info("service initialized")
+	service, closeService, err := newService(cfg, db, c, ac, ps, false)
+	if err != nil {
+		return err
+	}
+	defer closeService()
 
 	// Init consumer.
-	consumer := _consumer.New(service, ps, pubsubTopic)
+	consumer := newConsumer(cfg.Consumer, service, ps)
 	utils.Info("consumer initialized")
 	defer consumer.Close()
 
@@ -116,6 +104,13 @@ func consumer() error {
 	}
 
 	utils.Info("consumer ready")
+
+	// Run outbox relay.
+	outboxRelay := newOutboxRelay(cfg.Outbox, service)
+	outboxRelay.Run()
+	defer outboxRelay.Close()
+	utils.Info("outbox relay running")
+
 	<-sigChan
 
 	return nil
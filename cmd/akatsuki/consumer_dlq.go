@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+)
+
+func dlqList(page, limit int) error {
+	s, cleanup, err := newCommandService(newPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	deadLetters, pagination, _, err := s.GetDeadLetters(context.Background(), service.GetDeadLettersRequest{
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tANIME ID\tUSERNAME\tSTATUS\tATTEMPT\tCREATED AT\tERROR")
+	for _, d := range deadLetters {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%d\t%s\t%s\n", d.ID, d.Type, d.AnimeID, d.Username, d.Status, d.Attempt, d.CreatedAt.Format(time.RFC3339), d.Error)
+	}
+	w.Flush()
+
+	utils.Info("page %d, showing %d of %d dead letters", pagination.Page, len(deadLetters), pagination.Total)
+	return nil
+}
+
+func dlqReplay(args []string, all bool) error {
+	ids, err := parseDLQIDs(args, all)
+	if err != nil {
+		return err
+	}
+
+	s, cleanup, err := newCommandService(newPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cnt, _, err := s.ReplayDeadLetters(context.Background(), ids)
+	if err != nil {
+		return err
+	}
+
+	utils.Info("replayed %d dead letters", cnt)
+	return nil
+}
+
+func dlqPurge(args []string, all bool) error {
+	ids, err := parseDLQIDs(args, all)
+	if err != nil {
+		return err
+	}
+
+	s, cleanup, err := newCommandService(newPubsub)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, err := s.PurgeDeadLetters(context.Background(), ids); err != nil {
+		return err
+	}
+
+	utils.Info("done")
+	return nil
+}
+
+func parseDLQIDs(args []string, all bool) ([]int64, error) {
+	if all {
+		return nil, nil
+	}
+
+	if len(args) == 0 {
+		return nil, errors.ErrRequiredField("id (or use --all)")
+	}
+
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidFormat("id")
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
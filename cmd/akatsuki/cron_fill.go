@@ -1,26 +1,15 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rl404/akatsuki/internal/delivery/cron"
-	animeRepository "github.com/rl404/akatsuki/internal/domain/anime/repository"
-	animeSQL "github.com/rl404/akatsuki/internal/domain/anime/repository/sql"
-	emptyIDRepository "github.com/rl404/akatsuki/internal/domain/empty_id/repository"
-	emptyIDSQL "github.com/rl404/akatsuki/internal/domain/empty_id/repository/sql"
-	genreRepository "github.com/rl404/akatsuki/internal/domain/genre/repository"
-	genreSQL "github.com/rl404/akatsuki/internal/domain/genre/repository/sql"
-	malRepository "github.com/rl404/akatsuki/internal/domain/mal/repository"
-	malClient "github.com/rl404/akatsuki/internal/domain/mal/repository/client"
-	publisherRepository "github.com/rl404/akatsuki/internal/domain/publisher/repository"
-	publisherPubsub "github.com/rl404/akatsuki/internal/domain/publisher/repository/pubsub"
-	studioRepository "github.com/rl404/akatsuki/internal/domain/studio/repository"
-	studioSQL "github.com/rl404/akatsuki/internal/domain/studio/repository/sql"
-	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
-	"github.com/rl404/akatsuki/pkg/pubsub"
+	"github.com/rl404/akatsuki/pkg/cache"
 	_nr "github.com/rl404/fairy/log/newrelic"
+	nrCache "github.com/rl404/fairy/monitoring/newrelic/cache"
 	nrPS "github.com/rl404/fairy/monitoring/newrelic/pubsub"
 )
 
@@ -48,6 +37,33 @@ func cronFill() error {
 		utils.Info("newrelic initialized")
 	}
 
+	// Init tracer.
+	tp, err := newTracer(cfg.Tracer)
+	if err != nil {
+		return err
+	}
+	defer tp.Shutdown(context.Background())
+	utils.Info("tracer initialized")
+
+	// Init cache.
+	c, err := cache.New(cacheType[cfg.Cache.Dialect], cfg.Cache.Address, cfg.Cache.Password, cfg.Cache.Time)
+	if err != nil {
+		return err
+	}
+	c = nrCache.New(cfg.Cache.Dialect, cfg.Cache.Address, c)
+	utils.Info("cache initialized")
+	defer c.Close()
+
+	// Init atomic cache.
+	ac, err := newAtomicCache(cfg.Cache)
+	if err != nil {
+		return err
+	}
+	if ac != nil {
+		utils.Info("atomic cache initialized")
+		defer ac.Close()
+	}
+
 	// Init db.
 	db, err := newDB(cfg.DB)
 	if err != nil {
@@ -58,7 +74,7 @@ func cronFill() error {
 	defer tmp.Close()
 
 	// Init pubsub.
-	ps, err := pubsub.New(pubsubType[cfg.PubSub.Dialect], cfg.PubSub.Address, cfg.PubSub.Password)
+	ps, err := newPubsub(cfg.PubSub, db)
 	if err != nil {
 		return err
 	}
@@ -66,37 +82,16 @@ func cronFill() error {
 	utils.Info("pubsub initialized")
 	defer ps.Close()
 
-	// Init anime.
-	var anime animeRepository.Repository = animeSQL.New(db, cfg.Cron.FinishedAge, cfg.Cron.ReleasingAge, cfg.Cron.NotYetAge)
-	utils.Info("repository anime initialized")
-
-	// Init genre.
-	var genre genreRepository.Repository = genreSQL.New(db)
-	utils.Info("repository genre initialized")
-
-	// Init studio.
-	var studio studioRepository.Repository = studioSQL.New(db)
-	utils.Info("repository studio initialized")
-
-	// Init empty id.
-	var emptyID emptyIDRepository.Repository = emptyIDSQL.New(db)
-	utils.Info("repository empty id initialized")
-
-	// Init mal.
-	var mal malRepository.Repository = malClient.New(cfg.Mal.ClientID)
-	utils.Info("repository mal initialized")
-
-	// Init publisher.
-	var publisher publisherRepository.Repository = publisherPubsub.New(ps, pubsubTopic)
-	utils.Info("repository publisher initialized")
-
 	// Init service.
-	service := service.New(anime, genre, studio, nil, emptyID, publisher, mal)
-	utils.Info("service initialized")
+	service, closeService, err := newService(cfg, db, c, ac, ps, false)
+	if err != nil {
+		return err
+	}
+	defer closeService()
 
 	// Run cron.
 	utils.Info("filling missing data...")
-	if err := cron.New(service, nrApp).Fill(cfg.Cron.FillLimit); err != nil {
+	if _, err := cron.New(service, nrApp).Fill(context.Background(), cfg.Cron.FillLimit); err != nil {
 		return err
 	}
 
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/delivery/cron"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/cache"
+	_nr "github.com/rl404/fairy/log/newrelic"
+	nrCache "github.com/rl404/fairy/monitoring/newrelic/cache"
+	nrPS "github.com/rl404/fairy/monitoring/newrelic/pubsub"
+)
+
+func cronFranchise() error {
+	// Get config.
+	cfg, err := getConfig()
+	if err != nil {
+		return err
+	}
+	utils.Info("config initialized")
+
+	// Init newrelic.
+	nrApp, err := newrelic.NewApplication(
+		newrelic.ConfigAppName(cfg.Newrelic.Name),
+		newrelic.ConfigLicense(cfg.Newrelic.LicenseKey),
+		newrelic.ConfigDistributedTracerEnabled(true),
+		newrelic.ConfigAppLogForwardingEnabled(true),
+	)
+	if err != nil {
+		utils.Error(err.Error())
+	} else {
+		nrApp.WaitForConnection(10 * time.Second)
+		defer nrApp.Shutdown(10 * time.Second)
+		utils.AddLog(_nr.NewFromNewrelicApp(nrApp, _nr.LogLevel(cfg.Log.Level)))
+		utils.Info("newrelic initialized")
+	}
+
+	// Init tracer.
+	tp, err := newTracer(cfg.Tracer)
+	if err != nil {
+		return err
+	}
+	defer tp.Shutdown(context.Background())
+	utils.Info("tracer initialized")
+
+	// Init cache.
+	c, err := cache.New(cacheType[cfg.Cache.Dialect], cfg.Cache.Address, cfg.Cache.Password, cfg.Cache.Time)
+	if err != nil {
+		return err
+	}
+	c = nrCache.New(cfg.Cache.Dialect, cfg.Cache.Address, c)
+	utils.Info("cache initialized")
+	defer c.Close()
+
+	// Init atomic cache.
+	ac, err := newAtomicCache(cfg.Cache)
+	if err != nil {
+		return err
+	}
+	if ac != nil {
+		utils.Info("atomic cache initialized")
+		defer ac.Close()
+	}
+
+	// Init db.
+	db, err := newDB(cfg.DB)
+	if err != nil {
+		return err
+	}
+	utils.Info("database initialized")
+	tmp, _ := db.DB()
+	defer tmp.Close()
+
+	// Init pubsub.
+	ps, err := newPubsub(cfg.PubSub, db)
+	if err != nil {
+		return err
+	}
+	ps = nrPS.New(cfg.PubSub.Dialect, ps, nrApp)
+	utils.Info("pubsub initialized")
+	defer ps.Close()
+
+	// Init service.
+	service, closeService, err := newService(cfg, db, c, ac, ps, false)
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	// Run cron.
+	utils.Info("updating franchises...")
+	if _, err := cron.New(service, nrApp).Franchise(context.Background()); err != nil {
+		return err
+	}
+
+	utils.Info("done")
+	return nil
+}
@@ -11,6 +11,10 @@ import (
 // @description Akatsuki API.
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey APIKey
+// @in header
+// @name Authorization
+// @description API key as "Bearer <key>".
 func main() {
 	cmd := cobra.Command{
 		Use:   "akatsuki",
@@ -33,13 +37,55 @@ func main() {
 		},
 	})
 
-	cmd.AddCommand(&cobra.Command{
+	consumerCmd := cobra.Command{
 		Use:   "consumer",
 		Short: "Run message consumer",
 		RunE: func(*cobra.Command, []string) error {
 			return consumer()
 		},
-	})
+	}
+
+	dlqCmd := cobra.Command{
+		Use:   "dlq",
+		Short: "Manage dead letter queue",
+	}
+
+	var page, limit int
+	dlqListCmd := cobra.Command{
+		Use:   "list",
+		Short: "List failed messages",
+		RunE: func(*cobra.Command, []string) error {
+			return dlqList(page, limit)
+		},
+	}
+	dlqListCmd.Flags().IntVar(&page, "page", 1, "page")
+	dlqListCmd.Flags().IntVar(&limit, "limit", 20, "limit")
+	dlqCmd.AddCommand(&dlqListCmd)
+
+	var replayAll bool
+	dlqReplayCmd := cobra.Command{
+		Use:   "replay [id...]",
+		Short: "Requeue failed messages",
+		RunE: func(_ *cobra.Command, args []string) error {
+			return dlqReplay(args, replayAll)
+		},
+	}
+	dlqReplayCmd.Flags().BoolVar(&replayAll, "all", false, "replay all failed messages")
+	dlqCmd.AddCommand(&dlqReplayCmd)
+
+	var purgeAll bool
+	dlqPurgeCmd := cobra.Command{
+		Use:   "purge [id...]",
+		Short: "Delete failed messages",
+		RunE: func(_ *cobra.Command, args []string) error {
+			return dlqPurge(args, purgeAll)
+		},
+	}
+	dlqPurgeCmd.Flags().BoolVar(&purgeAll, "all", false, "delete all failed messages")
+	dlqCmd.AddCommand(&dlqPurgeCmd)
+
+	consumerCmd.AddCommand(&dlqCmd)
+	cmd.AddCommand(&consumerCmd)
 
 	cronCmd := cobra.Command{
 		Use:   "cron",
@@ -62,8 +108,99 @@ func main() {
 		},
 	})
 
+	cronCmd.AddCommand(&cobra.Command{
+		Use:   "airing",
+		Short: "Refresh recently aired anime",
+		RunE: func(*cobra.Command, []string) error {
+			return cronAiring()
+		},
+	})
+
+	cronCmd.AddCommand(&cobra.Command{
+		Use:   "recommendation",
+		Short: "Update anime recommendations",
+		RunE: func(*cobra.Command, []string) error {
+			return cronRecommendation()
+		},
+	})
+
+	cronCmd.AddCommand(&cobra.Command{
+		Use:   "franchise",
+		Short: "Update anime franchises",
+		RunE: func(*cobra.Command, []string) error {
+			return cronFranchise()
+		},
+	})
+
+	cronCmd.AddCommand(&cobra.Command{
+		Use:   "trending",
+		Short: "Update trending anime",
+		RunE: func(*cobra.Command, []string) error {
+			return cronTrending()
+		},
+	})
+
 	cmd.AddCommand(&cronCmd)
 
+	cmd.AddCommand(&cobra.Command{
+		Use:   "scheduler",
+		Short: "Run cron jobs on schedule",
+		RunE: func(*cobra.Command, []string) error {
+			return scheduler()
+		},
+	})
+
+	apiKeyCmd := cobra.Command{
+		Use:   "apikey",
+		Short: "Manage api keys",
+	}
+
+	var name, scopes string
+	var rate float64
+	var burst int
+	apiKeyCreateCmd := cobra.Command{
+		Use:   "create",
+		Short: "Create api key",
+		RunE: func(*cobra.Command, []string) error {
+			return apiKeyCreate(name, scopes, rate, burst)
+		},
+	}
+	apiKeyCreateCmd.Flags().StringVar(&name, "name", "", "key name")
+	apiKeyCreateCmd.Flags().StringVar(&scopes, "scopes", "READ", "comma separated scopes (READ,TRIGGER_UPDATE,ADMIN)")
+	apiKeyCreateCmd.Flags().Float64Var(&rate, "rate", 5, "request per second")
+	apiKeyCreateCmd.Flags().IntVar(&burst, "burst", 10, "burst")
+	apiKeyCmd.AddCommand(&apiKeyCreateCmd)
+
+	apiKeyListCmd := cobra.Command{
+		Use:   "list",
+		Short: "List api keys and their usage",
+		RunE: func(*cobra.Command, []string) error {
+			return apiKeyList(page, limit)
+		},
+	}
+	apiKeyListCmd.Flags().IntVar(&page, "page", 1, "page")
+	apiKeyListCmd.Flags().IntVar(&limit, "limit", 20, "limit")
+	apiKeyCmd.AddCommand(&apiKeyListCmd)
+
+	apiKeyCmd.AddCommand(&cobra.Command{
+		Use:   "delete [id]",
+		Short: "Delete api key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return apiKeyDelete(args[0])
+		},
+	})
+
+	cmd.AddCommand(&apiKeyCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "all-in-one",
+		Short: "Run API server, consumer and scheduler in one process",
+		RunE: func(*cobra.Command, []string) error {
+			return allInOne()
+		},
+	})
+
 	if err := cmd.Execute(); err != nil {
 		utils.Fatal(err.Error())
 	}
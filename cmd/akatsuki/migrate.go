@@ -2,11 +2,24 @@ package main
 
 import (
 	animeSQL "github.com/rl404/akatsuki/internal/domain/anime/repository/sql"
+	apiKeySQL "github.com/rl404/akatsuki/internal/domain/api_key/repository/sql"
+	cooldownSQL "github.com/rl404/akatsuki/internal/domain/cooldown/repository/sql"
+	deadLetterSQL "github.com/rl404/akatsuki/internal/domain/dead_letter/repository/sql"
 	emptyIDSQL "github.com/rl404/akatsuki/internal/domain/empty_id/repository/sql"
+	franchiseSQL "github.com/rl404/akatsuki/internal/domain/franchise/repository/sql"
 	genreSQL "github.com/rl404/akatsuki/internal/domain/genre/repository/sql"
+	inFlightSQL "github.com/rl404/akatsuki/internal/domain/in_flight/repository/sql"
+	jobHistorySQL "github.com/rl404/akatsuki/internal/domain/job_history/repository/sql"
+	outboxSQL "github.com/rl404/akatsuki/internal/domain/outbox/repository/sql"
+	recommendationSQL "github.com/rl404/akatsuki/internal/domain/recommendation/repository/sql"
 	studioSQL "github.com/rl404/akatsuki/internal/domain/studio/repository/sql"
+	syncStatusSQL "github.com/rl404/akatsuki/internal/domain/sync_status/repository/sql"
+	trendingSQL "github.com/rl404/akatsuki/internal/domain/trending/repository/sql"
 	userAnimeSQL "github.com/rl404/akatsuki/internal/domain/user_anime/repository/sql"
 	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/leader"
+	sqlLimit "github.com/rl404/akatsuki/pkg/limit/sql"
+	pubsubSQL "github.com/rl404/akatsuki/pkg/pubsub/sql"
 )
 
 func migrate() error {
@@ -39,6 +52,21 @@ func migrate() error {
 		studioSQL.Studio{},
 		userAnimeSQL.UserAnime{},
 		emptyIDSQL.EmptyID{},
+		deadLetterSQL.DeadLetter{},
+		inFlightSQL.InFlight{},
+		inFlightSQL.InFlightStat{},
+		outboxSQL.Outbox{},
+		sqlLimit.RateLimit{},
+		jobHistorySQL.JobHistory{},
+		syncStatusSQL.SyncStatus{},
+		apiKeySQL.APIKey{},
+		cooldownSQL.Cooldown{},
+		recommendationSQL.AnimeSimilarity{},
+		franchiseSQL.Franchise{},
+		franchiseSQL.FranchiseAnime{},
+		trendingSQL.AnimeTrending{},
+		leader.Lock{},
+		pubsubSQL.PubsubMessage{},
 	); err != nil {
 		return err
 	}
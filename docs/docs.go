@@ -15,16 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/anime": {
-            "get": {
+        "/admin/anime/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Queue anime in id range (max 1000 ids) or anime list filter result.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime"
+                    "Admin"
                 ],
-                "summary": "Get anime list.",
+                "summary": "Force update anime in bulk.",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "start anime id",
+                        "name": "start_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end anime id",
+                        "name": "end_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "title",
@@ -164,8 +182,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "allOf": [
                                 {
@@ -175,13 +193,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/service.Anime"
-                                            }
-                                        },
-                                        "meta": {
-                                            "$ref": "#/definitions/service.Pagination"
+                                            "type": "integer"
                                         }
                                     }
                                 }
@@ -194,6 +206,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -203,15 +233,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/anime/{animeID}": {
+        "/admin/anime/{animeID}/sync": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime"
+                    "Admin"
                 ],
-                "summary": "Get anime by id.",
+                "summary": "Get anime sync status.",
                 "parameters": [
                     {
                         "type": "integer",
@@ -233,21 +268,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/service.Anime"
+                                            "$ref": "#/definitions/service.SyncStatus"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "202": {
-                        "description": "Accepted",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -258,6 +299,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -267,45 +314,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/anime/{animeID}/history": {
+        "/admin/api-keys": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Including their usage.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime"
+                    "Admin"
                 ],
-                "summary": "Get anime stats histories by id.",
+                "summary": "Get api keys.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "anime id",
-                        "name": "animeID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "start date (yyyy-mm-dd)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "end date (yyyy-mm-dd)",
-                        "name": "end_date",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "WEEKLY",
-                            "MONTHLY",
-                            "YEARLY"
-                        ],
-                        "type": "string",
-                        "default": "MONTHLY",
-                        "description": "group",
-                        "name": "group",
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -323,8 +359,11 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/service.AnimeHistory"
+                                                "$ref": "#/definitions/service.APIKey"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
                                         }
                                     }
                                 }
@@ -337,54 +376,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/anime/{animeID}/update": {
-            "post": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Anime"
-                ],
-                "summary": "Update anime by id.",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "anime id",
-                        "name": "animeID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -396,59 +401,54 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/genres": {
-            "get": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "The key is only shown once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Genre"
+                    "Admin"
                 ],
-                "summary": "Get genre list.",
+                "summary": "Create api key.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "name",
+                        "description": "key name",
                         "name": "name",
-                        "in": "query"
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "NAME",
-                            "-NAME",
-                            "COUNT",
-                            "-COUNT",
-                            "MEAN",
-                            "-MEAN",
-                            "MEMBER",
-                            "-MEMBER"
-                        ],
                         "type": "string",
-                        "default": "NAME",
-                        "description": "sort",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "comma separated scopes (READ,TRIGGER_UPDATE,ADMIN)",
+                        "name": "scopes",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
+                        "type": "number",
+                        "default": 5,
+                        "description": "request per second",
+                        "name": "rate",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "limit",
-                        "name": "limit",
+                        "default": 10,
+                        "description": "burst",
+                        "name": "burst",
                         "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -458,13 +458,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/service.Genre"
-                                            }
-                                        },
-                                        "meta": {
-                                            "$ref": "#/definitions/service.Pagination"
+                                            "$ref": "#/definitions/service.APIKey"
                                         }
                                     }
                                 }
@@ -477,6 +471,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -486,20 +498,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/genres/{genreID}": {
-            "get": {
+        "/admin/api-keys/{apiKeyID}": {
+            "delete": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Genre"
+                    "Admin"
                 ],
-                "summary": "Get genre by id.",
+                "summary": "Delete api key.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "genre id",
-                        "name": "genreID",
+                        "description": "api key id",
+                        "name": "apiKeyID",
                         "in": "path",
                         "required": true
                     }
@@ -508,19 +525,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/service.Genre"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "400": {
@@ -529,12 +534,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -544,44 +567,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/genres/{genreID}/history": {
+        "/admin/empty-ids": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Anime ids not found in MyAnimeList. Oldest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Genre"
+                    "Admin"
                 ],
-                "summary": "Get genre stats histories by id.",
+                "summary": "Get empty anime ids.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "genre id",
-                        "name": "genreID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "start year",
-                        "name": "start_year",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "end year",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "MONTHLY",
-                            "YEARLY"
-                        ],
-                        "type": "string",
-                        "default": "MONTHLY",
-                        "description": "group",
-                        "name": "group",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -599,8 +612,11 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/service.GenreHistory"
+                                                "$ref": "#/definitions/service.EmptyID"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
                                         }
                                     }
                                 }
@@ -613,96 +629,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/studios": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Studio"
-                ],
-                "summary": "Get studio list.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "NAME",
-                            "-NAME",
-                            "COUNT",
-                            "-COUNT",
-                            "MEAN",
-                            "-MEAN",
-                            "MEMBER",
-                            "-MEMBER"
-                        ],
-                        "type": "string",
-                        "default": "NAME",
-                        "description": "sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/service.Studio"
-                                            }
-                                        },
-                                        "meta": {
-                                            "$ref": "#/definitions/service.Pagination"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -714,23 +654,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/studios/{studioID}": {
-            "get": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Delete empty anime ids older than n days so they will be filled again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Studio"
+                    "Admin"
                 ],
-                "summary": "Get studio by id.",
+                "summary": "Expire old empty anime ids.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "studio id",
-                        "name": "studioID",
-                        "in": "path",
+                        "description": "days",
+                        "name": "days",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -746,7 +690,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/service.Studio"
+                                            "type": "integer"
                                         }
                                     }
                                 }
@@ -759,8 +703,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -774,50 +730,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/studios/{studioID}/history": {
-            "get": {
+        "/admin/empty-ids/recheck": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Queue oldest empty anime ids to be parsed again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Studio"
+                    "Admin"
                 ],
-                "summary": "Get studio stats histories by id.",
+                "summary": "Recheck empty anime ids.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "studio id",
-                        "name": "studioID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "start year",
-                        "name": "start_year",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "end year",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "MONTHLY",
-                            "YEARLY"
-                        ],
-                        "type": "string",
-                        "default": "MONTHLY",
-                        "description": "group",
-                        "name": "group",
+                        "default": 100,
+                        "description": "limit",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "allOf": [
                                 {
@@ -827,10 +766,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/service.StudioHistory"
-                                            }
+                                            "type": "integer"
                                         }
                                     }
                                 }
@@ -843,8 +779,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -858,22 +806,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{username}/anime": {
+        "/admin/jobs": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Anime"
+                    "Admin"
                 ],
-                "summary": "Get user's anime.",
+                "summary": "Get scheduled job runs.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
+                        "description": "job name",
+                        "name": "name",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -904,7 +856,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/service.UserAnime"
+                                                "$ref": "#/definitions/service.Job"
                                             }
                                         },
                                         "meta": {
@@ -915,20 +867,26 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "202": {
-                        "description": "Accepted",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -942,24 +900,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{username}/anime/relations": {
+        "/admin/queue": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Anime"
-                ],
-                "summary": "Get user's anime relations.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Queue"
                 ],
+                "summary": "Get queue stats.",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -972,27 +926,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/service.UserAnimeRelation"
+                                            "$ref": "#/definitions/service.QueueStats"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "202": {
-                        "description": "Accepted",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1006,42 +960,272 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{username}/update": {
-            "post": {
+        "/anime": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Anime"
+                    "Anime"
                 ],
-                "summary": "Update user's anime.",
+                "summary": "Get anime list.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "202": {
-                        "description": "Accepted",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
+                        "description": "title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "nsfw",
+                        "name": "nsfw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "TV",
+                            "OVA",
+                            "ONA",
+                            "MOVIE",
+                            "SPECIAL",
+                            "MUSIC",
+                            "CM",
+                            "PV",
+                            "TV_SPECIAL"
+                        ],
+                        "type": "string",
+                        "description": "type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "FINISHED",
+                            "RELEASING",
+                            "NOT_YET"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "WINTER",
+                            "SPRING",
+                            "SUMMER",
+                            "FALL"
+                        ],
+                        "type": "string",
+                        "description": "season",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "season year",
+                        "name": "season_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "start mean",
+                        "name": "start_mean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "end mean",
+                        "name": "end_mean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "start airing year",
+                        "name": "start_airing_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "end airing year",
+                        "name": "end_airing_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "genre id",
+                        "name": "genre_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "studio id",
+                        "name": "studio_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ID",
+                            "-ID",
+                            "TITLE",
+                            "-TITLE",
+                            "START_DATE",
+                            "-START_DATE",
+                            "MEAN",
+                            "-MEAN",
+                            "RANK",
+                            "-RANK",
+                            "POPULARITY",
+                            "-POPULARITY",
+                            "MEMBER",
+                            "-MEMBER",
+                            "VOTER",
+                            "-VOTER"
+                        ],
+                        "type": "string",
+                        "default": "RANK",
+                        "description": "sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.Anime"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/anime/trending": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Get trending anime.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "7d",
+                            "30d"
+                        ],
+                        "type": "string",
+                        "default": "7d",
+                        "description": "window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MEMBER",
+                            "RANK",
+                            "MEAN"
+                        ],
+                        "type": "string",
+                        "default": "MEMBER",
+                        "description": "sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.TrendingAnime"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1051,241 +1235,2811 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "service.AlternativeTitle": {
+        },
+        "/anime/{animeID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Get anime by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "anime id",
+                        "name": "animeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Anime"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/anime/{animeID}/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Get anime stats histories by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "anime id",
+                        "name": "animeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start date (yyyy-mm-dd)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end date (yyyy-mm-dd)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "WEEKLY",
+                            "MONTHLY",
+                            "YEARLY"
+                        ],
+                        "type": "string",
+                        "default": "MONTHLY",
+                        "description": "group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.AnimeHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/anime/{animeID}/update": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Update anime by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "anime id",
+                        "name": "animeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Cooldown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/anime/{animeID}/watch-order": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Get watch order of anime franchise.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "anime id",
+                        "name": "animeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "RELEASE",
+                            "CHRONOLOGICAL"
+                        ],
+                        "type": "string",
+                        "default": "CHRONOLOGICAL",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.WatchOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/franchises": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Franchise"
+                ],
+                "summary": "Get franchise list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.Franchise"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/franchises/{franchiseID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Franchise"
+                ],
+                "summary": "Get franchise by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "franchise id",
+                        "name": "franchiseID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.FranchiseDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/franchises/{franchiseID}/watch-order": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Franchise"
+                ],
+                "summary": "Get franchise watch order.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "franchise id",
+                        "name": "franchiseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "RELEASE",
+                            "CHRONOLOGICAL"
+                        ],
+                        "type": "string",
+                        "default": "CHRONOLOGICAL",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.WatchOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genre"
+                ],
+                "summary": "Get genre list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NAME",
+                            "-NAME",
+                            "COUNT",
+                            "-COUNT",
+                            "MEAN",
+                            "-MEAN",
+                            "MEMBER",
+                            "-MEMBER"
+                        ],
+                        "type": "string",
+                        "default": "NAME",
+                        "description": "sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.Genre"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{genreID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genre"
+                ],
+                "summary": "Get genre by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "genre id",
+                        "name": "genreID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Genre"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{genreID}/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genre"
+                ],
+                "summary": "Get genre stats histories by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "genre id",
+                        "name": "genreID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start year",
+                        "name": "start_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end year",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MONTHLY",
+                            "YEARLY"
+                        ],
+                        "type": "string",
+                        "default": "MONTHLY",
+                        "description": "group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.GenreHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/queue/stats": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get queue stats.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.QueueStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get weekly broadcast schedule.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Asia/Tokyo",
+                        "description": "timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only anime in user's watching list",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Schedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule.ics": {
+            "get": {
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get broadcast schedule calendar.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "api key with READ scope, for clients that can't set header",
+                        "name": "key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Season"
+                ],
+                "summary": "Get season list.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.SeasonCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/{season}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Season"
+                ],
+                "summary": "Get season anime and summary.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "WINTER",
+                            "SPRING",
+                            "SUMMER",
+                            "FALL"
+                        ],
+                        "type": "string",
+                        "description": "season",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.SeasonDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/studios": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Studio"
+                ],
+                "summary": "Get studio list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NAME",
+                            "-NAME",
+                            "COUNT",
+                            "-COUNT",
+                            "MEAN",
+                            "-MEAN",
+                            "MEMBER",
+                            "-MEMBER"
+                        ],
+                        "type": "string",
+                        "default": "NAME",
+                        "description": "sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.Studio"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/studios/{studioID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Studio"
+                ],
+                "summary": "Get studio by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "studio id",
+                        "name": "studioID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Studio"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/studios/{studioID}/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Studio"
+                ],
+                "summary": "Get studio stats histories by id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "studio id",
+                        "name": "studioID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start year",
+                        "name": "start_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end year",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MONTHLY",
+                            "YEARLY"
+                        ],
+                        "type": "string",
+                        "default": "MONTHLY",
+                        "description": "group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.StudioHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/anime": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Get user's anime.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "WATCHING",
+                            "COMPLETED",
+                            "ON_HOLD",
+                            "DROPPED",
+                            "PLANNED"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start score",
+                        "name": "start_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end score",
+                        "name": "end_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "is rewatching",
+                        "name": "is_rewatching",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "anime title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "TV",
+                            "OVA",
+                            "ONA",
+                            "MOVIE",
+                            "SPECIAL",
+                            "MUSIC",
+                            "CM",
+                            "PV",
+                            "TV_SPECIAL"
+                        ],
+                        "type": "string",
+                        "description": "anime type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "WINTER",
+                            "SPRING",
+                            "SUMMER",
+                            "FALL"
+                        ],
+                        "type": "string",
+                        "description": "anime season",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "anime season year",
+                        "name": "season_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "anime genre id",
+                        "name": "genre_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "SCORE",
+                            "-SCORE",
+                            "UPDATED_AT",
+                            "-UPDATED_AT",
+                            "PROGRESS",
+                            "-PROGRESS",
+                            "TITLE",
+                            "-TITLE"
+                        ],
+                        "type": "string",
+                        "default": "-UPDATED_AT",
+                        "description": "sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.UserAnime"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/service.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/anime/relations": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Get user's anime relations.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated relation types, e.g. SEQUEL,PREQUEL",
+                        "name": "relations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "max depth",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5000,
+                        "description": "max nodes",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.UserAnimeRelation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/compare/{username2}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Compare 2 users' anime.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "other username",
+                        "name": "username2",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.UserAnimeCompare"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/recommendations": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Get user's anime recommendations.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.Recommendation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/schedule.ics": {
+            "get": {
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get user's watching anime broadcast schedule calendar.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "api key with READ scope, for clients that can't set header",
+                        "name": "key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Get user's anime stats.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.UserAnimeStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{username}/update": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Anime"
+                ],
+                "summary": "Update user's anime.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Cooldown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "service.APIKey": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "limited_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "request_count": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.AlternativeTitle": {
+            "type": "object",
+            "properties": {
+                "english": {
+                    "type": "string"
+                },
+                "japanese": {
+                    "type": "string"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.Anime": {
+            "type": "object",
+            "properties": {
+                "alternative_titles": {
+                    "$ref": "#/definitions/service.AlternativeTitle"
+                },
+                "background": {
+                    "type": "string"
+                },
+                "broadcast": {
+                    "$ref": "#/definitions/service.Broadcast"
+                },
+                "end_date": {
+                    "$ref": "#/definitions/service.Date"
+                },
+                "episode": {
+                    "$ref": "#/definitions/service.Episode"
+                },
+                "franchise_id": {
+                    "type": "integer"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AnimeGenre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "nsfw": {
+                    "type": "boolean"
+                },
+                "picture": {
+                    "type": "string"
+                },
+                "pictures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "popularity": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "related": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AnimeRelated"
+                    }
+                },
+                "season": {
+                    "$ref": "#/definitions/service.Season"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "$ref": "#/definitions/service.Date"
+                },
+                "stats": {
+                    "$ref": "#/definitions/service.Stats"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "studios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AnimeStudio"
+                    }
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "voter": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.AnimeGenre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AnimeHistory": {
+            "type": "object",
+            "properties": {
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "popularity": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "user_completed": {
+                    "type": "integer"
+                },
+                "user_dropped": {
+                    "type": "integer"
+                },
+                "user_on_hold": {
+                    "type": "integer"
+                },
+                "user_planned": {
+                    "type": "integer"
+                },
+                "user_watching": {
+                    "type": "integer"
+                },
+                "voter": {
+                    "type": "integer"
+                },
+                "week": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.AnimeRelated": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "picture": {
+                    "type": "string"
+                },
+                "relation": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AnimeStudio": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Broadcast": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Cooldown": {
+            "type": "object",
+            "properties": {
+                "retry_after": {
+                    "description": "Seconds until the data can be updated again.",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "Last time the data was fetched from MyAnimeList.",
+                    "type": "string"
+                }
+            }
+        },
+        "service.Date": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.EmptyID": {
+            "type": "object",
+            "properties": {
+                "anime_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Episode": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.Franchise": {
+            "type": "object",
+            "properties": {
+                "anime_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.FranchiseDetail": {
+            "type": "object",
+            "properties": {
+                "anime": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.franchiseAnime"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Genre": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.GenreHistory": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "popularity": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "voter": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.Job": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.QueueStats": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "Published but not finished message count per type.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "processed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SyncStats"
+                    }
+                },
+                "suppressed": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "service.Recommendation": {
+            "type": "object",
+            "properties": {
+                "anime_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Schedule": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ScheduleDay"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ScheduleDay": {
+            "type": "object",
+            "properties": {
+                "anime": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.scheduleAnime"
+                    }
+                },
+                "day": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Season": {
+            "type": "object",
+            "properties": {
+                "season": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.SeasonCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.SeasonDetail": {
+            "type": "object",
+            "properties": {
+                "anime": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.seasonAnime"
+                    }
+                },
+                "carry_overs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.seasonAnime"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "mean_score": {
+                    "type": "number"
+                },
+                "previous_season": {
+                    "$ref": "#/definitions/service.Season"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.seasonCount"
+                    }
+                },
+                "studios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.seasonStudio"
+                    }
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.seasonCount"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.Stats": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/service.StatsStatus"
+                }
+            }
+        },
+        "service.StatsStatus": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "dropped": {
+                    "type": "integer"
+                },
+                "on_hold": {
+                    "type": "integer"
+                },
+                "planned": {
+                    "type": "integer"
+                },
+                "watching": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.Studio": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.StudioHistory": {
             "type": "object",
             "properties": {
-                "english": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "japanese": {
-                    "type": "string"
+                "mean": {
+                    "type": "number"
                 },
-                "synonyms": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "member": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "popularity": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "voter": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "service.Anime": {
+        "service.SyncStats": {
             "type": "object",
             "properties": {
-                "alternative_titles": {
-                    "$ref": "#/definitions/service.AlternativeTitle"
+                "attempt": {
+                    "type": "integer"
                 },
-                "background": {
-                    "type": "string"
+                "failed": {
+                    "type": "integer"
                 },
-                "broadcast": {
-                    "$ref": "#/definitions/service.Broadcast"
+                "total": {
+                    "type": "integer"
                 },
-                "end_date": {
-                    "$ref": "#/definitions/service.Date"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.SyncStatus": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
                 },
-                "episode": {
-                    "$ref": "#/definitions/service.Episode"
+                "last_attempt_at": {
+                    "type": "string"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/service.AnimeGenre"
-                    }
+                "last_error": {
+                    "type": "string"
                 },
-                "id": {
+                "last_success_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.TrendingAnime": {
+            "type": "object",
+            "properties": {
+                "anime_id": {
                     "type": "integer"
                 },
                 "mean": {
                     "type": "number"
                 },
+                "mean_change": {
+                    "type": "number"
+                },
                 "member": {
                     "type": "integer"
                 },
-                "nsfw": {
-                    "type": "boolean"
+                "member_gain": {
+                    "type": "integer"
                 },
                 "picture": {
                     "type": "string"
                 },
-                "pictures": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "rank": {
+                    "type": "integer"
                 },
-                "popularity": {
+                "rank_change": {
                     "type": "integer"
                 },
-                "rank": {
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.UserAnime": {
+            "type": "object",
+            "properties": {
+                "anime_id": {
                     "type": "integer"
                 },
-                "rating": {
+                "comment": {
                     "type": "string"
                 },
-                "related": {
+                "episode": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/service.AnimeRelated"
+                        "type": "string"
                     }
                 },
-                "season": {
-                    "$ref": "#/definitions/service.Season"
-                },
-                "source": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "service.UserAnimeCompare": {
+            "type": "object",
+            "properties": {
+                "affinity": {
+                    "type": "number"
                 },
-                "start_date": {
-                    "$ref": "#/definitions/service.Date"
-                },
-                "stats": {
-                    "$ref": "#/definitions/service.Stats"
+                "genre_differences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeCompareGenre"
+                    }
                 },
-                "status": {
-                    "type": "string"
+                "only_user1": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "studios": {
+                "only_user2": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/service.AnimeStudio"
+                        "type": "integer"
                     }
                 },
-                "synopsis": {
-                    "type": "string"
+                "score_correlation": {
+                    "type": "number"
                 },
-                "title": {
-                    "type": "string"
+                "score_mean_difference": {
+                    "type": "number"
                 },
-                "type": {
+                "shared": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeCompareShared"
+                    }
+                },
+                "username1": {
                     "type": "string"
                 },
-                "voter": {
-                    "type": "integer"
+                "username2": {
+                    "type": "string"
                 }
             }
         },
-        "service.AnimeGenre": {
+        "service.UserAnimeRelation": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeRelationLink"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeRelationNode"
+                    }
+                },
+                "truncated": {
+                    "type": "boolean"
                 }
             }
         },
-        "service.AnimeHistory": {
+        "service.UserAnimeStats": {
             "type": "object",
             "properties": {
-                "mean": {
+                "completion_rate": {
                     "type": "number"
                 },
-                "member": {
-                    "type": "integer"
+                "genres_by_count": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsItem"
+                    }
                 },
-                "month": {
-                    "type": "integer"
+                "genres_by_score": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsItem"
+                    }
                 },
-                "popularity": {
-                    "type": "integer"
+                "mean_score": {
+                    "type": "number"
                 },
-                "rank": {
-                    "type": "integer"
+                "score_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsScore"
+                    }
                 },
-                "user_completed": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/service.userAnimeStatsStatus"
                 },
-                "user_dropped": {
-                    "type": "integer"
+                "studios_by_count": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsItem"
+                    }
                 },
-                "user_on_hold": {
-                    "type": "integer"
+                "studios_by_score": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsItem"
+                    }
                 },
-                "user_planned": {
+                "total_duration": {
                     "type": "integer"
                 },
-                "user_watching": {
+                "total_episode": {
                     "type": "integer"
                 },
-                "voter": {
-                    "type": "integer"
+                "years": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.userAnimeStatsYear"
+                    }
+                }
+            }
+        },
+        "service.WatchOrder": {
+            "type": "object",
+            "properties": {
+                "anime": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.watchOrderAnime"
+                    }
                 },
-                "week": {
+                "franchise_id": {
                     "type": "integer"
                 },
-                "year": {
+                "order": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.franchiseAnime": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "integer"
+                },
+                "picture": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "$ref": "#/definitions/service.Date"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "service.AnimeRelated": {
+        "service.scheduleAnime": {
             "type": "object",
             "properties": {
+                "episode": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
                 "picture": {
                     "type": "string"
                 },
-                "relation": {
+                "time": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "service.AnimeStudio": {
+        "service.seasonAnime": {
             "type": "object",
             "properties": {
+                "episode_count": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "mean": {
+                    "type": "number"
+                },
+                "member": {
+                    "type": "integer"
+                },
+                "picture": {
                     "type": "string"
-                }
-            }
-        },
-        "service.Broadcast": {
-            "type": "object",
-            "properties": {
-                "day": {
+                },
+                "source": {
                     "type": "string"
                 },
-                "time": {
+                "start_date": {
+                    "$ref": "#/definitions/service.Date"
+                },
+                "status": {
                     "type": "string"
-                }
-            }
-        },
-        "service.Date": {
-            "type": "object",
-            "properties": {
-                "day": {
-                    "type": "integer"
                 },
-                "month": {
-                    "type": "integer"
+                "title": {
+                    "type": "string"
                 },
-                "year": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "service.Episode": {
+        "service.seasonCount": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "duration": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "service.Genre": {
+        "service.seasonStudio": {
             "type": "object",
             "properties": {
                 "count": {
@@ -1294,100 +4048,113 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "mean": {
-                    "type": "number"
-                },
-                "member": {
-                    "type": "integer"
-                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "service.GenreHistory": {
+        "service.userAnimeCompareGenre": {
             "type": "object",
             "properties": {
-                "count": {
+                "count1": {
                     "type": "integer"
                 },
-                "mean": {
-                    "type": "number"
-                },
-                "member": {
+                "count2": {
                     "type": "integer"
                 },
-                "month": {
-                    "type": "integer"
+                "difference": {
+                    "type": "number"
                 },
-                "popularity": {
+                "id": {
                     "type": "integer"
                 },
-                "rank": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "voter": {
-                    "type": "integer"
+                "ratio1": {
+                    "type": "number"
                 },
-                "year": {
-                    "type": "integer"
+                "ratio2": {
+                    "type": "number"
                 }
             }
         },
-        "service.Pagination": {
+        "service.userAnimeCompareShared": {
             "type": "object",
             "properties": {
-                "limit": {
+                "anime_id": {
                     "type": "integer"
                 },
-                "page": {
+                "score1": {
                     "type": "integer"
                 },
-                "total": {
+                "score2": {
                     "type": "integer"
                 }
             }
         },
-        "service.Season": {
+        "service.userAnimeRelationLink": {
             "type": "object",
             "properties": {
-                "season": {
-                    "type": "string"
+                "anime_id1": {
+                    "type": "integer"
                 },
-                "year": {
+                "anime_id2": {
                     "type": "integer"
+                },
+                "relation": {
+                    "type": "string"
                 }
             }
         },
-        "service.Stats": {
-            "type": "object",
-            "properties": {
-                "status": {
-                    "$ref": "#/definitions/service.StatsStatus"
-                }
-            }
-        },
-        "service.StatsStatus": {
+        "service.userAnimeRelationNode": {
             "type": "object",
             "properties": {
-                "completed": {
+                "anime_id": {
                     "type": "integer"
                 },
-                "dropped": {
+                "episode_count": {
                     "type": "integer"
                 },
-                "on_hold": {
+                "episode_duration": {
                     "type": "integer"
                 },
-                "planned": {
+                "score": {
+                    "type": "number"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "season_year": {
                     "type": "integer"
                 },
-                "watching": {
+                "source": {
+                    "type": "string"
+                },
+                "start_year": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_anime_score": {
+                    "type": "integer"
+                },
+                "user_anime_status": {
+                    "type": "string"
+                },
+                "user_episode_count": {
                     "type": "integer"
                 }
             }
         },
-        "service.Studio": {
+        "service.userAnimeStatsItem": {
             "type": "object",
             "properties": {
                 "count": {
@@ -1396,132 +4163,73 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "mean": {
+                "mean_score": {
                     "type": "number"
                 },
-                "member": {
-                    "type": "integer"
-                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "service.StudioHistory": {
+        "service.userAnimeStatsScore": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "mean": {
-                    "type": "number"
-                },
-                "member": {
-                    "type": "integer"
-                },
-                "month": {
-                    "type": "integer"
-                },
-                "popularity": {
-                    "type": "integer"
-                },
-                "rank": {
-                    "type": "integer"
-                },
-                "voter": {
-                    "type": "integer"
-                },
-                "year": {
+                "score": {
                     "type": "integer"
                 }
             }
         },
-        "service.UserAnime": {
+        "service.userAnimeStatsStatus": {
             "type": "object",
             "properties": {
-                "anime_id": {
+                "completed": {
                     "type": "integer"
                 },
-                "comment": {
-                    "type": "string"
-                },
-                "episode": {
+                "dropped": {
                     "type": "integer"
                 },
-                "score": {
+                "on_hold": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "string"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "planned": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "service.UserAnimeRelation": {
-            "type": "object",
-            "properties": {
-                "links": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/service.userAnimeRelationLink"
-                    }
+                "total": {
+                    "type": "integer"
                 },
-                "nodes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/service.userAnimeRelationNode"
-                    }
+                "watching": {
+                    "type": "integer"
                 }
             }
         },
-        "service.userAnimeRelationLink": {
+        "service.userAnimeStatsYear": {
             "type": "object",
             "properties": {
-                "anime_id1": {
+                "finished": {
                     "type": "integer"
                 },
-                "anime_id2": {
+                "started": {
                     "type": "integer"
                 },
-                "relation": {
-                    "type": "string"
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "service.userAnimeRelationNode": {
+        "service.watchOrderAnime": {
             "type": "object",
             "properties": {
-                "anime_id": {
-                    "type": "integer"
-                },
-                "episode_count": {
-                    "type": "integer"
-                },
-                "episode_duration": {
-                    "type": "integer"
-                },
-                "score": {
-                    "type": "number"
-                },
-                "season": {
-                    "type": "string"
-                },
-                "season_year": {
+                "id": {
                     "type": "integer"
                 },
-                "source": {
+                "picture": {
                     "type": "string"
                 },
-                "start_year": {
-                    "type": "integer"
+                "start_date": {
+                    "$ref": "#/definitions/service.Date"
                 },
                 "status": {
                     "type": "string"
@@ -1532,14 +4240,8 @@ const docTemplate = `{
                 "type": {
                     "type": "string"
                 },
-                "user_anime_score": {
-                    "type": "integer"
-                },
-                "user_anime_status": {
+                "user_status": {
                     "type": "string"
-                },
-                "user_episode_count": {
-                    "type": "integer"
                 }
             }
         },
@@ -1560,6 +4262,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "APIKey": {
+            "description": "API key as \"Bearer \u003ckey\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
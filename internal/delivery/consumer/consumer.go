@@ -3,56 +3,302 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
 	"github.com/rl404/fairy/log"
 	"github.com/rl404/fairy/pubsub"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Config is consumer config.
+type Config struct {
+	// Low priority topic.
+	Topic string
+	// High priority topic.
+	HighTopic string
+	// Topic for messages that failed too many times.
+	DeadLetterTopic string
+	// Max consume attempt before sent to dead letter topic.
+	MaxAttempt int
+	// Base retry delay. Doubled every attempt.
+	Backoff time.Duration
+	// Max retry delay.
+	MaxBackoff time.Duration
+	// Max consecutive high priority messages consumed
+	// before giving a turn to low priority message.
+	HighWeight int
+	// Number of messages consumed concurrently.
+	Worker int
+}
+
 // Consumer contains functions for consumer.
 type Consumer struct {
 	service service.Service
 	pubsub  pubsub.PubSub
-	topic   string
+	cfg     Config
+	handler pubsub.HandlerFunc
+	high    chan job
+	low     chan job
+	worker  chan struct{}
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+type job struct {
+	ctx     context.Context
+	message []byte
 }
 
 // New to create new consumer.
-func New(service service.Service, ps pubsub.PubSub, topic string) *Consumer {
-	ps.Use(log.PubSubMiddlewareWithLog(utils.GetLogger(0), log.PubSubMiddlewareConfig{Error: true}))
-	ps.Use(log.PubSubMiddlewareWithLog(utils.GetLogger(1), log.PubSubMiddlewareConfig{
-		Topic:   topic,
-		Payload: true,
-		Error:   true,
-	}))
+func New(service service.Service, ps pubsub.PubSub, cfg Config) *Consumer {
+	if cfg.HighWeight <= 0 {
+		cfg.HighWeight = 1
+	}
 
-	return &Consumer{
+	if cfg.Worker <= 0 {
+		cfg.Worker = 1
+	}
+
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+
+	if cfg.MaxBackoff < cfg.Backoff {
+		cfg.MaxBackoff = cfg.Backoff
+	}
+
+	c := &Consumer{
 		service: service,
 		pubsub:  ps,
-		topic:   topic,
+		cfg:     cfg,
+		high:    make(chan job),
+		low:     make(chan job),
+		worker:  make(chan struct{}, cfg.Worker),
+		stop:    make(chan struct{}),
+	}
+
+	for i := 0; i < cfg.Worker; i++ {
+		c.worker <- struct{}{}
 	}
+
+	// Messages are consumed by workers so the log
+	// middlewares are applied here instead of in pubsub.
+	c.handler = c.withLog(c.consume)
+
+	return c
+}
+
+func (c *Consumer) withLog(h pubsub.HandlerFunc) pubsub.HandlerFunc {
+	h = log.PubSubMiddlewareWithLog(utils.GetLogger(1), log.PubSubMiddlewareConfig{
+		Topic:   c.cfg.Topic,
+		Payload: true,
+		Error:   true,
+	})(h)
+	return log.PubSubMiddlewareWithLog(utils.GetLogger(0), log.PubSubMiddlewareConfig{Error: true})(h)
 }
 
 // Subscribe to start subscribing to topic.
 func (c *Consumer) Subscribe(nrApp *newrelic.Application) error {
-	return c.pubsub.Subscribe(context.Background(), c.topic, func(ctx context.Context, message []byte) error {
+	c.wg.Add(1)
+	go c.dispatch()
+
+	if err := c.pubsub.Subscribe(context.Background(), c.cfg.HighTopic, c.enqueue(c.high)); err != nil {
+		return err
+	}
+
+	if err := c.pubsub.Subscribe(context.Background(), c.cfg.Topic, c.enqueue(c.low)); err != nil {
+		return err
+	}
+
+	return c.pubsub.Subscribe(context.Background(), c.cfg.DeadLetterTopic, c.withLog(func(ctx context.Context, message []byte) error {
 		var msg entity.Message
 		if err := json.Unmarshal(message, &msg); err != nil {
 			return stack.Wrap(ctx, err)
 		}
 
-		if err := c.service.ConsumeMessage(ctx, msg); err != nil {
+		if err := c.service.SaveDeadLetter(ctx, msg); err != nil {
 			return stack.Wrap(ctx, err)
 		}
 
 		return nil
-	})
+	}))
+}
+
+// enqueue will pass the message to dispatcher and
+// wait until it is picked up by a worker. The message
+// is already acked by pubsub so if the consumer is
+// closed, it is saved as dead letter to be replayed
+// later instead of dropped.
+func (c *Consumer) enqueue(lane chan job) pubsub.HandlerFunc {
+	return func(ctx context.Context, message []byte) error {
+		select {
+		case lane <- job{ctx: ctx, message: message}:
+			return nil
+		case <-c.stop:
+		}
+
+		var msg entity.Message
+		if err := json.Unmarshal(message, &msg); err != nil {
+			return stack.Wrap(ctx, err)
+		}
+
+		msg.Error = errors.ErrConsumerClosed.Error()
+		if err := c.service.SaveDeadLetter(ctx, msg); err != nil {
+			return stack.Wrap(ctx, err, errors.ErrConsumerClosed)
+		}
+
+		return nil
+	}
+}
+
+// dispatch will pass high priority messages to workers
+// first. After HighWeight high priority messages in a
+// row, a waiting low priority message will get a turn
+// so low lane is not starved.
+func (c *Consumer) dispatch() {
+	defer c.wg.Done()
+
+	var streak int
+	for {
+		// Wait for free worker.
+		select {
+		case <-c.worker:
+		case <-c.stop:
+			return
+		}
+
+		j, ok := c.next(&streak)
+		if !ok {
+			return
+		}
+
+		// Error is already logged by the handler middleware
+		// and failed message is retried or sent to dead letter.
+		c.wg.Add(1)
+		go func(j job) {
+			defer c.wg.Done()
+			defer func() { c.worker <- struct{}{} }()
+			_ = c.handler(j.ctx, j.message)
+		}(j)
+	}
+}
+
+func (c *Consumer) next(streak *int) (job, bool) {
+	if *streak < c.cfg.HighWeight {
+		select {
+		case j := <-c.high:
+			*streak++
+			return j, true
+		default:
+		}
+	}
+
+	select {
+	case j := <-c.low:
+		*streak = 0
+		return j, true
+	default:
+	}
+
+	select {
+	case j := <-c.high:
+		*streak++
+		return j, true
+	case j := <-c.low:
+		*streak = 0
+		return j, true
+	case <-c.stop:
+		return job{}, false
+	}
+}
+
+func (c *Consumer) consume(ctx context.Context, message []byte) (err error) {
+	var msg entity.Message
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return stack.Wrap(ctx, err)
+	}
+
+	// Continue trace from publisher.
+	ctx = utils.ExtractTrace(ctx, msg.Trace)
+	ctx, span := utils.StartSpan(ctx, "Consume "+string(msg.Type), trace.WithSpanKind(trace.SpanKindConsumer))
+	defer func() { utils.EndSpan(span, err) }()
+
+	if err := c.service.ConsumeMessage(ctx, msg); err != nil {
+		if errRetry := c.retry(ctx, msg, err); errRetry != nil {
+			return stack.Wrap(ctx, errRetry, err)
+		}
+		return stack.Wrap(ctx, err)
+	}
+
+	return nil
+}
+
+// retry will schedule the failed message to be republished
+// with exponential backoff or send it to dead letter
+// after too many attempts or if it can't be scheduled.
+// The message is not waited here so it won't block
+// the next messages.
+func (c *Consumer) retry(ctx context.Context, msg entity.Message, errConsume error) error {
+	msg.Attempt++
+	msg.Error = errConsume.Error()
+	msg.Trace = utils.InjectTrace(ctx)
+
+	if msg.Attempt < c.cfg.MaxAttempt {
+		msg.RetryAt = time.Now().Add(c.backoff(msg.Attempt))
+		errRetry := c.service.RetryMessage(ctx, msg)
+		if errRetry == nil {
+			return nil
+		}
+
+		stack.Wrap(ctx, errRetry)
+		msg.RetryAt = time.Time{}
+	}
+
+	return c.deadLetter(ctx, msg)
+}
+
+// backoff to get retry delay of the attempt.
+// Doubled every attempt up to max backoff.
+func (c *Consumer) backoff(attempt int) time.Duration {
+	backoff := c.cfg.Backoff
+	for i := 1; i < attempt && backoff < c.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.cfg.MaxBackoff)
+}
+
+// deadLetter will publish the message to dead letter topic.
+// If it fails, the message is saved directly.
+func (c *Consumer) deadLetter(ctx context.Context, msg entity.Message) error {
+	d, err := json.Marshal(msg)
+	if err != nil {
+		return stack.Wrap(ctx, err)
+	}
+
+	errPublish := c.pubsub.Publish(ctx, c.cfg.DeadLetterTopic, d)
+	if errPublish == nil {
+		return nil
+	}
+
+	if err := c.service.SaveDeadLetter(ctx, msg); err != nil {
+		return stack.Wrap(ctx, err, errPublish)
+	}
+
+	return nil
 }
 
-// Close to stop consumer connection.
+// Close to stop consumer connection. Messages which are
+// being consumed are waited before pubsub is closed.
 func (c *Consumer) Close() error {
+	c.once.Do(func() { close(c.stop) })
+	c.wg.Wait()
 	return c.pubsub.Close()
 }
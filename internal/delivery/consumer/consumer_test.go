@@ -0,0 +1,324 @@
+package consumer_test
+
+import (
+	"context"
+	"encoding/json"
+	_errors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/delivery/consumer"
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	_pubsub "github.com/rl404/fairy/pubsub"
+	"github.com/stretchr/testify/suite"
+)
+
+type dummyPubsub struct {
+	sync.Mutex
+	handlers map[string]_pubsub.HandlerFunc
+	messages map[string][][]byte
+	closed   bool
+}
+
+func (d *dummyPubsub) Use(...func(_pubsub.HandlerFunc) _pubsub.HandlerFunc) {}
+
+func (d *dummyPubsub) Publish(_ context.Context, topic string, message []byte) error {
+	d.Lock()
+	defer d.Unlock()
+	d.messages[topic] = append(d.messages[topic], message)
+	return nil
+}
+
+func (d *dummyPubsub) get(topic string) [][]byte {
+	d.Lock()
+	defer d.Unlock()
+	return d.messages[topic]
+}
+
+func (d *dummyPubsub) Subscribe(_ context.Context, topic string, h _pubsub.HandlerFunc) error {
+	d.handlers[topic] = h
+	return nil
+}
+
+func (d *dummyPubsub) Close() error {
+	d.Lock()
+	defer d.Unlock()
+	d.closed = true
+	return nil
+}
+
+func (d *dummyPubsub) isClosed() bool {
+	d.Lock()
+	defer d.Unlock()
+	return d.closed
+}
+
+type dummyService struct {
+	service.Service
+	sync.Mutex
+	err         error
+	retryErr    error
+	gate        chan struct{}
+	consumed    []entity.Message
+	retries     []entity.Message
+	deadLetters []entity.Message
+}
+
+func (d *dummyService) ConsumeMessage(_ context.Context, msg entity.Message) error {
+	if d.gate != nil {
+		<-d.gate
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.consumed = append(d.consumed, msg)
+	return d.err
+}
+
+func (d *dummyService) getConsumed() []entity.Message {
+	d.Lock()
+	defer d.Unlock()
+	return d.consumed
+}
+
+func (d *dummyService) RetryMessage(_ context.Context, msg entity.Message) error {
+	d.Lock()
+	defer d.Unlock()
+	if d.retryErr != nil {
+		return d.retryErr
+	}
+	d.retries = append(d.retries, msg)
+	return nil
+}
+
+func (d *dummyService) getRetries() []entity.Message {
+	d.Lock()
+	defer d.Unlock()
+	return d.retries
+}
+
+func (d *dummyService) SaveDeadLetter(_ context.Context, msg entity.Message) error {
+	d.Lock()
+	defer d.Unlock()
+	d.deadLetters = append(d.deadLetters, msg)
+	return nil
+}
+
+func (d *dummyService) getDeadLetters() []entity.Message {
+	d.Lock()
+	defer d.Unlock()
+	return d.deadLetters
+}
+
+type consumerTestSuite struct {
+	suite.Suite
+	pubsub  *dummyPubsub
+	service *dummyService
+}
+
+func TestConsumer(t *testing.T) {
+	suite.Run(t, new(consumerTestSuite))
+}
+
+func (suite *consumerTestSuite) SetupTest() {
+	suite.pubsub = &dummyPubsub{
+		handlers: make(map[string]_pubsub.HandlerFunc),
+		messages: make(map[string][][]byte),
+	}
+	suite.service = &dummyService{err: _errors.New("dummy error")}
+	suite.Nil(consumer.New(suite.service, suite.pubsub, consumer.Config{
+		Topic:           "topic",
+		HighTopic:       "topic-high",
+		DeadLetterTopic: "topic-dlq",
+		MaxAttempt:      3,
+		Backoff:         time.Second,
+		HighWeight:      2,
+		Worker:          1,
+	}).Subscribe(nil))
+}
+
+func (suite *consumerTestSuite) publish(topic string, msg entity.Message) {
+	d, err := json.Marshal(msg)
+	suite.Nil(err)
+	suite.Nil(suite.pubsub.handlers[topic](context.Background(), d))
+}
+
+func (suite *consumerTestSuite) waitPublished(topic string, cnt int) {
+	suite.Eventually(func() bool { return len(suite.pubsub.get(topic)) == cnt }, time.Second, 10*time.Millisecond)
+}
+
+func (suite *consumerTestSuite) waitRetried(cnt int) {
+	suite.Eventually(func() bool { return len(suite.service.getRetries()) == cnt }, time.Second, 10*time.Millisecond)
+}
+
+func (suite *consumerTestSuite) TestRetry() {
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 1, Attempt: 1})
+	suite.waitRetried(1)
+	suite.Len(suite.pubsub.get("topic"), 0)
+	suite.Len(suite.pubsub.get("topic-dlq"), 0)
+
+	msg := suite.service.getRetries()[0]
+	suite.Equal(int64(1), msg.ID)
+	suite.Equal(2, msg.Attempt)
+	suite.Equal("dummy error", msg.Error)
+	suite.WithinDuration(time.Now().Add(2*time.Second), msg.RetryAt, time.Second)
+}
+
+func (suite *consumerTestSuite) TestRetryMaxBackoff() {
+	c := consumer.New(suite.service, suite.pubsub, consumer.Config{
+		Topic:           "topic-backoff",
+		HighTopic:       "topic-backoff-high",
+		DeadLetterTopic: "topic-backoff-dlq",
+		MaxAttempt:      100,
+		Backoff:         time.Second,
+		MaxBackoff:      time.Minute,
+	})
+	suite.Nil(c.Subscribe(nil))
+
+	// Delay is not overflowed after many attempts.
+	suite.publish("topic-backoff", entity.Message{Type: entity.TypeParseAnime, ID: 1, Attempt: 70})
+	suite.waitRetried(1)
+	suite.WithinDuration(time.Now().Add(time.Minute), suite.service.getRetries()[0].RetryAt, time.Second)
+}
+
+func (suite *consumerTestSuite) TestDeadLetter() {
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 1, Attempt: 2})
+	suite.waitPublished("topic-dlq", 1)
+	suite.Len(suite.pubsub.get("topic"), 0)
+
+	var msg entity.Message
+	suite.Nil(json.Unmarshal(suite.pubsub.get("topic-dlq")[0], &msg))
+	suite.Equal(3, msg.Attempt)
+
+	suite.Nil(suite.pubsub.handlers["topic-dlq"](context.Background(), suite.pubsub.get("topic-dlq")[0]))
+	suite.Len(suite.service.getDeadLetters(), 1)
+	suite.Equal(int64(1), suite.service.getDeadLetters()[0].ID)
+}
+
+func (suite *consumerTestSuite) TestRetryError() {
+	// Message that can't be scheduled is not lost.
+	suite.service.retryErr = _errors.New("retry error")
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 1, Attempt: 1})
+	suite.waitPublished("topic-dlq", 1)
+
+	var msg entity.Message
+	suite.Nil(json.Unmarshal(suite.pubsub.get("topic-dlq")[0], &msg))
+	suite.Equal(int64(1), msg.ID)
+	suite.Equal(2, msg.Attempt)
+	suite.Equal("dummy error", msg.Error)
+}
+
+func (suite *consumerTestSuite) TestSuccess() {
+	suite.service.err = nil
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 1})
+	suite.Eventually(func() bool { return len(suite.service.getConsumed()) == 1 }, time.Second, 10*time.Millisecond)
+	suite.Len(suite.pubsub.get("topic"), 0)
+	suite.Len(suite.pubsub.get("topic-dlq"), 0)
+}
+
+func (suite *consumerTestSuite) TestRetryNotBlocking() {
+	// Retried message is not waited so the next
+	// message is consumed right away.
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 1, Attempt: 1, RetryAt: time.Now().Add(time.Hour)})
+	suite.publish("topic", entity.Message{Type: entity.TypeParseAnime, ID: 2})
+	suite.Eventually(func() bool { return len(suite.service.getConsumed()) == 2 }, time.Second, 10*time.Millisecond)
+	suite.waitRetried(2)
+}
+
+func (suite *consumerTestSuite) TestPriority() {
+	suite.service.err = nil
+	suite.service.gate = make(chan struct{})
+
+	var wg sync.WaitGroup
+	publish := func(topic string, id int64) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			suite.publish(topic, entity.Message{Type: entity.TypeParseAnime, ID: id})
+		}()
+	}
+
+	// Block the dispatcher with the first message.
+	publish("topic", 0)
+	time.Sleep(50 * time.Millisecond)
+
+	publish("topic", 1)
+	for i := int64(2); i <= 4; i++ {
+		publish("topic-high", i)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	close(suite.service.gate)
+	wg.Wait()
+	suite.Eventually(func() bool { return len(suite.service.getConsumed()) == 5 }, time.Second, 10*time.Millisecond)
+
+	consumed := suite.service.getConsumed()
+	ids := make([]int64, len(consumed))
+	for i, msg := range consumed {
+		ids[i] = msg.ID
+	}
+
+	// 2 high messages, then low gets a turn.
+	suite.Equal([]int64{0, 2, 3, 1, 4}, ids)
+}
+
+func (suite *consumerTestSuite) TestWorker() {
+	suite.service.err = nil
+	suite.service.gate = make(chan struct{})
+
+	c := consumer.New(suite.service, suite.pubsub, consumer.Config{
+		Topic:           "topic-worker",
+		HighTopic:       "topic-worker-high",
+		DeadLetterTopic: "topic-worker-dlq",
+		HighWeight:      1,
+		Worker:          3,
+	})
+	suite.Nil(c.Subscribe(nil))
+
+	for i := int64(1); i <= 3; i++ {
+		suite.publish("topic-worker", entity.Message{Type: entity.TypeParseAnime, ID: i})
+	}
+
+	// All messages are picked up by workers without waiting the gate.
+	close(suite.service.gate)
+	suite.Eventually(func() bool { return len(suite.service.getConsumed()) == 3 }, time.Second, 10*time.Millisecond)
+}
+
+func (suite *consumerTestSuite) TestClose() {
+	suite.service.err = nil
+	suite.service.gate = make(chan struct{})
+
+	c := consumer.New(suite.service, suite.pubsub, consumer.Config{
+		Topic:           "topic-close",
+		HighTopic:       "topic-close-high",
+		DeadLetterTopic: "topic-close-dlq",
+		Worker:          1,
+	})
+	suite.Nil(c.Subscribe(nil))
+
+	suite.publish("topic-close", entity.Message{Type: entity.TypeParseAnime, ID: 1})
+
+	closed := make(chan struct{})
+	go func() {
+		suite.Nil(c.Close())
+		close(closed)
+	}()
+
+	// In-flight message is waited before pubsub is closed.
+	time.Sleep(50 * time.Millisecond)
+	suite.False(suite.pubsub.isClosed())
+
+	close(suite.service.gate)
+	<-closed
+	suite.True(suite.pubsub.isClosed())
+	suite.Len(suite.service.getConsumed(), 1)
+
+	// Message received after close is saved as dead letter.
+	suite.publish("topic-close", entity.Message{Type: entity.TypeParseAnime, ID: 2})
+	suite.Len(suite.service.getConsumed(), 1)
+	suite.Len(suite.service.getDeadLetters(), 1)
+	suite.Equal(int64(2), suite.service.getDeadLetters()[0].ID)
+	suite.Equal("consumer is closed", suite.service.getDeadLetters()[0].Error)
+}
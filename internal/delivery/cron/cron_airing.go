@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Airing to refresh releasing anime which
+// aired within the window.
+// Will return total queued anime.
+func (c *Cron) Airing(ctx context.Context, window time.Duration, limit int) (cnt int, err error) {
+	ctx = stack.Init(ctx)
+	defer c.log(ctx)
+
+	tx := c.nrApp.StartTransaction("Cron airing")
+	defer tx.End()
+
+	ctx = newrelic.NewContext(ctx, tx)
+
+	ctx, span := utils.StartSpan(ctx, "Cron airing")
+	defer func() { utils.EndSpan(span, err) }()
+
+	cnt, err = c.queueAiredAnime(ctx, window, limit)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	return cnt, nil
+}
+
+func (c *Cron) queueAiredAnime(ctx context.Context, window time.Duration, limit int) (int, error) {
+	defer newrelic.FromContext(ctx).StartSegment("queueAiredAnime").End()
+
+	cnt, _, err := c.service.QueueAiredAnime(ctx, window, limit)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	utils.Info("queued %d aired anime", cnt)
+	c.nrApp.RecordCustomEvent("QueueAiredAnime", map[string]interface{}{"count": cnt})
+
+	return cnt, nil
+}
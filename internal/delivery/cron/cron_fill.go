@@ -9,8 +9,9 @@ import (
 )
 
 // Fill to fill missing anime.
-func (c *Cron) Fill(limit int) error {
-	ctx := stack.Init(context.Background())
+// Will return total queued anime.
+func (c *Cron) Fill(ctx context.Context, limit int) (cnt int, err error) {
+	ctx = stack.Init(ctx)
 	defer c.log(ctx)
 
 	tx := c.nrApp.StartTransaction("Cron fill")
@@ -18,23 +19,27 @@ func (c *Cron) Fill(limit int) error {
 
 	ctx = newrelic.NewContext(ctx, tx)
 
-	if err := c.queueMissingAnime(ctx, limit); err != nil {
-		return stack.Wrap(ctx, err)
+	ctx, span := utils.StartSpan(ctx, "Cron fill")
+	defer func() { utils.EndSpan(span, err) }()
+
+	cnt, err = c.queueMissingAnime(ctx, limit)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
 	}
 
-	return nil
+	return cnt, nil
 }
 
-func (c *Cron) queueMissingAnime(ctx context.Context, limit int) error {
+func (c *Cron) queueMissingAnime(ctx context.Context, limit int) (int, error) {
 	defer newrelic.FromContext(ctx).StartSegment("queueMissingAnime").End()
 
 	cnt, _, err := c.service.QueueMissingAnime(ctx, limit)
 	if err != nil {
-		return stack.Wrap(ctx, err)
+		return cnt, stack.Wrap(ctx, err)
 	}
 
 	utils.Info("queued %d anime", cnt)
 	c.nrApp.RecordCustomEvent("QueueMissingAnime", map[string]interface{}{"count": cnt})
 
-	return nil
+	return cnt, nil
 }
@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Franchise to recompute anime franchises.
+// Will return total saved franchises.
+func (c *Cron) Franchise(ctx context.Context) (cnt int, err error) {
+	ctx = stack.Init(ctx)
+	defer c.log(ctx)
+
+	tx := c.nrApp.StartTransaction("Cron franchise")
+	defer tx.End()
+
+	ctx = newrelic.NewContext(ctx, tx)
+
+	ctx, span := utils.StartSpan(ctx, "Cron franchise")
+	defer func() { utils.EndSpan(span, err) }()
+
+	cnt, err = c.updateFranchises(ctx)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	return cnt, nil
+}
+
+func (c *Cron) updateFranchises(ctx context.Context) (int, error) {
+	defer newrelic.FromContext(ctx).StartSegment("updateFranchises").End()
+
+	cnt, _, err := c.service.UpdateFranchises(ctx)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	utils.Info("saved %d franchises", cnt)
+	c.nrApp.RecordCustomEvent("UpdateFranchises", map[string]interface{}{"count": cnt})
+
+	return cnt, nil
+}
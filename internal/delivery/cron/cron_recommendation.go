@@ -0,0 +1,46 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Recommendation to recompute anime similarities
+// for user recommendations.
+// Will return total saved similarities.
+func (c *Cron) Recommendation(ctx context.Context, limit int) (cnt int, err error) {
+	ctx = stack.Init(ctx)
+	defer c.log(ctx)
+
+	tx := c.nrApp.StartTransaction("Cron recommendation")
+	defer tx.End()
+
+	ctx = newrelic.NewContext(ctx, tx)
+
+	ctx, span := utils.StartSpan(ctx, "Cron recommendation")
+	defer func() { utils.EndSpan(span, err) }()
+
+	cnt, err = c.updateRecommendations(ctx, limit)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	return cnt, nil
+}
+
+func (c *Cron) updateRecommendations(ctx context.Context, limit int) (int, error) {
+	defer newrelic.FromContext(ctx).StartSegment("updateRecommendations").End()
+
+	cnt, _, err := c.service.UpdateRecommendations(ctx, limit)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	utils.Info("saved %d anime similarities", cnt)
+	c.nrApp.RecordCustomEvent("UpdateRecommendations", map[string]interface{}{"count": cnt})
+
+	return cnt, nil
+}
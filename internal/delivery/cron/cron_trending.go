@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Trending to recompute trending anime.
+// Will return total saved trending anime.
+func (c *Cron) Trending(ctx context.Context) (cnt int, err error) {
+	ctx = stack.Init(ctx)
+	defer c.log(ctx)
+
+	tx := c.nrApp.StartTransaction("Cron trending")
+	defer tx.End()
+
+	ctx = newrelic.NewContext(ctx, tx)
+
+	ctx, span := utils.StartSpan(ctx, "Cron trending")
+	defer func() { utils.EndSpan(span, err) }()
+
+	cnt, err = c.updateTrending(ctx)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	return cnt, nil
+}
+
+func (c *Cron) updateTrending(ctx context.Context) (int, error) {
+	defer newrelic.FromContext(ctx).StartSegment("updateTrending").End()
+
+	cnt, _, err := c.service.UpdateTrending(ctx)
+	if err != nil {
+		return cnt, stack.Wrap(ctx, err)
+	}
+
+	utils.Info("saved %d trending anime", cnt)
+	c.nrApp.RecordCustomEvent("UpdateTrending", map[string]interface{}{"count": cnt})
+
+	return cnt, nil
+}
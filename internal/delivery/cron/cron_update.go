@@ -9,8 +9,10 @@ import (
 )
 
 // Update to update old data.
-func (c *Cron) Update(limit int) error {
-	ctx := stack.Init(context.Background())
+// Limit is applied to each queue.
+// Will return total queued data.
+func (c *Cron) Update(ctx context.Context, limit int) (cnt int, err error) {
+	ctx = stack.Init(ctx)
 	defer c.log(ctx)
 
 	tx := c.nrApp.StartTransaction("Cron update")
@@ -18,77 +20,47 @@ func (c *Cron) Update(limit int) error {
 
 	ctx = newrelic.NewContext(ctx, tx)
 
-	if err := c.queueOldReleasingAnime(ctx, limit); err != nil {
-		return stack.Wrap(ctx, err)
+	ctx, span := utils.StartSpan(ctx, "Cron update")
+	defer func() { utils.EndSpan(span, err) }()
+
+	for _, queue := range []func(context.Context, int) (int, error){
+		c.queueOldAnime,
+		c.queueOldUsername,
+	} {
+		n, err := queue(ctx, limit)
+		cnt += n
+		if err != nil {
+			return cnt, stack.Wrap(ctx, err)
+		}
 	}
 
-	if err := c.queueOldFinishedAnime(ctx, limit); err != nil {
-		return stack.Wrap(ctx, err)
-	}
-
-	if err := c.queueOldNotYetAnime(ctx, limit); err != nil {
-		return stack.Wrap(ctx, err)
-	}
-
-	if err := c.queueOldUsername(ctx, limit); err != nil {
-		return stack.Wrap(ctx, err)
-	}
-
-	return nil
-}
-
-func (c *Cron) queueOldReleasingAnime(ctx context.Context, limit int) error {
-	defer newrelic.FromContext(ctx).StartSegment("queueOldReleasingAnime").End()
-
-	cnt, _, err := c.service.QueueOldReleasingAnime(ctx, limit)
-	if err != nil {
-		return stack.Wrap(ctx, err)
-	}
-
-	utils.Info("queued %d old releasing anime", cnt)
-	c.nrApp.RecordCustomEvent("QueueOldReleasingAnime", map[string]interface{}{"count": cnt})
-
-	return nil
-}
-
-func (c *Cron) queueOldFinishedAnime(ctx context.Context, limit int) error {
-	defer newrelic.FromContext(ctx).StartSegment("queueOldFinishedAnime").End()
-
-	cnt, _, err := c.service.QueueOldFinishedAnime(ctx, limit)
-	if err != nil {
-		return stack.Wrap(ctx, err)
-	}
-
-	utils.Info("queued %d old finished anime", cnt)
-	c.nrApp.RecordCustomEvent("QueueOldFinishedAnime", map[string]interface{}{"count": cnt})
-
-	return nil
+	return cnt, nil
 }
 
-func (c *Cron) queueOldNotYetAnime(ctx context.Context, limit int) error {
-	defer newrelic.FromContext(ctx).StartSegment("queueOldNotYetAnime").End()
+func (c *Cron) queueOldAnime(ctx context.Context, limit int) (int, error) {
+	defer newrelic.FromContext(ctx).StartSegment("queueOldAnime").End()
 
-	cnt, _, err := c.service.QueueOldNotYetAnime(ctx, limit)
+	cnt, _, err := c.service.QueueOldAnime(ctx, limit)
 	if err != nil {
-		return stack.Wrap(ctx, err)
+		return cnt, stack.Wrap(ctx, err)
 	}
 
-	utils.Info("queued %d old not yet released anime", cnt)
-	c.nrApp.RecordCustomEvent("QueueOldNotYetAnime", map[string]interface{}{"count": cnt})
+	utils.Info("queued %d old anime", cnt)
+	c.nrApp.RecordCustomEvent("QueueOldAnime", map[string]interface{}{"count": cnt})
 
-	return nil
+	return cnt, nil
 }
 
-func (c *Cron) queueOldUsername(ctx context.Context, limit int) error {
+func (c *Cron) queueOldUsername(ctx context.Context, limit int) (int, error) {
 	defer newrelic.FromContext(ctx).StartSegment("queueOldUsername").End()
 
 	cnt, _, err := c.service.QueueOldUserAnime(ctx, limit)
 	if err != nil {
-		return stack.Wrap(ctx, err)
+		return cnt, stack.Wrap(ctx, err)
 	}
 
 	utils.Info("queued %d old username", cnt)
 	c.nrApp.RecordCustomEvent("QueueOldUsername", map[string]interface{}{"count": cnt})
 
-	return nil
+	return cnt, nil
 }
@@ -1,19 +1,52 @@
 package api
 
 import (
+	"context"
+	"math"
+	"strconv"
+
 	"github.com/rl404/akatsuki/internal/delivery/grpc/schema"
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
 	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // API contains all functions for api endpoints.
 type API struct {
-	service service.Service
+	service       service.Service
+	anonymousRead bool
 	schema.UnimplementedAPIServer
 }
 
 // New to create new api endpoints.
-func New(service service.Service) *API {
+// Endpoints can be accessed without api key
+// if anonymous read is enabled.
+func New(service service.Service, anonymousRead bool) *API {
 	return &API{
-		service: service,
+		service:       service,
+		anonymousRead: anonymousRead,
+	}
+}
+
+// Auth to check api key scope and rate limit.
+// All endpoints are read scope.
+func (api *API) Auth(ctx context.Context, token, _ string) error {
+	if token == "" && api.anonymousRead {
+		return nil
 	}
+
+	wait, code, err := api.service.Authenticate(ctx, service.AuthenticateRequest{
+		Key:   token,
+		Scope: entity.ScopeRead,
+	})
+	if err != nil {
+		if wait > 0 {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(wait.Seconds())))))
+		}
+		return utils.ResponseWithGRPC(code, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,100 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Config is outbox relay config.
+type Config struct {
+	// Interval to check pending messages.
+	Interval time.Duration
+	// Max messages published per relay.
+	Limit int
+}
+
+// Relay contains functions for outbox relay.
+type Relay struct {
+	service service.Service
+	cfg     Config
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New to create new outbox relay.
+func New(service service.Service, cfg Config) *Relay {
+	return &Relay{
+		service: service,
+		cfg:     cfg,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Run to start publishing pending outbox messages
+// in the background.
+func (r *Relay) Run() {
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			// Relay again immediately if there are
+			// probably more messages waiting.
+			if cnt := r.relay(); cnt == r.cfg.Limit {
+				continue
+			}
+
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *Relay) relay() int {
+	ctx := stack.Init(context.Background())
+	defer r.log(ctx)
+
+	cnt, _, err := r.service.RelayOutbox(ctx, r.cfg.Limit)
+	if err != nil {
+		stack.Wrap(ctx, err)
+	}
+
+	if cnt > 0 {
+		utils.Info("relayed %d outbox messages", cnt)
+	}
+
+	return cnt
+}
+
+func (r *Relay) log(ctx context.Context) {
+	if rvr := recover(); rvr != nil {
+		stack.Wrap(ctx, fmt.Errorf("%s", debug.Stack()), fmt.Errorf("%v", rvr), fmt.Errorf("panic"))
+	}
+
+	errStack := stack.Get(ctx)
+	if len(errStack) > 0 {
+		utils.Log(map[string]interface{}{
+			"level": utils.ErrorLevel,
+			"error": errStack,
+		})
+	}
+}
+
+// Close to stop relay.
+func (r *Relay) Close() error {
+	close(r.stop)
+	<-r.done
+	return nil
+}
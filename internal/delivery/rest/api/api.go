@@ -1,30 +1,50 @@
 package api
 
 import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
 	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
 	"github.com/rl404/fairy/log"
 	"github.com/rl404/fairy/monitoring/newrelic/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // API contains all functions for api endpoints.
 type API struct {
-	service service.Service
+	service       service.Service
+	anonymousRead bool
 }
 
 // New to create new api endpoints.
-func New(service service.Service) *API {
+// Read endpoints can be accessed without api key
+// if anonymous read is enabled.
+func New(service service.Service, anonymousRead bool) *API {
 	return &API{
-		service: service,
+		service:       service,
+		anonymousRead: anonymousRead,
 	}
 }
 
 // Register to register api routes.
 func (api *API) Register(r chi.Router, nrApp *newrelic.Application) {
 	r.Route("/", func(r chi.Router) {
+		r.Use(api.redactKey)
 		r.Use(middleware.NewHTTP(nrApp))
+		r.Use(otelhttp.NewMiddleware("api", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			// Use route pattern instead of path so anime id and
+			// username are not in span name. Span is renamed after
+			// routing when the full pattern is known.
+			return r.Method + " " + chi.RouteContext(r.Context()).RoutePattern()
+		})))
 		r.Use(log.HTTPMiddlewareWithLog(utils.GetLogger(0), log.APIMiddlewareConfig{Error: true}))
 		r.Use(log.HTTPMiddlewareWithLog(utils.GetLogger(1), log.APIMiddlewareConfig{
 			RequestHeader:  true,
@@ -36,21 +56,149 @@ func (api *API) Register(r chi.Router, nrApp *newrelic.Application) {
 		}))
 		r.Use(utils.Recoverer)
 
-		r.Get("/anime", api.HandleGetAnime)
-		r.Get("/anime/{animeID}", api.HandleGetAnimeByID)
-		r.Post("/anime/{animeID}/update", api.handleUpdateAnimeByID)
-		r.Get("/anime/{animeID}/history", api.handleGetAnimeHistoriesByID)
+		r.Group(func(r chi.Router) {
+			r.Use(api.auth(entity.ScopeRead))
+
+			r.Get("/anime", api.HandleGetAnime)
+			r.Get("/anime/trending", api.handleGetTrendingAnime)
+			r.Get("/anime/{animeID}", api.HandleGetAnimeByID)
+			r.Get("/anime/{animeID}/history", api.handleGetAnimeHistoriesByID)
+			r.Get("/anime/{animeID}/watch-order", api.handleGetAnimeWatchOrder)
+
+			r.Get("/franchises", api.handleGetFranchises)
+			r.Get("/franchises/{franchiseID}", api.handleGetFranchiseByID)
+			r.Get("/franchises/{franchiseID}/watch-order", api.handleGetFranchiseWatchOrder)
+
+			r.Get("/genres", api.handleGetGenres)
+			r.Get("/genres/{genreID}", api.handleGetGenreByID)
+			r.Get("/genres/{genreID}/history", api.handleGetGenreHistoriesByID)
+
+			r.Get("/seasons", api.handleGetSeasons)
+			r.Get("/seasons/{year}/{season}", api.handleGetSeason)
+
+			r.Get("/schedule", api.handleGetSchedule)
+
+			r.Get("/studios", api.handleGetStudios)
+			r.Get("/studios/{studioID}", api.handleGetStudioByID)
+			r.Get("/studios/{studioID}/history", api.handleGetStudioHistoriesByID)
+
+			r.Get("/user/{username}/anime", api.handleGetUserAnime)
+			r.Get("/user/{username}/anime/relations", api.handleGetUserAnimeRelations)
+			r.Get("/user/{username}/stats", api.handleGetUserAnimeStats)
+			r.Get("/user/{username}/compare/{username2}", api.handleCompareUserAnime)
+			r.Get("/user/{username}/recommendations", api.handleGetUserRecommendations)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(api.queryKey)
+			r.Use(api.auth(entity.ScopeRead))
+
+			r.Get("/schedule.ics", api.handleGetScheduleCalendar)
+			r.Get("/user/{username}/schedule.ics", api.handleGetUserScheduleCalendar)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(api.auth(entity.ScopeTriggerUpdate))
+
+			r.Post("/anime/{animeID}/update", api.handleUpdateAnimeByID)
+			r.Post("/user/{username}/update", api.handleUpdateUserAnime)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(api.auth(entity.ScopeAdmin))
+
+			r.Get("/queue/stats", api.handleGetQueueStats)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(api.auth(entity.ScopeAdmin))
+
+			r.Get("/jobs", api.handleGetJobs)
+			r.Get("/queue", api.handleGetQueueStats)
+			r.Get("/anime/{animeID}/sync", api.handleGetAnimeSyncStatus)
+			r.Post("/anime/refresh", api.handleRefreshAnime)
+
+			r.Get("/empty-ids", api.handleGetEmptyIDs)
+			r.Post("/empty-ids/recheck", api.handleRecheckEmptyIDs)
+			r.Delete("/empty-ids", api.handleExpireEmptyIDs)
 
-		r.Get("/genres", api.handleGetGenres)
-		r.Get("/genres/{genreID}", api.handleGetGenreByID)
-		r.Get("/genres/{genreID}/history", api.handleGetGenreHistoriesByID)
+			r.Get("/api-keys", api.handleGetAPIKeys)
+			r.Post("/api-keys", api.handleCreateAPIKey)
+			r.Delete("/api-keys/{apiKeyID}", api.handleDeleteAPIKey)
+		})
+	})
+}
+
+type keyCtx int
+
+const (
+	headerKeyCtx keyCtx = iota
+	queryKeyCtx
+)
+
+const redactedKey = "REDACTED"
+
+// redactKey to move api key in authorization header and
+// key query param to context so they are not logged.
+func (api *API) redactKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerKey := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		query := r.URL.Query()
+		queryKey := query.Get("key")
+
+		ctx := context.WithValue(r.Context(), headerKeyCtx, headerKey)
+		ctx = context.WithValue(ctx, queryKeyCtx, queryKey)
+		r = r.Clone(ctx)
+
+		if headerKey != "" {
+			r.Header.Set("Authorization", "Bearer "+redactedKey)
+		}
 
-		r.Get("/studios", api.handleGetStudios)
-		r.Get("/studios/{studioID}", api.handleGetStudioByID)
-		r.Get("/studios/{studioID}/history", api.handleGetStudioHistoriesByID)
+		if query.Has("key") {
+			query.Set("key", redactedKey)
+			r.URL.RawQuery = query.Encode()
+			r.RequestURI = r.URL.RequestURI()
+		}
 
-		r.Get("/user/{username}/anime", api.handleGetUserAnime)
-		r.Get("/user/{username}/anime/relations", api.handleGetUserAnimeRelations)
-		r.Post("/user/{username}/update", api.handleUpdateUserAnime)
+		next.ServeHTTP(w, r)
 	})
 }
+
+// queryKey to also accept api key in key query param
+// for clients that can't set header, like calendar apps.
+func (api *API) queryKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, _ := r.Context().Value(headerKeyCtx).(string); key == "" {
+			r = r.WithContext(context.WithValue(r.Context(), headerKeyCtx, r.Context().Value(queryKeyCtx)))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// auth to check api key in authorization header
+// has access to the scope and is not rate limited.
+func (api *API) auth(scope entity.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, _ := r.Context().Value(headerKeyCtx).(string)
+			if key == "" && scope == entity.ScopeRead && api.anonymousRead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			wait, code, err := api.service.Authenticate(r.Context(), service.AuthenticateRequest{
+				Key:   key,
+				Scope: scope,
+			})
+			if err != nil {
+				if wait > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				}
+				utils.ResponseWithJSON(w, code, nil, stack.Wrap(r.Context(), err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
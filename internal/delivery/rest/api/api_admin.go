@@ -0,0 +1,280 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// @summary Get scheduled job runs.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param name query string false "job name"
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.Job,meta=service.Pagination}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/jobs [get]
+func (api *API) handleGetJobs(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	jobs, pagination, code, err := api.service.GetJobs(r.Context(), service.GetJobsRequest{
+		Name:  name,
+		Page:  page,
+		Limit: limit,
+	})
+
+	utils.ResponseWithJSON(w, code, jobs, stack.Wrap(r.Context(), err), pagination)
+}
+
+// @summary Get anime sync status.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param animeID path integer true "anime id"
+// @success 200 {object} utils.Response{data=service.SyncStatus}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/anime/{animeID}/sync [get]
+func (api *API) handleGetAnimeSyncStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "animeID"), 10, 64)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidAnimeID))
+		return
+	}
+
+	status, code, err := api.service.GetAnimeSyncStatus(r.Context(), id)
+	utils.ResponseWithJSON(w, code, status, stack.Wrap(r.Context(), err))
+}
+
+// @summary Force update anime in bulk.
+// @description Queue anime in id range (max 1000 ids) or anime list filter result.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param start_id query integer false "start anime id"
+// @param end_id query integer false "end anime id"
+// @param title query string false "title"
+// @param nsfw query string false "nsfw" enums(true,false)
+// @param type query string false "type" enums(TV,OVA,ONA,MOVIE,SPECIAL,MUSIC,CM,PV,TV_SPECIAL)
+// @param status query string false "status" enums(FINISHED,RELEASING,NOT_YET)
+// @param season query string false "season" enums(WINTER,SPRING,SUMMER,FALL)
+// @param season_year query integer false "season year"
+// @param start_mean query number false "start mean"
+// @param end_mean query number false "end mean"
+// @param start_airing_year query number false "start airing year"
+// @param end_airing_year query number false "end airing year"
+// @param genre_id query integer false "genre id"
+// @param studio_id query integer false "studio id"
+// @param sort query string false "sort" enums(ID,-ID,TITLE,-TITLE,START_DATE,-START_DATE,MEAN,-MEAN,RANK,-RANK,POPULARITY,-POPULARITY,MEMBER,-MEMBER,VOTER,-VOTER) default(RANK)
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 202 {object} utils.Response{data=int}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/anime/refresh [post]
+func (api *API) handleRefreshAnime(w http.ResponseWriter, r *http.Request) {
+	startID, _ := strconv.ParseInt(r.URL.Query().Get("start_id"), 10, 64)
+	endID, _ := strconv.ParseInt(r.URL.Query().Get("end_id"), 10, 64)
+
+	filter, err := api.getAnimeRequest(r)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err))
+		return
+	}
+
+	cnt, code, err := api.service.RefreshAnime(r.Context(), service.RefreshAnimeRequest{
+		StartID: startID,
+		EndID:   endID,
+		Filter:  filter,
+	})
+
+	utils.ResponseWithJSON(w, code, cnt, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get empty anime ids.
+// @description Anime ids not found in MyAnimeList. Oldest first.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.EmptyID,meta=service.Pagination}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/empty-ids [get]
+func (api *API) handleGetEmptyIDs(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	ids, pagination, code, err := api.service.GetEmptyIDs(r.Context(), service.GetEmptyIDsRequest{
+		Page:  page,
+		Limit: limit,
+	})
+
+	utils.ResponseWithJSON(w, code, ids, stack.Wrap(r.Context(), err), pagination)
+}
+
+// @summary Recheck empty anime ids.
+// @description Queue oldest empty anime ids to be parsed again.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param limit query integer false "limit" default(100)
+// @success 202 {object} utils.Response{data=int}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/empty-ids/recheck [post]
+func (api *API) handleRecheckEmptyIDs(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	cnt, code, err := api.service.RecheckEmptyIDs(r.Context(), service.RecheckEmptyIDsRequest{
+		Limit: limit,
+	})
+
+	utils.ResponseWithJSON(w, code, cnt, stack.Wrap(r.Context(), err))
+}
+
+// @summary Expire old empty anime ids.
+// @description Delete empty anime ids older than n days so they will be filled again.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param days query integer true "days"
+// @success 200 {object} utils.Response{data=int}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/empty-ids [delete]
+func (api *API) handleExpireEmptyIDs(w http.ResponseWriter, r *http.Request) {
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+
+	cnt, code, err := api.service.ExpireEmptyIDs(r.Context(), service.ExpireEmptyIDsRequest{
+		Days: days,
+	})
+
+	utils.ResponseWithJSON(w, code, cnt, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get api keys.
+// @description Including their usage.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.APIKey,meta=service.Pagination}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/api-keys [get]
+func (api *API) handleGetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	apiKeys, pagination, code, err := api.service.GetAPIKeys(r.Context(), service.GetAPIKeysRequest{
+		Page:  page,
+		Limit: limit,
+	})
+
+	utils.ResponseWithJSON(w, code, apiKeys, stack.Wrap(r.Context(), err), pagination)
+}
+
+// @summary Create api key.
+// @description The key is only shown once.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param name query string true "key name"
+// @param scopes query string true "comma separated scopes (READ,TRIGGER_UPDATE,ADMIN)"
+// @param rate query number false "request per second" default(5)
+// @param burst query integer false "burst" default(10)
+// @success 201 {object} utils.Response{data=service.APIKey}
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/api-keys [post]
+func (api *API) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	burst, _ := strconv.Atoi(r.URL.Query().Get("burst"))
+
+	var scopes []string
+	if tmp := r.URL.Query().Get("scopes"); tmp != "" {
+		scopes = strings.Split(tmp, ",")
+	}
+
+	var rate float64
+	if tmp := r.URL.Query().Get("rate"); tmp != "" {
+		tmp2, err := strconv.ParseFloat(tmp, 64)
+		if err != nil {
+			utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidFormat("rate")))
+			return
+		}
+		rate = tmp2
+	}
+
+	apiKey, code, err := api.service.CreateAPIKey(r.Context(), service.CreateAPIKeyRequest{
+		Name:   name,
+		Scopes: scopes,
+		Rate:   rate,
+		Burst:  burst,
+	})
+
+	utils.ResponseWithJSON(w, code, apiKey, stack.Wrap(r.Context(), err))
+}
+
+// @summary Delete api key.
+// @tags Admin
+// @security APIKey
+// @produce json
+// @param apiKeyID path integer true "api key id"
+// @success 200 {object} utils.Response
+// @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /admin/api-keys/{apiKeyID} [delete]
+func (api *API) handleDeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "apiKeyID"), 10, 64)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidAPIKeyID))
+		return
+	}
+
+	code, err := api.service.DeleteAPIKey(r.Context(), id)
+	utils.ResponseWithJSON(w, code, nil, stack.Wrap(r.Context(), err))
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	franchiseEntity "github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	trendingEntity "github.com/rl404/akatsuki/internal/domain/trending/entity"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
@@ -35,6 +37,17 @@ import (
 // @failure 500 {object} utils.Response
 // @router /anime [get]
 func (api *API) HandleGetAnime(w http.ResponseWriter, r *http.Request) {
+	req, err := api.getAnimeRequest(r)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err))
+		return
+	}
+
+	anime, pagination, code, err := api.service.GetAnime(r.Context(), req)
+	utils.ResponseWithJSON(w, code, anime, stack.Wrap(r.Context(), err), pagination)
+}
+
+func (api *API) getAnimeRequest(r *http.Request) (service.GetAnimeRequest, error) {
 	title := r.URL.Query().Get("title")
 	nsfw := r.URL.Query().Get("nsfw")
 	_type := r.URL.Query().Get("type")
@@ -53,21 +66,19 @@ func (api *API) HandleGetAnime(w http.ResponseWriter, r *http.Request) {
 	if tmp := r.URL.Query().Get("start_mean"); tmp != "" {
 		tmp2, err := strconv.ParseFloat(tmp, 64)
 		if err != nil {
-			utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidFormat("start_mean")))
-			return
+			return service.GetAnimeRequest{}, stack.Wrap(r.Context(), err, errors.ErrInvalidFormat("start_mean"))
 		}
 		startMean = tmp2
 	}
 	if tmp := r.URL.Query().Get("end_mean"); tmp != "" {
 		tmp2, err := strconv.ParseFloat(tmp, 64)
 		if err != nil {
-			utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidFormat("end_mean")))
-			return
+			return service.GetAnimeRequest{}, stack.Wrap(r.Context(), err, errors.ErrInvalidFormat("end_mean"))
 		}
 		endMean = tmp2
 	}
 
-	anime, pagination, code, err := api.service.GetAnime(r.Context(), service.GetAnimeRequest{
+	return service.GetAnimeRequest{
 		Title:           title,
 		NSFW:            utils.ParseToBoolPtr(nsfw),
 		Type:            entity.Type(_type),
@@ -83,9 +94,7 @@ func (api *API) HandleGetAnime(w http.ResponseWriter, r *http.Request) {
 		Sort:            entity.Sort(sort),
 		Page:            page,
 		Limit:           limit,
-	})
-
-	utils.ResponseWithJSON(w, code, anime, stack.Wrap(r.Context(), err), pagination)
+	}, nil
 }
 
 // @summary Get anime by id.
@@ -111,11 +120,15 @@ func (api *API) HandleGetAnimeByID(w http.ResponseWriter, r *http.Request) {
 
 // @summary Update anime by id.
 // @tags Anime
+// @security APIKey
 // @produce json
 // @param animeID path integer true "anime id"
 // @success 202 {object} utils.Response
 // @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
 // @failure 404 {object} utils.Response
+// @failure 429 {object} utils.Response{data=service.Cooldown}
 // @failure 500 {object} utils.Response
 // @router /anime/{animeID}/update [post]
 func (api *API) handleUpdateAnimeByID(w http.ResponseWriter, r *http.Request) {
@@ -125,8 +138,12 @@ func (api *API) handleUpdateAnimeByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code, err := api.service.UpdateAnimeByID(r.Context(), id)
-	utils.ResponseWithJSON(w, code, nil, stack.Wrap(r.Context(), err))
+	cooldown, code, err := api.service.UpdateAnimeByID(r.Context(), id)
+	if cooldown != nil {
+		w.Header().Set("Retry-After", strconv.Itoa(cooldown.RetryAfter))
+	}
+
+	utils.ResponseWithJSON(w, code, cooldown, stack.Wrap(r.Context(), err))
 }
 
 // @summary Get anime stats histories by id.
@@ -161,3 +178,57 @@ func (api *API) handleGetAnimeHistoriesByID(w http.ResponseWriter, r *http.Reque
 
 	utils.ResponseWithJSON(w, code, histories, stack.Wrap(r.Context(), err))
 }
+
+// @summary Get watch order of anime franchise.
+// @tags Anime
+// @produce json
+// @param animeID path integer true "anime id"
+// @param order query string false "order" enums(RELEASE,CHRONOLOGICAL) default(CHRONOLOGICAL)
+// @param username query string false "username"
+// @success 200 {object} utils.Response{data=service.WatchOrder}
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /anime/{animeID}/watch-order [get]
+func (api *API) handleGetAnimeWatchOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "animeID"), 10, 64)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidAnimeID))
+		return
+	}
+
+	watchOrder, code, err := api.service.GetWatchOrder(r.Context(), service.GetWatchOrderRequest{
+		AnimeID:  id,
+		Order:    franchiseEntity.WatchOrder(r.URL.Query().Get("order")),
+		Username: r.URL.Query().Get("username"),
+	})
+
+	utils.ResponseWithJSON(w, code, watchOrder, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get trending anime.
+// @tags Anime
+// @produce json
+// @param window query string false "window" enums(7d,30d) default(7d)
+// @param sort query string false "sort" enums(MEMBER,RANK,MEAN) default(MEMBER)
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.TrendingAnime,meta=service.Pagination}
+// @failure 400 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /anime/trending [get]
+func (api *API) handleGetTrendingAnime(w http.ResponseWriter, r *http.Request) {
+	window := r.URL.Query().Get("window")
+	sort := r.URL.Query().Get("sort")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	anime, pagination, code, err := api.service.GetTrendingAnime(r.Context(), service.GetTrendingAnimeRequest{
+		Window: trendingEntity.Window(window),
+		Sort:   trendingEntity.Sort(sort),
+		Page:   page,
+		Limit:  limit,
+	})
+
+	utils.ResponseWithJSON(w, code, anime, stack.Wrap(r.Context(), err), pagination)
+}
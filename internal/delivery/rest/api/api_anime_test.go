@@ -46,7 +46,7 @@ func (suite *animeTestSuite) SetupSuite() {
 	suite.Require().Nil(err)
 
 	service := utils_test.GetService(cfg, db, cache, ps)
-	suite.cache, suite.db, suite.api = cache, db, api.New(service)
+	suite.cache, suite.db, suite.api = cache, db, api.New(service, true)
 }
 
 func (suite *animeTestSuite) TearDownSuite() {
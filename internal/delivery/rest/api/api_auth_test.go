@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rl404/akatsuki/internal/delivery/rest/api"
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	entityAPIKey "github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockAPIKey "github.com/rl404/akatsuki/tests/mocks/domain/api_key"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type authTestSuite struct {
+	suite.Suite
+	animeMock  *mockAnime.Repository
+	apiKeyMock *mockAPIKey.Repository
+	router     chi.Router
+}
+
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(authTestSuite))
+}
+
+func (suite *authTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.apiKeyMock = new(mockAPIKey.Repository)
+
+	service := service.New(service.Deps{
+		Anime:  suite.animeMock,
+		APIKey: suite.apiKeyMock,
+	})
+
+	suite.router = chi.NewRouter()
+	api.New(service, false).Register(suite.router, nil)
+}
+
+func (suite *authTestSuite) TestQueryKey() {
+	suite.apiKeyMock.On("GetByHash", mock.Anything, mock.Anything).Return(&entityAPIKey.APIKey{
+		ID:     1,
+		Scopes: []entityAPIKey.Scope{entityAPIKey.ScopeRead},
+		Rate:   100,
+		Burst:  100,
+	}, http.StatusOK, nil)
+	suite.apiKeyMock.On("AddUsage", mock.Anything, mock.Anything).Return(http.StatusOK, nil)
+	suite.animeMock.On("Get", mock.Anything, mock.Anything).Return([]*entity.Anime{}, 0, http.StatusOK, nil)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "calendar",
+			path:         "/schedule.ics?key=key",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "calendar-no-key",
+			path:         "/schedule.ics",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "not-calendar",
+			path:         "/schedule?key=key",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "not-read",
+			path:         "/admin/jobs?key=key",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "queue-stats-no-key",
+			path:         "/queue/stats",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			suite.Require().Nil(err)
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedCode, recorder.Code)
+		})
+	}
+}
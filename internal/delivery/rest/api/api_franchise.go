@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// @summary Get franchise list.
+// @tags Franchise
+// @produce json
+// @param name query string false "name"
+// @param page query integer false "page" default(1)
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.Franchise,meta=service.Pagination}
+// @failure 400 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /franchises [get]
+func (api *API) handleGetFranchises(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	franchises, pagination, code, err := api.service.GetFranchises(r.Context(), service.GetFranchisesRequest{
+		Name:  name,
+		Page:  page,
+		Limit: limit,
+	})
+
+	utils.ResponseWithJSON(w, code, franchises, stack.Wrap(r.Context(), err), pagination)
+}
+
+// @summary Get franchise by id.
+// @tags Franchise
+// @produce json
+// @param franchiseID path integer true "franchise id"
+// @success 200 {object} utils.Response{data=service.FranchiseDetail}
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /franchises/{franchiseID} [get]
+func (api *API) handleGetFranchiseByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "franchiseID"), 10, 64)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidFranchiseID))
+		return
+	}
+
+	franchise, code, err := api.service.GetFranchiseByID(r.Context(), id)
+	utils.ResponseWithJSON(w, code, franchise, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get franchise watch order.
+// @tags Franchise
+// @produce json
+// @param franchiseID path integer true "franchise id"
+// @param order query string false "order" enums(RELEASE,CHRONOLOGICAL) default(CHRONOLOGICAL)
+// @param username query string false "username"
+// @success 200 {object} utils.Response{data=service.WatchOrder}
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /franchises/{franchiseID}/watch-order [get]
+func (api *API) handleGetFranchiseWatchOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "franchiseID"), 10, 64)
+	if err != nil {
+		utils.ResponseWithJSON(w, http.StatusBadRequest, nil, stack.Wrap(r.Context(), err, errors.ErrInvalidFranchiseID))
+		return
+	}
+
+	watchOrder, code, err := api.service.GetWatchOrder(r.Context(), service.GetWatchOrderRequest{
+		FranchiseID: id,
+		Order:       entity.WatchOrder(r.URL.Query().Get("order")),
+		Username:    r.URL.Query().Get("username"),
+	})
+
+	utils.ResponseWithJSON(w, code, watchOrder, stack.Wrap(r.Context(), err))
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// @summary Get queue stats.
+// @tags Queue
+// @security APIKey
+// @produce json
+// @success 200 {object} utils.Response{data=service.QueueStats}
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
+// @failure 429 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /queue/stats [get]
+// @router /admin/queue [get]
+func (api *API) handleGetQueueStats(w http.ResponseWriter, r *http.Request) {
+	stats, code, err := api.service.GetQueueStats(r.Context())
+	utils.ResponseWithJSON(w, code, stats, stack.Wrap(r.Context(), err))
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// @summary Get weekly broadcast schedule.
+// @tags Schedule
+// @produce json
+// @param tz query string false "timezone" default(Asia/Tokyo)
+// @param username query string false "only anime in user's watching list"
+// @success 200 {object} utils.Response{data=service.Schedule}
+// @failure 202 {object} utils.Response
+// @failure 400 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /schedule [get]
+func (api *API) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, code, err := api.service.GetSchedule(r.Context(), service.GetScheduleRequest{
+		Timezone: r.URL.Query().Get("tz"),
+		Username: r.URL.Query().Get("username"),
+	})
+
+	utils.ResponseWithJSON(w, code, schedule, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get broadcast schedule calendar.
+// @tags Schedule
+// @produce text/calendar
+// @param key query string false "api key with READ scope, for clients that can't set header"
+// @success 200 {string} string "iCalendar"
+// @failure 500 {object} utils.Response
+// @router /schedule.ics [get]
+func (api *API) handleGetScheduleCalendar(w http.ResponseWriter, r *http.Request) {
+	calendar, code, err := api.service.GetScheduleCalendar(r.Context(), service.GetScheduleCalendarRequest{})
+	api.responseWithCalendar(w, r, code, calendar, err)
+}
+
+// @summary Get user's watching anime broadcast schedule calendar.
+// @tags Schedule
+// @produce text/calendar
+// @param username path string true "username"
+// @param key query string false "api key with READ scope, for clients that can't set header"
+// @success 200 {string} string "iCalendar"
+// @failure 202 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /user/{username}/schedule.ics [get]
+func (api *API) handleGetUserScheduleCalendar(w http.ResponseWriter, r *http.Request) {
+	calendar, code, err := api.service.GetScheduleCalendar(r.Context(), service.GetScheduleCalendarRequest{
+		Username: chi.URLParam(r, "username"),
+	})
+	api.responseWithCalendar(w, r, code, calendar, err)
+}
+
+func (api *API) responseWithCalendar(w http.ResponseWriter, r *http.Request, code int, calendar []byte, err error) {
+	if err != nil || code != http.StatusOK {
+		utils.ResponseWithJSON(w, code, nil, stack.Wrap(r.Context(), err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(calendar)))
+	w.WriteHeader(code)
+
+	_, _ = w.Write(calendar)
+}
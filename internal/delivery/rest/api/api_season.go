@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// @summary Get season list.
+// @tags Season
+// @produce json
+// @success 200 {object} utils.Response{data=[]service.SeasonCount}
+// @failure 500 {object} utils.Response
+// @router /seasons [get]
+func (api *API) handleGetSeasons(w http.ResponseWriter, r *http.Request) {
+	seasons, code, err := api.service.GetSeasons(r.Context())
+	utils.ResponseWithJSON(w, code, seasons, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get season anime and summary.
+// @tags Season
+// @produce json
+// @param year path integer true "year"
+// @param season path string true "season" enums(WINTER,SPRING,SUMMER,FALL)
+// @success 200 {object} utils.Response{data=service.SeasonDetail}
+// @failure 400 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /seasons/{year}/{season} [get]
+func (api *API) handleGetSeason(w http.ResponseWriter, r *http.Request) {
+	year, _ := strconv.Atoi(chi.URLParam(r, "year"))
+	season := chi.URLParam(r, "season")
+
+	detail, code, err := api.service.GetSeason(r.Context(), service.GetSeasonRequest{
+		Year:   year,
+		Season: entity.Season(season),
+	})
+
+	utils.ResponseWithJSON(w, code, detail, stack.Wrap(r.Context(), err))
+}
@@ -3,8 +3,11 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	userAnimeEntity "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
 	"github.com/rl404/akatsuki/internal/service"
 	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
@@ -14,6 +17,17 @@ import (
 // @tags User Anime
 // @produce json
 // @param username path string true "username"
+// @param status query string false "status" enums(WATCHING,COMPLETED,ON_HOLD,DROPPED,PLANNED)
+// @param start_score query integer false "start score"
+// @param end_score query integer false "end score"
+// @param tags query string false "comma separated tags"
+// @param is_rewatching query string false "is rewatching" enums(true,false)
+// @param title query string false "anime title"
+// @param type query string false "anime type" enums(TV,OVA,ONA,MOVIE,SPECIAL,MUSIC,CM,PV,TV_SPECIAL)
+// @param season query string false "anime season" enums(WINTER,SPRING,SUMMER,FALL)
+// @param season_year query integer false "anime season year"
+// @param genre_id query integer false "anime genre id"
+// @param sort query string false "sort" enums(SCORE,-SCORE,UPDATED_AT,-UPDATED_AT,PROGRESS,-PROGRESS,TITLE,-TITLE) default(-UPDATED_AT)
 // @param page query integer false "page" default(1)
 // @param limit query integer false "limit" default(20)
 // @success 200 {object} utils.Response{data=[]service.UserAnime,meta=service.Pagination}
@@ -24,13 +38,39 @@ import (
 // @router /user/{username}/anime [get]
 func (api *API) handleGetUserAnime(w http.ResponseWriter, r *http.Request) {
 	username := chi.URLParam(r, "username")
+	status := r.URL.Query().Get("status")
+	startScore, _ := strconv.Atoi(r.URL.Query().Get("start_score"))
+	endScore, _ := strconv.Atoi(r.URL.Query().Get("end_score"))
+	isRewatching := r.URL.Query().Get("is_rewatching")
+	title := r.URL.Query().Get("title")
+	_type := r.URL.Query().Get("type")
+	season := r.URL.Query().Get("season")
+	seasonYear, _ := strconv.Atoi(r.URL.Query().Get("season_year"))
+	genreID, _ := strconv.ParseInt(r.URL.Query().Get("genre_id"), 10, 64)
+	sort := r.URL.Query().Get("sort")
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 
+	var tags []string
+	if tmp := r.URL.Query().Get("tags"); tmp != "" {
+		tags = strings.Split(tmp, ",")
+	}
+
 	anime, pagination, code, err := api.service.GetUserAnime(r.Context(), service.GetUserAnimeRequest{
-		Username: username,
-		Page:     page,
-		Limit:    limit,
+		Username:     username,
+		Status:       userAnimeEntity.Status(status),
+		StartScore:   startScore,
+		EndScore:     endScore,
+		Tags:         tags,
+		IsRewatching: utils.ParseToBoolPtr(isRewatching),
+		Title:        title,
+		AnimeType:    animeEntity.Type(_type),
+		Season:       animeEntity.Season(season),
+		SeasonYear:   seasonYear,
+		GenreID:      genreID,
+		Sort:         userAnimeEntity.Sort(sort),
+		Page:         page,
+		Limit:        limit,
 	})
 
 	utils.ResponseWithJSON(w, code, anime, stack.Wrap(r.Context(), err), pagination)
@@ -40,6 +80,9 @@ func (api *API) handleGetUserAnime(w http.ResponseWriter, r *http.Request) {
 // @tags User Anime
 // @produce json
 // @param username path string true "username"
+// @param relations query string false "comma separated relation types, e.g. SEQUEL,PREQUEL"
+// @param depth query integer false "max depth" default(10)
+// @param limit query integer false "max nodes" default(5000)
 // @success 200 {object} utils.Response{data=service.UserAnimeRelation}
 // @failure 202 {object} utils.Response
 // @failure 400 {object} utils.Response
@@ -48,21 +91,101 @@ func (api *API) handleGetUserAnime(w http.ResponseWriter, r *http.Request) {
 // @router /user/{username}/anime/relations [get]
 func (api *API) handleGetUserAnimeRelations(w http.ResponseWriter, r *http.Request) {
 	username := chi.URLParam(r, "username")
-	relations, code, err := api.service.GetUserAnimeRelations(r.Context(), username)
+	depth, _ := strconv.Atoi(r.URL.Query().Get("depth"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	var relationTypes []animeEntity.Relation
+	if tmp := r.URL.Query().Get("relations"); tmp != "" {
+		for _, t := range strings.Split(tmp, ",") {
+			relationTypes = append(relationTypes, animeEntity.Relation(t))
+		}
+	}
+
+	relations, code, err := api.service.GetUserAnimeRelations(r.Context(), service.GetUserAnimeRelationsRequest{
+		Username:  username,
+		Relations: relationTypes,
+		Depth:     depth,
+		Limit:     limit,
+	})
 	utils.ResponseWithJSON(w, code, relations, stack.Wrap(r.Context(), err))
 }
 
+// @summary Get user's anime stats.
+// @tags User Anime
+// @produce json
+// @param username path string true "username"
+// @success 200 {object} utils.Response{data=service.UserAnimeStats}
+// @failure 202 {object} utils.Response
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /user/{username}/stats [get]
+func (api *API) handleGetUserAnimeStats(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	stats, code, err := api.service.GetUserAnimeStats(r.Context(), username)
+	utils.ResponseWithJSON(w, code, stats, stack.Wrap(r.Context(), err))
+}
+
+// @summary Compare 2 users' anime.
+// @tags User Anime
+// @produce json
+// @param username path string true "username"
+// @param username2 path string true "other username"
+// @success 200 {object} utils.Response{data=service.UserAnimeCompare}
+// @failure 202 {object} utils.Response
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /user/{username}/compare/{username2} [get]
+func (api *API) handleCompareUserAnime(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	username2 := chi.URLParam(r, "username2")
+	compare, code, err := api.service.CompareUserAnime(r.Context(), username, username2)
+	utils.ResponseWithJSON(w, code, compare, stack.Wrap(r.Context(), err))
+}
+
+// @summary Get user's anime recommendations.
+// @tags User Anime
+// @produce json
+// @param username path string true "username"
+// @param limit query integer false "limit" default(20)
+// @success 200 {object} utils.Response{data=[]service.Recommendation}
+// @failure 202 {object} utils.Response
+// @failure 400 {object} utils.Response
+// @failure 404 {object} utils.Response
+// @failure 500 {object} utils.Response
+// @router /user/{username}/recommendations [get]
+func (api *API) handleGetUserRecommendations(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	recommendations, code, err := api.service.GetUserRecommendations(r.Context(), service.GetUserRecommendationsRequest{
+		Username: username,
+		Limit:    limit,
+	})
+
+	utils.ResponseWithJSON(w, code, recommendations, stack.Wrap(r.Context(), err))
+}
+
 // @summary Update user's anime.
 // @tags User Anime
+// @security APIKey
 // @produce json
 // @param username path string true "username"
 // @success 202 {object} utils.Response
 // @failure 400 {object} utils.Response
+// @failure 401 {object} utils.Response
+// @failure 403 {object} utils.Response
 // @failure 404 {object} utils.Response
+// @failure 429 {object} utils.Response{data=service.Cooldown}
 // @failure 500 {object} utils.Response
 // @router /user/{username}/update [post]
 func (api *API) handleUpdateUserAnime(w http.ResponseWriter, r *http.Request) {
 	username := chi.URLParam(r, "username")
-	code, err := api.service.UpdateUserAnime(r.Context(), username)
-	utils.ResponseWithJSON(w, code, nil, stack.Wrap(r.Context(), err))
+	cooldown, code, err := api.service.UpdateUserAnime(r.Context(), username)
+	if cooldown != nil {
+		w.Header().Set("Retry-After", strconv.Itoa(cooldown.RetryAfter))
+	}
+
+	utils.ResponseWithJSON(w, code, cooldown, stack.Wrap(r.Context(), err))
 }
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rl404/akatsuki/internal/delivery/cron"
+	"github.com/rl404/akatsuki/internal/service"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+	_cron "github.com/robfig/cron/v3"
+)
+
+// Leader is leader election interface so only
+// one scheduler replica runs the jobs.
+type Leader interface {
+	IsLeader(ctx context.Context) (bool, error)
+	Hold(ctx context.Context) (context.Context, func())
+	Release(ctx context.Context) error
+}
+
+// Config is scheduler config.
+type Config struct {
+	// Cron expression for updating old data.
+	UpdateSchedule string
+	// Cron expression for filling missing data.
+	FillSchedule string
+	// Cron expression for refreshing aired anime.
+	AiringSchedule string
+	// Cron expression for updating recommendations.
+	RecommendationSchedule string
+	// Cron expression for updating franchises.
+	FranchiseSchedule string
+	// Cron expression for updating trending anime.
+	TrendingSchedule string
+
+	UpdateLimit         int
+	FillLimit           int
+	AiringLimit         int
+	AiringWindow        time.Duration
+	RecommendationLimit int
+}
+
+// Scheduler contains functions for scheduler.
+type Scheduler struct {
+	service service.Service
+	cron    *cron.Cron
+	leader  Leader
+	runner  *_cron.Cron
+}
+
+// New to create new scheduler.
+func New(service service.Service, nrApp *newrelic.Application, leader Leader, cfg Config) (*Scheduler, error) {
+	s := &Scheduler{
+		service: service,
+		cron:    cron.New(service, nrApp),
+		leader:  leader,
+		runner:  _cron.New(_cron.WithChain(_cron.SkipIfStillRunning(_cron.DiscardLogger))),
+	}
+
+	if _, err := s.runner.AddFunc(cfg.UpdateSchedule, s.job("update", func(ctx context.Context) (int, error) {
+		return s.cron.Update(ctx, cfg.UpdateLimit)
+	})); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.runner.AddFunc(cfg.FillSchedule, s.job("fill", func(ctx context.Context) (int, error) {
+		return s.cron.Fill(ctx, cfg.FillLimit)
+	})); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.runner.AddFunc(cfg.AiringSchedule, s.job("airing", func(ctx context.Context) (int, error) {
+		return s.cron.Airing(ctx, cfg.AiringWindow, cfg.AiringLimit)
+	})); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.runner.AddFunc(cfg.RecommendationSchedule, s.job("recommendation", func(ctx context.Context) (int, error) {
+		return s.cron.Recommendation(ctx, cfg.RecommendationLimit)
+	})); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.runner.AddFunc(cfg.FranchiseSchedule, s.job("franchise", func(ctx context.Context) (int, error) {
+		return s.cron.Franchise(ctx)
+	})); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.runner.AddFunc(cfg.TrendingSchedule, s.job("trending", func(ctx context.Context) (int, error) {
+		return s.cron.Trending(ctx)
+	})); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Run to start running jobs in the background.
+func (s *Scheduler) Run() {
+	s.runner.Start()
+}
+
+// job will only run the job if this replica is the
+// leader and save the run history. The lock is renewed
+// while the job is running and the job is cancelled if
+// the lock is lost.
+func (s *Scheduler) job(name string, fn func(context.Context) (int, error)) func() {
+	return func() {
+		ctx := stack.Init(context.Background())
+		defer s.log(ctx)
+
+		isLeader, err := s.leader.IsLeader(ctx)
+		if err != nil {
+			stack.Wrap(ctx, err)
+			return
+		}
+
+		if !isLeader {
+			utils.Info("skip job %s, not leader", name)
+			return
+		}
+
+		id, _, err := s.service.StartJob(ctx, name)
+		if err != nil {
+			stack.Wrap(ctx, err)
+			return
+		}
+
+		jobCtx, stop := s.leader.Hold(ctx)
+
+		// Job error is already logged by cron.
+		cnt, errJob := fn(jobCtx)
+
+		if err := context.Cause(jobCtx); err != nil {
+			stack.Wrap(ctx, err)
+			if errJob == nil {
+				errJob = err
+			}
+		}
+
+		stop()
+
+		if _, err := s.service.FinishJob(ctx, id, cnt, errJob); err != nil {
+			stack.Wrap(ctx, err)
+		}
+	}
+}
+
+func (s *Scheduler) log(ctx context.Context) {
+	if rvr := recover(); rvr != nil {
+		stack.Wrap(ctx, fmt.Errorf("%s", debug.Stack()), fmt.Errorf("%v", rvr), fmt.Errorf("panic"))
+	}
+
+	errStack := stack.Get(ctx)
+	if len(errStack) > 0 {
+		utils.Log(map[string]interface{}{
+			"level": utils.ErrorLevel,
+			"error": errStack,
+		})
+	}
+}
+
+// Close to stop scheduler and wait running jobs.
+func (s *Scheduler) Close() error {
+	<-s.runner.Stop().Done()
+	return s.leader.Release(context.Background())
+}
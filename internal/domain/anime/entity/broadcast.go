@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// JST is timezone of broadcast day and time from MyAnimeList.
+var JST = time.FixedZone("JST", 9*60*60)
+
+var dayToWeekday = map[Day]time.Weekday{
+	DaySunday:    time.Sunday,
+	DayMonday:    time.Monday,
+	DayTuesday:   time.Tuesday,
+	DayWednesday: time.Wednesday,
+	DayThursday:  time.Thursday,
+	DayFriday:    time.Friday,
+	DaySaturday:  time.Saturday,
+}
+
+var weekdayToDay = map[time.Weekday]Day{
+	time.Sunday:    DaySunday,
+	time.Monday:    DayMonday,
+	time.Tuesday:   DayTuesday,
+	time.Wednesday: DayWednesday,
+	time.Thursday:  DayThursday,
+	time.Friday:    DayFriday,
+	time.Saturday:  DaySaturday,
+}
+
+// Weekday to convert broadcast day to weekday.
+// Will return false if the day is unknown.
+func (d Day) Weekday() (time.Weekday, bool) {
+	weekday, ok := dayToWeekday[d]
+	return weekday, ok
+}
+
+// DayFromWeekday to convert weekday to broadcast day.
+func DayFromWeekday(weekday time.Weekday) Day {
+	return weekdayToDay[weekday]
+}
+
+// SlotInWeek to get the broadcast slot in the week
+// (Sunday to Saturday in JST) of t.
+// Will return false if broadcast day or time is unknown.
+func (b Broadcast) SlotInWeek(t time.Time) (time.Time, bool) {
+	weekday, ok := b.Day.Weekday()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	slot, err := time.Parse("15:04", b.Time)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	now := t.In(JST)
+	return time.Date(now.Year(), now.Month(), now.Day()-int(now.Weekday())+int(weekday), slot.Hour(), slot.Minute(), 0, 0, JST), true
+}
+
+// LastAiredAt to get the latest broadcast slot at or before t.
+// Will return false if broadcast day or time is unknown.
+func (b Broadcast) LastAiredAt(t time.Time) (time.Time, bool) {
+	aired, ok := b.SlotInWeek(t)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if aired.After(t) {
+		aired = aired.AddDate(0, 0, -7)
+	}
+
+	return aired, true
+}
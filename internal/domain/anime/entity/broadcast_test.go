@@ -0,0 +1,72 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastAiredAt(t *testing.T) {
+	// Wednesday 2024-01-10 12:00 JST.
+	now := time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		broadcast entity.Broadcast
+		expected  time.Time
+		ok        bool
+	}{
+		{name: "unknown-day", broadcast: entity.Broadcast{Day: entity.DayOther, Time: "10:00"}},
+		{name: "invalid-time", broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "25:00"}},
+		{name: "same-day", broadcast: entity.Broadcast{Day: entity.DayWednesday, Time: "10:30"}, expected: time.Date(2024, 1, 10, 1, 30, 0, 0, time.UTC), ok: true},
+		{name: "same-day-later", broadcast: entity.Broadcast{Day: entity.DayWednesday, Time: "23:00"}, expected: time.Date(2024, 1, 3, 14, 0, 0, 0, time.UTC), ok: true},
+		{name: "previous-day", broadcast: entity.Broadcast{Day: entity.DayTuesday, Time: "01:00"}, expected: time.Date(2024, 1, 8, 16, 0, 0, 0, time.UTC), ok: true},
+		{name: "next-day", broadcast: entity.Broadcast{Day: entity.DayThursday, Time: "01:00"}, expected: time.Date(2024, 1, 3, 16, 0, 0, 0, time.UTC), ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aired, ok := test.broadcast.LastAiredAt(now)
+			assert.Equal(t, test.ok, ok)
+			assert.True(t, test.expected.Equal(aired), aired)
+		})
+	}
+}
+
+func TestSlotInWeek(t *testing.T) {
+	// Wednesday 2024-01-10 12:00 JST.
+	now := time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		broadcast entity.Broadcast
+		expected  time.Time
+		ok        bool
+	}{
+		{name: "unknown-day", broadcast: entity.Broadcast{Day: entity.DayOther, Time: "10:00"}},
+		{name: "unknown-time", broadcast: entity.Broadcast{Day: entity.DayMonday}},
+		{name: "sunday", broadcast: entity.Broadcast{Day: entity.DaySunday, Time: "23:00"}, expected: time.Date(2024, 1, 7, 14, 0, 0, 0, time.UTC), ok: true},
+		{name: "saturday", broadcast: entity.Broadcast{Day: entity.DaySaturday, Time: "01:00"}, expected: time.Date(2024, 1, 12, 16, 0, 0, 0, time.UTC), ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slot, ok := test.broadcast.SlotInWeek(now)
+			assert.Equal(t, test.ok, ok)
+			assert.True(t, test.expected.Equal(slot), slot)
+		})
+	}
+}
+
+func TestDayWeekday(t *testing.T) {
+	for _, day := range []entity.Day{entity.DaySunday, entity.DayMonday, entity.DaySaturday} {
+		weekday, ok := day.Weekday()
+		assert.True(t, ok)
+		assert.Equal(t, day, entity.DayFromWeekday(weekday))
+	}
+
+	_, ok := entity.DayOther.Weekday()
+	assert.False(t, ok)
+}
@@ -109,6 +109,25 @@ const (
 	RelationOther              Relation = "OTHER"
 )
 
+// IsStory to check if relation is part of the same
+// story, so both anime belong to the same franchise.
+func (r Relation) IsStory() bool {
+	switch r {
+	case RelationSequel,
+		RelationPrequel,
+		RelationAlternativeSetting,
+		RelationAlternativeVersion,
+		RelationSideStory,
+		RelationParentStory,
+		RelationSummary,
+		RelationFullStory,
+		RelationSpinOff:
+		return true
+	default:
+		return false
+	}
+}
+
 // HistoryGroup is anime history group.
 type HistoryGroup string
 
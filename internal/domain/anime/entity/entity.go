@@ -94,6 +94,39 @@ type AnimeRelated struct {
 	Relation Relation
 }
 
+// AnimeGenre is entity for anime genre.
+type AnimeGenre struct {
+	AnimeID int64
+	GenreID int64
+}
+
+// AnimeStudio is entity for anime studio.
+type AnimeStudio struct {
+	AnimeID  int64
+	StudioID int64
+}
+
+// SeasonCount is entity for anime count in a season.
+type SeasonCount struct {
+	Season Season
+	Year   int
+	Count  int
+}
+
+// StatsChange is entity for anime stats change between
+// the baseline and last stats history in a period.
+type StatsChange struct {
+	AnimeID     int64
+	StartMember int
+	EndMember   int
+	StartRank   int
+	EndRank     int
+	StartMean   float64
+	EndMean     float64
+	StartAt     time.Time
+	EndAt       time.Time
+}
+
 // History is entity for anime history.
 type History struct {
 	Year          int
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+const minRefreshInterval = time.Hour
+
+// RefreshInterval to calculate how long until the anime
+// should be refreshed from its status base interval.
+//
+// Anime with more members and anime whose stats change
+// faster are refreshed sooner. Obscure and stable anime
+// can wait longer than the base interval.
+//
+// Change rate is relative member change per day
+// (0.01 means members grow 1% a day).
+func (a Anime) RefreshInterval(base time.Duration, changeRate float64) time.Duration {
+	// 1k members keeps the base, 1m members is 4x faster.
+	memberFactor := math.Max(0.25, 1.75-math.Log10(float64(a.Member)+1)/4)
+
+	// 1% daily change is 2x faster.
+	changeFactor := 1 / (1 + math.Abs(changeRate)*100)
+
+	interval := time.Duration(float64(base) * memberFactor * changeFactor)
+	if interval < minRefreshInterval {
+		return minRefreshInterval
+	}
+
+	return interval
+}
@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshInterval(t *testing.T) {
+	base := 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		member     int
+		changeRate float64
+		expected   time.Duration
+	}{
+		{name: "obscure", member: 0, expected: 42 * time.Hour},
+		{name: "base", member: 999, expected: base},
+		{name: "popular", member: 999_999, expected: 6 * time.Hour},
+		{name: "changing", member: 999, changeRate: 0.01, expected: 12 * time.Hour},
+		{name: "min", member: 999_999, changeRate: 1, expected: time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interval := entity.Anime{Member: test.member}.RefreshInterval(base, test.changeRate)
+			assert.InDelta(t, test.expected, interval, float64(time.Second))
+		})
+	}
+}
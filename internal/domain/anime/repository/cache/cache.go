@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
 	"github.com/rl404/akatsuki/internal/domain/anime/repository"
@@ -74,19 +75,15 @@ func (c *Cache) IsOld(ctx context.Context, id int64) (bool, int, error) {
 	return c.repo.IsOld(ctx, id)
 }
 
-// GetOldReleasingIDs to get old releasing anime ids.
-func (c *Cache) GetOldReleasingIDs(ctx context.Context) ([]int64, int, error) {
-	return c.repo.GetOldReleasingIDs(ctx)
+// GetOldIDs to get anime ids which are due to refresh.
+func (c *Cache) GetOldIDs(ctx context.Context, limit int) ([]int64, int, error) {
+	return c.repo.GetOldIDs(ctx, limit)
 }
 
-// GetOldFinishedIDs to get old finished anime ids.
-func (c *Cache) GetOldFinishedIDs(ctx context.Context) ([]int64, int, error) {
-	return c.repo.GetOldFinishedIDs(ctx)
-}
-
-// GetOldNotYetIDs to get old not yet released anime ids.
-func (c *Cache) GetOldNotYetIDs(ctx context.Context) ([]int64, int, error) {
-	return c.repo.GetOldNotYetIDs(ctx)
+// GetAiredIDs to get releasing anime ids which aired
+// since the time and not updated yet.
+func (c *Cache) GetAiredIDs(ctx context.Context, since time.Time) ([]int64, int, error) {
+	return c.repo.GetAiredIDs(ctx, since)
 }
 
 // GetMaxID to get max id.
@@ -104,6 +101,31 @@ func (c *Cache) GetRelatedByIDs(ctx context.Context, ids []int64) ([]*entity.Ani
 	return c.repo.GetRelatedByIDs(ctx, ids)
 }
 
+// GetAllRelated to get all related.
+func (c *Cache) GetAllRelated(ctx context.Context) ([]*entity.AnimeRelated, int, error) {
+	return c.repo.GetAllRelated(ctx)
+}
+
+// GetGenresByIDs to get genres by ids.
+func (c *Cache) GetGenresByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeGenre, int, error) {
+	return c.repo.GetGenresByIDs(ctx, ids)
+}
+
+// GetStudiosByIDs to get studios by ids.
+func (c *Cache) GetStudiosByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeStudio, int, error) {
+	return c.repo.GetStudiosByIDs(ctx, ids)
+}
+
+// GetSeasons to get all seasons with anime count.
+func (c *Cache) GetSeasons(ctx context.Context) ([]*entity.SeasonCount, int, error) {
+	return c.repo.GetSeasons(ctx)
+}
+
+// GetStatsChanges to get anime stats changes since the time.
+func (c *Cache) GetStatsChanges(ctx context.Context, since time.Time) ([]*entity.StatsChange, int, error) {
+	return c.repo.GetStatsChanges(ctx, since)
+}
+
 // DeleteByID to delete by id.
 func (c *Cache) DeleteByID(ctx context.Context, id int64) (int, error) {
 	return c.repo.DeleteByID(ctx, id)
@@ -5,6 +5,7 @@ import (
 	_errors "errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
 	"github.com/rl404/akatsuki/internal/domain/anime/repository/cache"
@@ -259,40 +260,28 @@ func (suite *testSuite) TestIsOld() {
 	suite.Nil(err)
 }
 
-func (suite *testSuite) TestGetOldReleasingIDs() {
+func (suite *testSuite) TestGetOldIDs() {
 	ctx := context.Background()
 
-	suite.repoMock.On("GetOldReleasingIDs", ctx).Return([]int64{1}, http.StatusOK, nil)
+	suite.repoMock.On("GetOldIDs", ctx, 10).Return([]int64{1}, http.StatusOK, nil)
 
 	c := cache.New(suite.cacherMock, suite.repoMock)
 
-	res, code, err := c.GetOldReleasingIDs(ctx)
+	res, code, err := c.GetOldIDs(ctx, 10)
 	suite.Equal([]int64{1}, res)
 	suite.Equal(http.StatusOK, code)
 	suite.Nil(err)
 }
 
-func (suite *testSuite) TestGetOldFinishedIDs() {
+func (suite *testSuite) TestGetAiredIDs() {
 	ctx := context.Background()
+	since := time.Now()
 
-	suite.repoMock.On("GetOldFinishedIDs", ctx).Return([]int64{1}, http.StatusOK, nil)
+	suite.repoMock.On("GetAiredIDs", ctx, since).Return([]int64{1}, http.StatusOK, nil)
 
 	c := cache.New(suite.cacherMock, suite.repoMock)
 
-	res, code, err := c.GetOldFinishedIDs(ctx)
-	suite.Equal([]int64{1}, res)
-	suite.Equal(http.StatusOK, code)
-	suite.Nil(err)
-}
-
-func (suite *testSuite) TestGetOldNotYetIDs() {
-	ctx := context.Background()
-
-	suite.repoMock.On("GetOldNotYetIDs", ctx).Return([]int64{1}, http.StatusOK, nil)
-
-	c := cache.New(suite.cacherMock, suite.repoMock)
-
-	res, code, err := c.GetOldNotYetIDs(ctx)
+	res, code, err := c.GetAiredIDs(ctx, since)
 	suite.Equal([]int64{1}, res)
 	suite.Equal(http.StatusOK, code)
 	suite.Nil(err)
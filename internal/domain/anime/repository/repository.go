@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
 )
@@ -14,12 +15,16 @@ type Repository interface {
 	GetHistories(ctx context.Context, data entity.GetHistoriesRequest) ([]entity.History, int, error)
 	Update(ctx context.Context, data entity.Anime) (int, error)
 	GetRelatedByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeRelated, int, error)
+	GetAllRelated(ctx context.Context) ([]*entity.AnimeRelated, int, error)
+	GetGenresByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeGenre, int, error)
+	GetStudiosByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeStudio, int, error)
+	GetSeasons(ctx context.Context) ([]*entity.SeasonCount, int, error)
+	GetStatsChanges(ctx context.Context, since time.Time) ([]*entity.StatsChange, int, error)
 	DeleteByID(ctx context.Context, id int64) (int, error)
 
 	IsOld(ctx context.Context, id int64) (bool, int, error)
 	GetMaxID(ctx context.Context) (int64, int, error)
 	GetIDs(ctx context.Context) ([]int64, int, error)
-	GetOldFinishedIDs(ctx context.Context) ([]int64, int, error)
-	GetOldReleasingIDs(ctx context.Context) ([]int64, int, error)
-	GetOldNotYetIDs(ctx context.Context) ([]int64, int, error)
+	GetOldIDs(ctx context.Context, limit int) ([]int64, int, error)
+	GetAiredIDs(ctx context.Context, since time.Time) ([]int64, int, error)
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	outboxEntity "github.com/rl404/akatsuki/internal/domain/outbox/entity"
 	"gorm.io/gorm"
 )
 
@@ -50,6 +51,8 @@ type Anime struct {
 	UserDropped   int
 	UserPlanned   int
 
+	NextRefreshAt time.Time `gorm:"index"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt
@@ -240,6 +243,17 @@ func (sql *SQL) animeRelatedFromEntity(anime entity.Anime) []AnimeRelated {
 	return ar
 }
 
+func (sql *SQL) outboxFromEntity(anime entity.Anime) []outboxEntity.Outbox {
+	o := make([]outboxEntity.Outbox, len(anime.Related))
+	for i, r := range anime.Related {
+		o[i] = outboxEntity.Outbox{
+			Type:    outboxEntity.TypeParseAnime,
+			AnimeID: r.ID,
+		}
+	}
+	return o
+}
+
 func (sql *SQL) animeStudioFromEntity(anime entity.Anime) []AnimeStudio {
 	as := make([]AnimeStudio, len(anime.StudioIDs))
 	for i, s := range anime.StudioIDs {
@@ -299,6 +313,24 @@ type animeStatsHistory struct {
 	UserPlanned   int
 }
 
+type animeSeasonCount struct {
+	Season     entity.Season
+	SeasonYear int
+	Count      int
+}
+
+type animeStatsChange struct {
+	AnimeID     int64
+	StartMember int
+	EndMember   int
+	StartRank   int
+	EndRank     int
+	StartMean   float64
+	EndMean     float64
+	StartAt     time.Time
+	EndAt       time.Time
+}
+
 func (sql *SQL) convertSort(sort entity.Sort) string {
 	if sort == "" {
 		sort = entity.SortRank
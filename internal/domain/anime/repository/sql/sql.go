@@ -3,15 +3,20 @@ package sql
 import (
 	"context"
 	_errors "errors"
+	"math"
 	"net/http"
 	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	outboxSQL "github.com/rl404/akatsuki/internal/domain/outbox/repository/sql"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/fairy/errors/stack"
 	"gorm.io/gorm"
 )
 
+// Stats history count to calculate change rate.
+const changeRateHistoryCount = 10
+
 // SQL contains functions for anime sql database.
 type SQL struct {
 	db           *gorm.DB
@@ -21,6 +26,7 @@ type SQL struct {
 }
 
 // New to create new anime database.
+// The ages are base refresh interval (in days) of each status.
 func New(db *gorm.DB, finishedAge, releasingAge, notYetAge int) *SQL {
 	return &SQL{
 		db:           db,
@@ -184,8 +190,15 @@ func (sql *SQL) Update(ctx context.Context, data entity.Anime) (int, error) {
 		}
 	}
 
+	// Get stats change rate.
+	changeRate, err := sql.getChangeRate(tx.WithContext(ctx), data)
+	if err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
 	// Update anime.
 	anime := sql.animeFromEntity(data)
+	anime.NextRefreshAt = time.Now().Add(data.RefreshInterval(sql.getRefreshAge(data.Status), changeRate))
 	anime.CreatedAt = a.CreatedAt
 	if err := tx.WithContext(ctx).Save(anime).Error; err != nil {
 		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
@@ -244,6 +257,11 @@ func (sql *SQL) Update(ctx context.Context, data entity.Anime) (int, error) {
 		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
 
+	// Queue related anime.
+	if err := outboxSQL.Add(tx.WithContext(ctx), sql.outboxFromEntity(data)...); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
@@ -252,43 +270,119 @@ func (sql *SQL) Update(ctx context.Context, data entity.Anime) (int, error) {
 }
 
 // IsOld to check if old.
+// Releasing anime which aired since last update are also old.
 func (sql *SQL) IsOld(ctx context.Context, id int64) (bool, int, error) {
-	res := sql.db.WithContext(ctx).
-		Where("id = ? and ((status = ? and updated_at >= ?) or (status = ? and updated_at >= ?) or (status = ? and updated_at >= ?))", id,
-			entity.StatusFinished, time.Now().Add(-sql.finishedAge),
-			entity.StatusReleasing, time.Now().Add(-sql.releasingAge),
-			entity.StatusNotYet, time.Now().Add(-sql.notYetAge)).
+	var animes []Anime
+	if err := sql.db.WithContext(ctx).
+		Select("status", "broadcast_day", "broadcast_time", "next_refresh_at", "updated_at").
+		Where("id = ?", id).
 		Limit(1).
-		Find(&[]Anime{})
+		Find(&animes).Error; err != nil {
+		return true, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(animes) == 0 {
+		return true, http.StatusOK, nil
+	}
 
-	if res.Error != nil {
-		return true, http.StatusInternalServerError, stack.Wrap(ctx, res.Error, errors.ErrInternalDB)
+	now := time.Now()
+	if !animes[0].NextRefreshAt.After(now) {
+		return true, http.StatusOK, nil
 	}
 
-	return res.RowsAffected == 0, http.StatusOK, nil
+	_, aired := sql.airedSinceUpdate(animes[0], now)
+	return aired, http.StatusOK, nil
 }
 
-func (sql *SQL) getOldIDs(ctx context.Context, status entity.Status, age time.Duration) ([]int64, int, error) {
+// GetOldIDs to get anime ids which are due to refresh.
+// Anime saved before next refresh time existed have null
+// and are due. Popular anime come first.
+func (sql *SQL) GetOldIDs(ctx context.Context, limit int) ([]int64, int, error) {
 	var ids []int64
-	if err := sql.db.WithContext(ctx).Model(&Anime{}).Where("status = ? and updated_at <= ?", status, time.Now().Add(-age)).Pluck("id", &ids).Error; err != nil {
+	if err := sql.db.WithContext(ctx).Model(&Anime{}).
+		Where("next_refresh_at is null or next_refresh_at <= ?", time.Now()).
+		Order("popularity = 0, popularity asc, next_refresh_at asc").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
 		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
 	return ids, http.StatusOK, nil
 }
 
-// GetOldReleasingIDs to get old releasing anime ids.
-func (sql *SQL) GetOldReleasingIDs(ctx context.Context) ([]int64, int, error) {
-	return sql.getOldIDs(ctx, entity.StatusReleasing, sql.releasingAge)
+// GetAiredIDs to get releasing anime ids which aired
+// since the time and not updated yet.
+func (sql *SQL) GetAiredIDs(ctx context.Context, since time.Time) ([]int64, int, error) {
+	var animes []Anime
+	if err := sql.db.WithContext(ctx).
+		Select("id", "broadcast_day", "broadcast_time", "updated_at").
+		Where("status = ? and broadcast_day <> ? and broadcast_time <> ?", entity.StatusReleasing, entity.DayOther, "").
+		Find(&animes).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	now := time.Now()
+	ids := []int64{}
+	for _, a := range animes {
+		airedAt, ok := sql.airedSinceUpdate(a, now)
+		if !ok || airedAt.Before(since) {
+			continue
+		}
+		ids = append(ids, a.ID)
+	}
+
+	return ids, http.StatusOK, nil
 }
 
-// GetOldFinishedIDs to get old finished anime ids.
-func (sql *SQL) GetOldFinishedIDs(ctx context.Context) ([]int64, int, error) {
-	return sql.getOldIDs(ctx, entity.StatusFinished, sql.finishedAge)
+// airedSinceUpdate to get the last broadcast slot of
+// releasing anime and whether it is not updated since.
+func (sql *SQL) airedSinceUpdate(a Anime, now time.Time) (time.Time, bool) {
+	if a.Status != entity.StatusReleasing {
+		return time.Time{}, false
+	}
+
+	airedAt, ok := entity.Broadcast{Day: a.BroadcastDay, Time: a.BroadcastTime}.LastAiredAt(now)
+	if !ok || a.UpdatedAt.After(airedAt) {
+		return time.Time{}, false
+	}
+
+	return airedAt, true
+}
+
+func (sql *SQL) getRefreshAge(status entity.Status) time.Duration {
+	switch status {
+	case entity.StatusReleasing:
+		return sql.releasingAge
+	case entity.StatusNotYet:
+		return sql.notYetAge
+	default:
+		return sql.finishedAge
+	}
 }
 
-// GetOldNotYetIDs to get old not yet released anime ids.
-func (sql *SQL) GetOldNotYetIDs(ctx context.Context) ([]int64, int, error) {
-	return sql.getOldIDs(ctx, entity.StatusNotYet, sql.notYetAge)
+// getChangeRate to get relative member change per day
+// from the oldest of recent stats history.
+func (sql *SQL) getChangeRate(tx *gorm.DB, data entity.Anime) (float64, error) {
+	var histories []AnimeStatsHistory
+	if err := tx.Select("member", "created_at").
+		Where("anime_id = ?", data.ID).
+		Order("created_at desc").
+		Limit(changeRateHistoryCount).
+		Find(&histories).Error; err != nil {
+		return 0, err
+	}
+
+	if len(histories) == 0 {
+		return 0, nil
+	}
+
+	oldest := histories[len(histories)-1]
+	if oldest.Member == 0 {
+		return 0, nil
+	}
+
+	days := math.Max(1, time.Since(oldest.CreatedAt).Hours()/24)
+
+	return float64(data.Member-oldest.Member) / float64(oldest.Member) / days, nil
 }
 
 // GetMaxID to get max id.
@@ -318,6 +412,120 @@ func (sql *SQL) GetRelatedByIDs(ctx context.Context, ids []int64) ([]*entity.Ani
 	return sql.animeRelatedToEntities(ar), http.StatusOK, nil
 }
 
+// GetAllRelated to get all related anime.
+func (sql *SQL) GetAllRelated(ctx context.Context) ([]*entity.AnimeRelated, int, error) {
+	var ar []AnimeRelated
+	if err := sql.db.WithContext(ctx).Find(&ar).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return sql.animeRelatedToEntities(ar), http.StatusOK, nil
+}
+
+// GetGenresByIDs to get anime genres by ids.
+func (sql *SQL) GetGenresByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeGenre, int, error) {
+	var ag []AnimeGenre
+	if err := sql.db.WithContext(ctx).Where("anime_id in ?", ids).Find(&ag).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]*entity.AnimeGenre, len(ag))
+	for i, g := range ag {
+		res[i] = &entity.AnimeGenre{
+			AnimeID: g.AnimeID,
+			GenreID: g.GenreID,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// GetStudiosByIDs to get anime studios by ids.
+func (sql *SQL) GetStudiosByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeStudio, int, error) {
+	var as []AnimeStudio
+	if err := sql.db.WithContext(ctx).Where("anime_id in ?", ids).Find(&as).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]*entity.AnimeStudio, len(as))
+	for i, s := range as {
+		res[i] = &entity.AnimeStudio{
+			AnimeID:  s.AnimeID,
+			StudioID: s.StudioID,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// GetSeasons to get all seasons with anime count.
+func (sql *SQL) GetSeasons(ctx context.Context) ([]*entity.SeasonCount, int, error) {
+	var sc []animeSeasonCount
+	if err := sql.db.WithContext(ctx).
+		Model(&Anime{}).
+		Select("season, season_year, count(*) as count").
+		Where("season <> '' and season_year > 0").
+		Group("season, season_year").
+		Find(&sc).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]*entity.SeasonCount, len(sc))
+	for i, s := range sc {
+		res[i] = &entity.SeasonCount{
+			Season: s.Season,
+			Year:   s.SeasonYear,
+			Count:  s.Count,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// GetStatsChanges to get anime stats changes since the time.
+//
+// Baseline is the last stats history at or before since,
+// looked up to one period earlier, so the change doesn't
+// depend on when the anime happened to be refreshed.
+// Anime without older history use their first stats
+// history in the period. Only anime with stats history
+// in the period are returned.
+func (sql *SQL) GetStatsChanges(ctx context.Context, since time.Time) ([]*entity.StatsChange, int, error) {
+	period := sql.db.WithContext(ctx).
+		Model(&AnimeStatsHistory{}).
+		Select("anime_id, coalesce(max(case when created_at <= ? then created_at end), min(created_at)) as start_at, max(created_at) as end_at", since).
+		Where("created_at >= ?", since.Add(-time.Since(since))).
+		Group("anime_id").
+		Having("max(created_at) > ?", since)
+
+	var sc []animeStatsChange
+	if err := sql.db.WithContext(ctx).
+		Table("(?) as p", period).
+		Select("p.anime_id, s.member as start_member, e.member as end_member, s.rank as start_rank, e.rank as end_rank, s.mean as start_mean, e.mean as end_mean, p.start_at, p.end_at").
+		Joins("join anime_stats_history s on s.anime_id = p.anime_id and s.created_at = p.start_at").
+		Joins("join anime_stats_history e on e.anime_id = p.anime_id and e.created_at = p.end_at").
+		Where("p.start_at < p.end_at").
+		Find(&sc).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]*entity.StatsChange, len(sc))
+	for i, s := range sc {
+		res[i] = &entity.StatsChange{
+			AnimeID:     s.AnimeID,
+			StartMember: s.StartMember,
+			EndMember:   s.EndMember,
+			StartRank:   s.StartRank,
+			EndRank:     s.EndRank,
+			StartMean:   s.StartMean,
+			EndMean:     s.EndMean,
+			StartAt:     s.StartAt,
+			EndAt:       s.EndAt,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
 // DeleteByID to delete by id.
 func (sql *SQL) DeleteByID(ctx context.Context, id int64) (int, error) {
 	tx := sql.db.WithContext(ctx).Begin()
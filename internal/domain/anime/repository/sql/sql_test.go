@@ -122,7 +122,7 @@ func (suite *testSuite) TestGet() {
 				StudioID:        4,
 				Limit:           1,
 			},
-			query: `SELECT "anime"."id","anime"."title","anime"."title_synonym","anime"."title_english","anime"."title_japanese","anime"."picture","anime"."start_day","anime"."start_month","anime"."start_year","anime"."end_day","anime"."end_month","anime"."end_year","anime"."synopsis","anime"."nsfw","anime"."type","anime"."status","anime"."episode","anime"."episode_duration","anime"."season","anime"."season_year","anime"."broadcast_day","anime"."broadcast_time","anime"."source","anime"."rating","anime"."background","anime"."mean","anime"."rank","anime"."popularity","anime"."member","anime"."voter","anime"."user_watching","anime"."user_completed","anime"."user_on_hold","anime"."user_dropped","anime"."user_planned","anime"."created_at","anime"."updated_at","anime"."deleted_at" FROM "anime" join (SELECT "anime_id" FROM "anime_genre" WHERE genre_id = $1) ag on ag.anime_id = id join (SELECT "anime_id" FROM "anime_studio" WHERE studio_id = $2) ast on ast.anime_id = id WHERE (title ilike $3 or title_synonym ilike $4 or title_english ilike $5 or title_japanese ilike $6) AND nsfw = $7 AND type = $8 AND status = $9 AND season = $10 AND season_year = $11 AND mean >= $12 AND mean <= $13 AND start_year >= $14 AND start_year <= $15 AND "anime"."deleted_at" IS NULL ORDER BY rank = 0 nulls last, rank asc LIMIT $16`,
+			query: `SELECT "anime"."id","anime"."title","anime"."title_synonym","anime"."title_english","anime"."title_japanese","anime"."picture","anime"."start_day","anime"."start_month","anime"."start_year","anime"."end_day","anime"."end_month","anime"."end_year","anime"."synopsis","anime"."nsfw","anime"."type","anime"."status","anime"."episode","anime"."episode_duration","anime"."season","anime"."season_year","anime"."broadcast_day","anime"."broadcast_time","anime"."source","anime"."rating","anime"."background","anime"."mean","anime"."rank","anime"."popularity","anime"."member","anime"."voter","anime"."user_watching","anime"."user_completed","anime"."user_on_hold","anime"."user_dropped","anime"."user_planned","anime"."next_refresh_at","anime"."created_at","anime"."updated_at","anime"."deleted_at" FROM "anime" join (SELECT "anime_id" FROM "anime_genre" WHERE genre_id = $1) ag on ag.anime_id = id join (SELECT "anime_id" FROM "anime_studio" WHERE studio_id = $2) ast on ast.anime_id = id WHERE (title ilike $3 or title_synonym ilike $4 or title_english ilike $5 or title_japanese ilike $6) AND nsfw = $7 AND type = $8 AND status = $9 AND season = $10 AND season_year = $11 AND mean >= $12 AND mean <= $13 AND start_year >= $14 AND start_year <= $15 AND "anime"."deleted_at" IS NULL ORDER BY rank = 0 nulls last, rank asc LIMIT $16`,
 			queryArgs: []driver.Value{
 				3,
 				4,
@@ -516,6 +516,11 @@ func (suite *testSuite) TestUpdate() {
 		selectQueryArgs          []driver.Value
 		selectQueryReturn        []*sqlmock.Rows
 		selectQueryError         error
+		selectHistoryCalled      bool
+		selectHistoryQuery       string
+		selectHistoryQueryArgs   []driver.Value
+		selectHistoryQueryReturn []*sqlmock.Rows
+		selectHistoryQueryError  error
 		saveCalled               bool
 		saveQuery                string
 		saveQueryArgs            []driver.Value
@@ -566,6 +571,11 @@ func (suite *testSuite) TestUpdate() {
 		createHistoryQueryArgs   []driver.Value
 		createHistoryQueryReturn []*sqlmock.Rows
 		createHistoryQueryError  error
+		createOutboxCalled       bool
+		createOutboxQuery        string
+		createOutboxQueryArgs    []driver.Value
+		createOutboxQueryReturn  []*sqlmock.Rows
+		createOutboxQueryError   error
 		rollbackCalled           bool
 		commitCalled             bool
 		commitError              error
@@ -592,67 +602,99 @@ func (suite *testSuite) TestUpdate() {
 			expectedError:     errors.ErrInternalDB,
 		},
 		{
-			name:              "error-save",
-			param:             anime,
-			selectCalled:      true,
-			selectQuery:       `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
-			selectQueryArgs:   []driver.Value{1, 1},
-			selectQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
-			selectQueryError:  nil,
-			saveCalled:        true,
-			saveQuery:         `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:     []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
-			saveQueryError:    errDummy,
-			rollbackCalled:    true,
-			expectedCode:      http.StatusInternalServerError,
-			expectedError:     errors.ErrInternalDB,
+			name:                     "error-select-history",
+			param:                    anime,
+			selectCalled:             true,
+			selectQuery:              `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
+			selectQueryArgs:          []driver.Value{1, 1},
+			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
+			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{},
+			selectHistoryQueryError:  errDummy,
+			rollbackCalled:           true,
+			expectedCode:             http.StatusInternalServerError,
+			expectedError:            errors.ErrInternalDB,
 		},
 		{
-			name:                  "error-delete-genre",
-			param:                 anime,
-			selectCalled:          true,
-			selectQuery:           `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
-			selectQueryArgs:       []driver.Value{1, 1},
-			selectQueryReturn:     []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
-			selectQueryError:      nil,
-			saveCalled:            true,
-			saveQuery:             `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:         []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
-			saveQueryResult:       sqlmock.NewResult(0, 1),
-			saveQueryError:        nil,
-			deleteGenreCalled:     true,
-			deleteGenreQuery:      `DELETE FROM "anime_genre" WHERE anime_id = $1`,
-			deleteGenreQueryArgs:  []driver.Value{1},
-			deleteGenreQueryError: errDummy,
-			rollbackCalled:        true,
-			expectedCode:          http.StatusInternalServerError,
-			expectedError:         errors.ErrInternalDB,
+			name:                     "error-save",
+			param:                    anime,
+			selectCalled:             true,
+			selectQuery:              `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
+			selectQueryArgs:          []driver.Value{1, 1},
+			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
+			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
+			saveCalled:               true,
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
+			saveQueryError:           errDummy,
+			rollbackCalled:           true,
+			expectedCode:             http.StatusInternalServerError,
+			expectedError:            errors.ErrInternalDB,
 		},
 		{
-			name:                   "error-create-genre",
-			param:                  anime,
-			selectCalled:           true,
-			selectQuery:            `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
-			selectQueryArgs:        []driver.Value{1, 1},
-			selectQueryReturn:      []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
-			selectQueryError:       nil,
-			saveCalled:             true,
-			saveQuery:              `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:          []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
-			saveQueryResult:        sqlmock.NewResult(0, 1),
-			saveQueryError:         nil,
-			deleteGenreCalled:      true,
-			deleteGenreQuery:       `DELETE FROM "anime_genre" WHERE anime_id = $1`,
-			deleteGenreQueryArgs:   []driver.Value{1},
-			deleteGenreQueryResult: sqlmock.NewResult(0, 1),
-			deleteGenreQueryError:  nil,
-			createGenreCalled:      true,
-			createGenreQuery:       `INSERT INTO "anime_genre" ("anime_id","genre_id") VALUES ($1,$2)`,
-			createGenreQueryArgs:   []driver.Value{1, 2},
-			createGenreQueryError:  errDummy,
-			rollbackCalled:         true,
-			expectedCode:           http.StatusInternalServerError,
-			expectedError:          errors.ErrInternalDB,
+			name:                     "error-delete-genre",
+			param:                    anime,
+			selectCalled:             true,
+			selectQuery:              `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
+			selectQueryArgs:          []driver.Value{1, 1},
+			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
+			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
+			saveCalled:               true,
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
+			saveQueryResult:          sqlmock.NewResult(0, 1),
+			saveQueryError:           nil,
+			deleteGenreCalled:        true,
+			deleteGenreQuery:         `DELETE FROM "anime_genre" WHERE anime_id = $1`,
+			deleteGenreQueryArgs:     []driver.Value{1},
+			deleteGenreQueryError:    errDummy,
+			rollbackCalled:           true,
+			expectedCode:             http.StatusInternalServerError,
+			expectedError:            errors.ErrInternalDB,
+		},
+		{
+			name:                     "error-create-genre",
+			param:                    anime,
+			selectCalled:             true,
+			selectQuery:              `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
+			selectQueryArgs:          []driver.Value{1, 1},
+			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
+			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
+			saveCalled:               true,
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
+			saveQueryResult:          sqlmock.NewResult(0, 1),
+			saveQueryError:           nil,
+			deleteGenreCalled:        true,
+			deleteGenreQuery:         `DELETE FROM "anime_genre" WHERE anime_id = $1`,
+			deleteGenreQueryArgs:     []driver.Value{1},
+			deleteGenreQueryResult:   sqlmock.NewResult(0, 1),
+			deleteGenreQueryError:    nil,
+			createGenreCalled:        true,
+			createGenreQuery:         `INSERT INTO "anime_genre" ("anime_id","genre_id") VALUES ($1,$2)`,
+			createGenreQueryArgs:     []driver.Value{1, 2},
+			createGenreQueryError:    errDummy,
+			rollbackCalled:           true,
+			expectedCode:             http.StatusInternalServerError,
+			expectedError:            errors.ErrInternalDB,
 		},
 		{
 			name:                     "error-delete-picture",
@@ -662,9 +704,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -694,9 +741,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -731,9 +783,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -773,9 +830,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -820,9 +882,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -872,9 +939,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -929,9 +1001,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -983,6 +1060,78 @@ func (suite *testSuite) TestUpdate() {
 			expectedCode:             http.StatusInternalServerError,
 			expectedError:            errors.ErrInternalDB,
 		},
+		{
+			name:                     "error-create-outbox",
+			param:                    anime,
+			selectCalled:             true,
+			selectQuery:              `SELECT "created_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL ORDER BY "anime"."id" LIMIT $2`,
+			selectQueryArgs:          []driver.Value{1, 1},
+			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
+			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
+			saveCalled:               true,
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
+			saveQueryResult:          sqlmock.NewResult(0, 1),
+			saveQueryError:           nil,
+			deleteGenreCalled:        true,
+			deleteGenreQuery:         `DELETE FROM "anime_genre" WHERE anime_id = $1`,
+			deleteGenreQueryArgs:     []driver.Value{1},
+			deleteGenreQueryResult:   sqlmock.NewResult(0, 1),
+			deleteGenreQueryError:    nil,
+			createGenreCalled:        true,
+			createGenreQuery:         `INSERT INTO "anime_genre" ("anime_id","genre_id") VALUES ($1,$2)`,
+			createGenreQueryArgs:     []driver.Value{1, 2},
+			createGenreQueryResult:   sqlmock.NewResult(0, 1),
+			createGenreQueryError:    nil,
+			deletePictureCalled:      true,
+			deletePictureQuery:       `DELETE FROM "anime_picture" WHERE anime_id = $1`,
+			deletePictureQueryArgs:   []driver.Value{1},
+			deletePictureQueryResult: sqlmock.NewResult(0, 1),
+			deletePictureQueryError:  nil,
+			createPictureCalled:      true,
+			createPictureQuery:       `INSERT INTO "anime_picture" ("anime_id","url") VALUES ($1,$2)`,
+			createPictureQueryArgs:   []driver.Value{1, "www"},
+			createPictureQueryResult: sqlmock.NewResult(0, 1),
+			createPictureQueryError:  nil,
+			deleteRelatedCalled:      true,
+			deleteRelatedQuery:       `DELETE FROM "anime_related" WHERE anime_id1 = $1`,
+			deleteRelatedQueryArgs:   []driver.Value{1},
+			deleteRelatedQueryResult: sqlmock.NewResult(0, 1),
+			deleteRelatedQueryError:  nil,
+			createRelatedCalled:      true,
+			createRelatedQuery:       `INSERT INTO "anime_related" ("anime_id1","anime_id2","relation") VALUES ($1,$2,$3)`,
+			createRelatedQueryArgs:   []driver.Value{1, 3, "FULL_STORY"},
+			createRelatedQueryResult: sqlmock.NewResult(0, 1),
+			createRelatedQueryError:  nil,
+			deleteStudioCalled:       true,
+			deleteStudioQuery:        `DELETE FROM "anime_studio" WHERE anime_id = $1`,
+			deleteStudioQueryArgs:    []driver.Value{1},
+			deleteStudioQueryResult:  sqlmock.NewResult(0, 1),
+			deleteStudioQueryError:   nil,
+			createStudioCalled:       true,
+			createStudioQuery:        `INSERT INTO "anime_studio" ("anime_id","studio_id") VALUES ($1,$2)`,
+			createStudioQueryArgs:    []driver.Value{1, 4},
+			createStudioQueryResult:  sqlmock.NewResult(0, 1),
+			createStudioQueryError:   nil,
+			createHistoryCalled:      true,
+			createHistoryQuery:       `INSERT INTO "anime_stats_history" ("anime_id","mean","rank","popularity","member","voter","user_watching","user_completed","user_on_hold","user_dropped","user_planned","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING "id`,
+			createHistoryQueryArgs:   []driver.Value{1, 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg()},
+			createHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
+			createHistoryQueryError:  nil,
+			createOutboxCalled:       true,
+			createOutboxQuery:        `INSERT INTO "outbox" ("type","anime_id","username","status","message","publish_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`,
+			createOutboxQueryArgs:    []driver.Value{"parse-anime", 3, "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()},
+			createOutboxQueryReturn:  []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
+			createOutboxQueryError:   errDummy,
+			rollbackCalled:           true,
+			expectedCode:             http.StatusInternalServerError,
+			expectedError:            errors.ErrInternalDB,
+		},
 		{
 			name:                     "error-commit",
 			param:                    anime,
@@ -991,9 +1140,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -1041,6 +1195,11 @@ func (suite *testSuite) TestUpdate() {
 			createHistoryQueryArgs:   []driver.Value{1, 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg()},
 			createHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
 			createHistoryQueryError:  nil,
+			createOutboxCalled:       true,
+			createOutboxQuery:        `INSERT INTO "outbox" ("type","anime_id","username","status","message","publish_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`,
+			createOutboxQueryArgs:    []driver.Value{"parse-anime", 3, "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()},
+			createOutboxQueryReturn:  []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
+			createOutboxQueryError:   nil,
 			commitCalled:             true,
 			commitError:              errDummy,
 			expectedCode:             http.StatusInternalServerError,
@@ -1054,9 +1213,14 @@ func (suite *testSuite) TestUpdate() {
 			selectQueryArgs:          []driver.Value{1, 1},
 			selectQueryReturn:        []*sqlmock.Rows{sqlmock.NewRows([]string{"created_at"}).AddRow(&now)},
 			selectQueryError:         nil,
+			selectHistoryCalled:      true,
+			selectHistoryQuery:       `SELECT "member","created_at" FROM "anime_stats_history" WHERE anime_id = $1 ORDER BY created_at desc LIMIT $2`,
+			selectHistoryQueryArgs:   []driver.Value{1, 10},
+			selectHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"member", "created_at"})},
+			selectHistoryQueryError:  nil,
 			saveCalled:               true,
-			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"created_at"=$35,"updated_at"=$36,"deleted_at"=$37 WHERE "anime"."deleted_at" IS NULL AND "id" = $38`,
-			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, now, sqlmock.AnyArg(), nil, 1},
+			saveQuery:                `UPDATE "anime" SET "title"=$1,"title_synonym"=$2,"title_english"=$3,"title_japanese"=$4,"picture"=$5,"start_day"=$6,"start_month"=$7,"start_year"=$8,"end_day"=$9,"end_month"=$10,"end_year"=$11,"synopsis"=$12,"nsfw"=$13,"type"=$14,"status"=$15,"episode"=$16,"episode_duration"=$17,"season"=$18,"season_year"=$19,"broadcast_day"=$20,"broadcast_time"=$21,"source"=$22,"rating"=$23,"background"=$24,"mean"=$25,"rank"=$26,"popularity"=$27,"member"=$28,"voter"=$29,"user_watching"=$30,"user_completed"=$31,"user_on_hold"=$32,"user_dropped"=$33,"user_planned"=$34,"next_refresh_at"=$35,"created_at"=$36,"updated_at"=$37,"deleted_at"=$38 WHERE "anime"."deleted_at" IS NULL AND "id" = $39`,
+			saveQueryArgs:            []driver.Value{anime.Title, "[]", "", "", "", 0, 0, 0, 0, 0, 0, "", false, "", "", 0, 0, "", 0, "", "", "", "", "", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), now, sqlmock.AnyArg(), nil, 1},
 			saveQueryResult:          sqlmock.NewResult(0, 1),
 			saveQueryError:           nil,
 			deleteGenreCalled:        true,
@@ -1104,6 +1268,11 @@ func (suite *testSuite) TestUpdate() {
 			createHistoryQueryArgs:   []driver.Value{1, 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg()},
 			createHistoryQueryReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
 			createHistoryQueryError:  nil,
+			createOutboxCalled:       true,
+			createOutboxQuery:        `INSERT INTO "outbox" ("type","anime_id","username","status","message","publish_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`,
+			createOutboxQueryArgs:    []driver.Value{"parse-anime", 3, "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()},
+			createOutboxQueryReturn:  []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
+			createOutboxQueryError:   nil,
 			commitCalled:             true,
 			commitError:              nil,
 			expectedCode:             http.StatusOK,
@@ -1122,6 +1291,13 @@ func (suite *testSuite) TestUpdate() {
 					WillReturnError(test.selectQueryError)
 			}
 
+			if test.selectHistoryCalled {
+				suite.dbMock.ExpectQuery(regexp.QuoteMeta(test.selectHistoryQuery)).
+					WithArgs(test.selectHistoryQueryArgs...).
+					WillReturnRows(test.selectHistoryQueryReturn...).
+					WillReturnError(test.selectHistoryQueryError)
+			}
+
 			if test.saveCalled {
 				suite.dbMock.ExpectExec(regexp.QuoteMeta(test.saveQuery)).
 					WithArgs(test.saveQueryArgs...).
@@ -1192,6 +1368,13 @@ func (suite *testSuite) TestUpdate() {
 					WillReturnError(test.createHistoryQueryError)
 			}
 
+			if test.createOutboxCalled {
+				suite.dbMock.ExpectQuery(regexp.QuoteMeta(test.createOutboxQuery)).
+					WithArgs(test.createOutboxQueryArgs...).
+					WillReturnRows(test.createOutboxQueryReturn...).
+					WillReturnError(test.createOutboxQueryError)
+			}
+
 			if test.rollbackCalled {
 				suite.dbMock.ExpectRollback()
 			}
@@ -1209,3 +1392,87 @@ func (suite *testSuite) TestUpdate() {
 		})
 	}
 }
+
+func (suite *testSuite) TestGetStatsChanges() {
+	ctx := context.Background()
+	now := time.Now()
+	since := now.Add(-7 * 24 * time.Hour)
+
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT p.anime_id, s.member as start_member, e.member as end_member, s.rank as start_rank, e.rank as end_rank, s.mean as start_mean, e.mean as end_mean, p.start_at, p.end_at FROM (SELECT anime_id, coalesce(max(case when created_at <= $1 then created_at end), min(created_at)) as start_at, max(created_at) as end_at FROM "anime_stats_history" WHERE created_at >= $2 GROUP BY "anime_id" HAVING max(created_at) > $3) as p join anime_stats_history s on s.anime_id = p.anime_id and s.created_at = p.start_at join anime_stats_history e on e.anime_id = p.anime_id and e.created_at = p.end_at WHERE p.start_at < p.end_at`)).
+		WithArgs(since, sqlmock.AnyArg(), since).
+		WillReturnRows(sqlmock.NewRows([]string{"anime_id", "start_member", "end_member", "start_at", "end_at"}).
+			AddRow(1, 100, 150, since.Add(-time.Hour), now))
+
+	data, code, err := sql.New(suite.db, 0, 0, 0).GetStatsChanges(ctx, since)
+	suite.Equal([]*entity.StatsChange{{AnimeID: 1, StartMember: 100, EndMember: 150, StartAt: since.Add(-time.Hour), EndAt: now}}, data)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+}
+
+func (suite *testSuite) TestGetOldIDs() {
+	ctx := context.Background()
+
+	// Anime saved before next_refresh_at existed.
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "anime" WHERE (next_refresh_at is null or next_refresh_at <= $1) AND "anime"."deleted_at" IS NULL ORDER BY popularity = 0, popularity asc, next_refresh_at asc LIMIT $2`)).
+		WithArgs(sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	ids, code, err := sql.New(suite.db, 0, 0, 0).GetOldIDs(ctx, 10)
+	suite.Equal([]int64{1}, ids)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+}
+
+func (suite *testSuite) TestIsOld() {
+	ctx := context.Background()
+	now := time.Now()
+	future := now.Add(time.Hour)
+	broadcast := entity.Broadcast{Day: entity.DayFromWeekday(now.In(entity.JST).Weekday()), Time: now.In(entity.JST).Add(-time.Minute).Format("15:04")}
+	airedAt, _ := broadcast.LastAiredAt(now)
+
+	tests := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		expected bool
+	}{
+		{
+			name:     "not-found",
+			rows:     sqlmock.NewRows([]string{"status"}),
+			expected: true,
+		},
+		{
+			name: "null-next-refresh",
+			rows: sqlmock.NewRows([]string{"status", "broadcast_day", "broadcast_time", "next_refresh_at", "updated_at"}).
+				AddRow(entity.StatusFinished, "", "", nil, now),
+			expected: true,
+		},
+		{
+			name: "not-due",
+			rows: sqlmock.NewRows([]string{"status", "broadcast_day", "broadcast_time", "next_refresh_at", "updated_at"}).
+				AddRow(entity.StatusReleasing, broadcast.Day, broadcast.Time, future, airedAt.Add(time.Second)),
+			expected: false,
+		},
+		{
+			name: "aired-since-update",
+			rows: sqlmock.NewRows([]string{"status", "broadcast_day", "broadcast_time", "next_refresh_at", "updated_at"}).
+				AddRow(entity.StatusReleasing, broadcast.Day, broadcast.Time, future, airedAt.Add(-time.Second)),
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT "status","broadcast_day","broadcast_time","next_refresh_at","updated_at" FROM "anime" WHERE id = $1 AND "anime"."deleted_at" IS NULL LIMIT $2`)).
+				WithArgs(1, 1).
+				WillReturnRows(test.rows)
+
+			isOld, code, err := sql.New(suite.db, 0, 0, 0).IsOld(ctx, 1)
+			suite.Equal(test.expected, isOld)
+			suite.Equal(http.StatusOK, code)
+			suite.Nil(err)
+			suite.Nil(suite.dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Scope is api key access scope.
+type Scope string
+
+// Available scopes.
+const (
+	ScopeRead          Scope = "READ"
+	ScopeTriggerUpdate Scope = "TRIGGER_UPDATE"
+	ScopeAdmin         Scope = "ADMIN"
+)
+
+// APIKey is entity for api key.
+// Only the key hash is saved.
+type APIKey struct {
+	ID           int64
+	Name         string
+	Hash         string
+	Scopes       []Scope
+	Rate         float64
+	Burst        int
+	RequestCount int
+	LimitedCount int
+	LastUsedAt   *time.Time
+	CreatedAt    time.Time
+}
+
+// HasScope to check if api key has the scope.
+// Admin scope has access to all scopes.
+func (a APIKey) HasScope(scope Scope) bool {
+	for _, s := range a.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage is api key usage counter to be added.
+type Usage struct {
+	ID         int64
+	Request    int
+	Limited    int
+	LastUsedAt time.Time
+}
+
+// GetRequest is get api key list request model.
+type GetRequest struct {
+	Page  int
+	Limit int
+}
@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/domain/api_key/repository"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Batch contains functions to collect api key usage
+// counters in memory and save them periodically so
+// requests don't wait for database update.
+type Batch struct {
+	repo     repository.Repository
+	interval time.Duration
+
+	sync.Mutex
+	usages map[int64]entity.Usage
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New to create new api_key usage batch.
+func New(repo repository.Repository, interval time.Duration) *Batch {
+	return &Batch{
+		repo:     repo,
+		interval: interval,
+		usages:   make(map[int64]entity.Usage),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Create to create api key.
+func (b *Batch) Create(ctx context.Context, data entity.APIKey) (*entity.APIKey, int, error) {
+	return b.repo.Create(ctx, data)
+}
+
+// GetByID to get api key by id.
+func (b *Batch) GetByID(ctx context.Context, id int64) (*entity.APIKey, int, error) {
+	return b.repo.GetByID(ctx, id)
+}
+
+// GetByHash to get api key by key hash.
+func (b *Batch) GetByHash(ctx context.Context, hash string) (*entity.APIKey, int, error) {
+	return b.repo.GetByHash(ctx, hash)
+}
+
+// Get to get api key list.
+func (b *Batch) Get(ctx context.Context, data entity.GetRequest) ([]*entity.APIKey, int, int, error) {
+	return b.repo.Get(ctx, data)
+}
+
+// Delete to delete api key.
+func (b *Batch) Delete(ctx context.Context, id int64) (int, error) {
+	return b.repo.Delete(ctx, id)
+}
+
+// AddUsage to add api key usage counter to the batch.
+func (b *Batch) AddUsage(_ context.Context, data entity.Usage) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	u := b.usages[data.ID]
+	u.ID = data.ID
+	u.Request += data.Request
+	u.Limited += data.Limited
+	if data.LastUsedAt.After(u.LastUsedAt) {
+		u.LastUsedAt = data.LastUsedAt
+	}
+	b.usages[data.ID] = u
+
+	return http.StatusOK, nil
+}
+
+// Run to start saving usage counters in the background.
+func (b *Batch) Run() {
+	go func() {
+		defer close(b.done)
+
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-b.stop:
+				return
+			case <-ticker.C:
+				b.flush()
+			}
+		}
+	}()
+}
+
+func (b *Batch) flush() {
+	b.Lock()
+	usages := b.usages
+	b.usages = make(map[int64]entity.Usage)
+	b.Unlock()
+
+	ctx := stack.Init(context.Background())
+
+	for _, u := range usages {
+		if _, err := b.repo.AddUsage(ctx, u); err != nil {
+			stack.Wrap(ctx, err)
+		}
+	}
+
+	if errStack := stack.Get(ctx); len(errStack) > 0 {
+		utils.Log(map[string]interface{}{
+			"level": utils.ErrorLevel,
+			"error": errStack,
+		})
+	}
+}
+
+// Close to stop the batch and save the remaining
+// usage counters.
+func (b *Batch) Close() error {
+	close(b.stop)
+	<-b.done
+	b.flush()
+	return nil
+}
@@ -0,0 +1,36 @@
+package batch_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/domain/api_key/repository/batch"
+	mockRepository "github.com/rl404/akatsuki/tests/mocks/domain/api_key"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddUsage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := new(mockRepository.Repository)
+	repo.On("AddUsage", mock.Anything, entity.Usage{ID: 1, Request: 2, Limited: 1, LastUsedAt: now}).Return(http.StatusOK, nil).Once()
+	repo.On("AddUsage", mock.Anything, entity.Usage{ID: 2, Request: 1, LastUsedAt: now}).Return(http.StatusOK, nil).Once()
+
+	b := batch.New(repo, time.Hour)
+	b.Run()
+
+	b.AddUsage(ctx, entity.Usage{ID: 1, Request: 1, Limited: 1, LastUsedAt: now.Add(-time.Second)})
+	b.AddUsage(ctx, entity.Usage{ID: 1, Request: 1, LastUsedAt: now})
+	b.AddUsage(ctx, entity.Usage{ID: 2, Request: 1, LastUsedAt: now})
+
+	// Not saved until flushed.
+	repo.AssertNotCalled(t, "AddUsage", mock.Anything, mock.Anything)
+
+	// Remaining usages are saved on close.
+	b.Close()
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/domain/api_key/repository"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/cache"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Cache contains functions for api_key cache.
+// Cacher should have short expiration time since
+// deleted key is only removed from this cacher.
+// MissCacher caches unknown key so invalid keys won't
+// hit the database every request. It should have even
+// shorter expiration time for the same reason.
+type Cache struct {
+	cacher     cache.Cacher
+	missCacher cache.Cacher
+	repo       repository.Repository
+}
+
+// New to create new api_key cache.
+func New(cacher, missCacher cache.Cacher, repo repository.Repository) *Cache {
+	return &Cache{
+		cacher:     cacher,
+		missCacher: missCacher,
+		repo:       repo,
+	}
+}
+
+// Create to create api key.
+func (c *Cache) Create(ctx context.Context, data entity.APIKey) (*entity.APIKey, int, error) {
+	apiKey, code, err := c.repo.Create(ctx, data)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	// New key should work right away.
+	if err := c.missCacher.Delete(ctx, utils.GetKey("api-key-miss", apiKey.Hash)); err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	return apiKey, code, nil
+}
+
+// GetByID to get api key by id.
+func (c *Cache) GetByID(ctx context.Context, id int64) (*entity.APIKey, int, error) {
+	return c.repo.GetByID(ctx, id)
+}
+
+// GetByHash to get api key by key hash.
+func (c *Cache) GetByHash(ctx context.Context, hash string) (data *entity.APIKey, code int, err error) {
+	key := utils.GetKey("api-key", hash)
+	if c.cacher.Get(ctx, key, &data) == nil {
+		return data, http.StatusOK, nil
+	}
+
+	var miss bool
+	missKey := utils.GetKey("api-key-miss", hash)
+	if c.missCacher.Get(ctx, missKey, &miss) == nil && miss {
+		return nil, http.StatusUnauthorized, stack.Wrap(ctx, errors.ErrInvalidAPIKey)
+	}
+
+	data, code, err = c.repo.GetByHash(ctx, hash)
+	if err != nil {
+		if _errors.Is(err, errors.ErrInvalidAPIKey) {
+			if err := c.missCacher.Set(ctx, missKey, true); err != nil {
+				return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+			}
+		}
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if err := c.cacher.Set(ctx, key, data); err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	return data, code, nil
+}
+
+// Get to get api key list.
+func (c *Cache) Get(ctx context.Context, data entity.GetRequest) ([]*entity.APIKey, int, int, error) {
+	return c.repo.Get(ctx, data)
+}
+
+// Delete to delete api key.
+func (c *Cache) Delete(ctx context.Context, id int64) (int, error) {
+	apiKey, code, err := c.repo.GetByID(ctx, id)
+	if err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
+	if code, err := c.repo.Delete(ctx, id); err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
+	if err := c.cacher.Delete(ctx, utils.GetKey("api-key", apiKey.Hash)); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	return http.StatusOK, nil
+}
+
+// AddUsage to add api key usage counter.
+func (c *Cache) AddUsage(ctx context.Context, data entity.Usage) (int, error) {
+	return c.repo.AddUsage(ctx, data)
+}
@@ -0,0 +1,66 @@
+package cache_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/domain/api_key/repository/cache"
+	"github.com/rl404/akatsuki/internal/errors"
+	mockRepository "github.com/rl404/akatsuki/tests/mocks/domain/api_key"
+	"github.com/rl404/fairy/cache/inmemory"
+	"github.com/stretchr/testify/suite"
+)
+
+type testSuite struct {
+	suite.Suite
+	repoMock *mockRepository.Repository
+	cache    *cache.Cache
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (suite *testSuite) SetupTest() {
+	cacher, err := inmemory.New(time.Minute)
+	suite.Require().NoError(err)
+
+	missCacher, err := inmemory.New(time.Minute)
+	suite.Require().NoError(err)
+
+	suite.repoMock = new(mockRepository.Repository)
+	suite.cache = cache.New(cacher, missCacher, suite.repoMock)
+}
+
+func (suite *testSuite) TestGetByHashMiss() {
+	ctx := context.Background()
+
+	suite.repoMock.On("GetByHash", ctx, "hash").Return(nil, http.StatusUnauthorized, errors.ErrInvalidAPIKey).Once()
+
+	// Second call is served from miss cache.
+	for range 2 {
+		data, code, err := suite.cache.GetByHash(ctx, "hash")
+		suite.Nil(data)
+		suite.Equal(http.StatusUnauthorized, code)
+		suite.ErrorIs(err, errors.ErrInvalidAPIKey)
+	}
+
+	// New key is not rejected by miss cache.
+	apiKey := &entity.APIKey{ID: 1, Hash: "hash"}
+	suite.repoMock.On("Create", ctx, entity.APIKey{Hash: "hash"}).Return(apiKey, http.StatusCreated, nil).Once()
+	suite.repoMock.On("GetByHash", ctx, "hash").Return(apiKey, http.StatusOK, nil).Once()
+
+	_, code, err := suite.cache.Create(ctx, entity.APIKey{Hash: "hash"})
+	suite.Equal(http.StatusCreated, code)
+	suite.Nil(err)
+
+	data, code, err := suite.cache.GetByHash(ctx, "hash")
+	suite.Equal(apiKey, data)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.repoMock.AssertExpectations(suite.T())
+}
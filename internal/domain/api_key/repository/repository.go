@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+)
+
+// Repository contains functions for api_key domain.
+type Repository interface {
+	Create(ctx context.Context, data entity.APIKey) (*entity.APIKey, int, error)
+	GetByID(ctx context.Context, id int64) (*entity.APIKey, int, error)
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, int, error)
+	Get(ctx context.Context, data entity.GetRequest) ([]*entity.APIKey, int, int, error)
+	Delete(ctx context.Context, id int64) (int, error)
+	AddUsage(ctx context.Context, data entity.Usage) (int, error)
+}
@@ -0,0 +1,72 @@
+package sql
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+)
+
+// APIKey is api_key database model.
+type APIKey struct {
+	ID           int64 `gorm:"primaryKey"`
+	Name         string
+	Hash         string `gorm:"size:64;uniqueIndex"`
+	Scopes       string // comma separated
+	Rate         float64
+	Burst        int
+	RequestCount int `gorm:"not null;default:0"`
+	LimitedCount int `gorm:"not null;default:0"`
+	LastUsedAt   *time.Time
+	CreatedAt    time.Time
+}
+
+func (sql *SQL) fromEntity(data entity.APIKey) APIKey {
+	scopes := make([]string, len(data.Scopes))
+	for i, s := range data.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return APIKey{
+		ID:           data.ID,
+		Name:         data.Name,
+		Hash:         data.Hash,
+		Scopes:       strings.Join(scopes, ","),
+		Rate:         data.Rate,
+		Burst:        data.Burst,
+		RequestCount: data.RequestCount,
+		LimitedCount: data.LimitedCount,
+		LastUsedAt:   data.LastUsedAt,
+		CreatedAt:    data.CreatedAt,
+	}
+}
+
+func (a *APIKey) toEntity() *entity.APIKey {
+	var scopes []entity.Scope
+	for _, s := range strings.Split(a.Scopes, ",") {
+		if s != "" {
+			scopes = append(scopes, entity.Scope(s))
+		}
+	}
+
+	return &entity.APIKey{
+		ID:           a.ID,
+		Name:         a.Name,
+		Hash:         a.Hash,
+		Scopes:       scopes,
+		Rate:         a.Rate,
+		Burst:        a.Burst,
+		RequestCount: a.RequestCount,
+		LimitedCount: a.LimitedCount,
+		LastUsedAt:   a.LastUsedAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func (sql *SQL) toEntities(data []APIKey) []*entity.APIKey {
+	a := make([]*entity.APIKey, len(data))
+	for i, aa := range data {
+		a[i] = aa.toEntity()
+	}
+	return a
+}
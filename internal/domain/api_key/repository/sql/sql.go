@@ -0,0 +1,98 @@
+package sql
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+// SQL contains functions for api_key sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new api_key database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Create to create api key.
+func (sql *SQL) Create(ctx context.Context, data entity.APIKey) (*entity.APIKey, int, error) {
+	a := sql.fromEntity(data)
+	if err := sql.db.WithContext(ctx).Create(&a).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return a.toEntity(), http.StatusCreated, nil
+}
+
+// GetByID to get api key by id.
+func (sql *SQL) GetByID(ctx context.Context, id int64) (*entity.APIKey, int, error) {
+	var a APIKey
+	if err := sql.db.WithContext(ctx).Where("id = ?", id).First(&a).Error; err != nil {
+		if _errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, stack.Wrap(ctx, err, errors.ErrAPIKeyNotFound)
+		}
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return a.toEntity(), http.StatusOK, nil
+}
+
+// GetByHash to get api key by key hash.
+func (sql *SQL) GetByHash(ctx context.Context, hash string) (*entity.APIKey, int, error) {
+	var a APIKey
+	if err := sql.db.WithContext(ctx).Where("hash = ?", hash).First(&a).Error; err != nil {
+		if _errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusUnauthorized, stack.Wrap(ctx, err, errors.ErrInvalidAPIKey)
+		}
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return a.toEntity(), http.StatusOK, nil
+}
+
+// Get to get api key list.
+func (sql *SQL) Get(ctx context.Context, data entity.GetRequest) ([]*entity.APIKey, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&APIKey{})
+
+	var a []APIKey
+	if err := query.Order("id asc").Offset((data.Page - 1) * data.Limit).Limit(data.Limit).Find(&a).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var total int64
+	if err := query.Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(a), int(total), http.StatusOK, nil
+}
+
+// Delete to delete api key.
+func (sql *SQL) Delete(ctx context.Context, id int64) (int, error) {
+	res := sql.db.WithContext(ctx).Where("id = ?", id).Delete(&APIKey{})
+	if res.Error != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, res.Error, errors.ErrInternalDB)
+	}
+	if res.RowsAffected == 0 {
+		return http.StatusNotFound, stack.Wrap(ctx, errors.ErrAPIKeyNotFound)
+	}
+	return http.StatusOK, nil
+}
+
+// AddUsage to add api key usage counter.
+func (sql *SQL) AddUsage(ctx context.Context, data entity.Usage) (int, error) {
+	if err := sql.db.WithContext(ctx).Model(&APIKey{}).Where("id = ?", data.ID).Updates(map[string]interface{}{
+		"request_count": gorm.Expr("request_count + ?", data.Request),
+		"limited_count": gorm.Expr("limited_count + ?", data.Limited),
+		"last_used_at":  data.LastUsedAt,
+	}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
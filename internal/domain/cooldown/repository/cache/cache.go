@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/cache/atomic"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Cache contains functions for cooldown cache.
+type Cache struct {
+	cacher atomic.Cacher
+	window time.Duration
+}
+
+// New to create new cooldown cache.
+func New(cacher atomic.Cacher, window time.Duration) *Cache {
+	return &Cache{
+		cacher: cacher,
+		window: window,
+	}
+}
+
+// Take to start cooldown window of the key.
+// Will return remaining time if the key is still
+// in cooldown.
+func (c *Cache) Take(ctx context.Context, key string) (time.Duration, int, error) {
+	ok, wait, err := c.cacher.SetNX(ctx, utils.GetKey("cooldown", key), c.window)
+	if err != nil {
+		return 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	if !ok {
+		return wait, http.StatusOK, nil
+	}
+
+	return 0, http.StatusCreated, nil
+}
+
+// Release to end cooldown window of the key early.
+func (c *Cache) Release(ctx context.Context, key string) (int, error) {
+	if err := c.cacher.Del(ctx, utils.GetKey("cooldown", key)); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Repository contains functions for cooldown domain.
+type Repository interface {
+	Take(ctx context.Context, key string) (time.Duration, int, error)
+	Release(ctx context.Context, key string) (int, error)
+}
@@ -0,0 +1,9 @@
+package sql
+
+import "time"
+
+// Cooldown is cooldown database model.
+type Cooldown struct {
+	ID        string `gorm:"primaryKey;size:255"`
+	ExpiredAt time.Time
+}
@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQL contains functions for cooldown sql database.
+type SQL struct {
+	db     *gorm.DB
+	window time.Duration
+}
+
+// New to create new cooldown database.
+func New(db *gorm.DB, window time.Duration) *SQL {
+	return &SQL{
+		db:     db,
+		window: window,
+	}
+}
+
+// Take to start cooldown window of the key.
+// Will return remaining time if the key is still
+// in cooldown.
+func (sql *SQL) Take(ctx context.Context, key string) (time.Duration, int, error) {
+	now := time.Now()
+
+	// Delete expired key.
+	if err := sql.db.WithContext(ctx).Where("id = ? and expired_at < ?", key, now).Delete(&Cooldown{}).Error; err != nil {
+		return 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := sql.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&Cooldown{
+		ID:        key,
+		ExpiredAt: now.Add(sql.window),
+	})
+	if res.Error != nil {
+		return 0, http.StatusInternalServerError, stack.Wrap(ctx, res.Error, errors.ErrInternalDB)
+	}
+
+	if res.RowsAffected > 0 {
+		return 0, http.StatusCreated, nil
+	}
+
+	var c Cooldown
+	if err := sql.db.WithContext(ctx).Where("id = ?", key).Find(&c).Error; err != nil {
+		return 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return max(0, time.Until(c.ExpiredAt)), http.StatusOK, nil
+}
+
+// Release to end cooldown window of the key early.
+func (sql *SQL) Release(ctx context.Context, key string) (int, error) {
+	if err := sql.db.WithContext(ctx).Where("id = ?", key).Delete(&Cooldown{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
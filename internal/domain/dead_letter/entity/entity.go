@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// DeadLetter is entity for dead letter.
+type DeadLetter struct {
+	ID        int64
+	Type      string
+	Message   []byte
+	Error     string
+	Attempt   int
+	CreatedAt time.Time
+}
+
+// GetRequest is get dead letter list request model.
+type GetRequest struct {
+	Page  int
+	Limit int
+}
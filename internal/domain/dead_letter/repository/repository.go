@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/dead_letter/entity"
+)
+
+// Repository contains functions for dead_letter domain.
+type Repository interface {
+	Create(ctx context.Context, data entity.DeadLetter) (int, error)
+	Get(ctx context.Context, data entity.GetRequest) ([]*entity.DeadLetter, int, int, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*entity.DeadLetter, int, error)
+	Delete(ctx context.Context, ids []int64) (int, error)
+	DeleteAll(ctx context.Context) (int, error)
+}
@@ -0,0 +1,46 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/dead_letter/entity"
+)
+
+// DeadLetter is dead_letter database model.
+type DeadLetter struct {
+	ID        int64 `gorm:"primaryKey"`
+	Type      string
+	Message   string
+	Error     string
+	Attempt   int
+	CreatedAt time.Time
+}
+
+func (sql *SQL) fromEntity(data entity.DeadLetter) DeadLetter {
+	return DeadLetter{
+		ID:      data.ID,
+		Type:    data.Type,
+		Message: string(data.Message),
+		Error:   data.Error,
+		Attempt: data.Attempt,
+	}
+}
+
+func (d *DeadLetter) toEntity() *entity.DeadLetter {
+	return &entity.DeadLetter{
+		ID:        d.ID,
+		Type:      d.Type,
+		Message:   []byte(d.Message),
+		Error:     d.Error,
+		Attempt:   d.Attempt,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func (sql *SQL) toEntities(data []DeadLetter) []*entity.DeadLetter {
+	d := make([]*entity.DeadLetter, len(data))
+	for i, dd := range data {
+		d[i] = dd.toEntity()
+	}
+	return d
+}
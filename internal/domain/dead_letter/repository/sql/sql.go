@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/dead_letter/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+// SQL contains functions for dead_letter sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new dead_letter database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Create to create dead letter.
+func (sql *SQL) Create(ctx context.Context, data entity.DeadLetter) (int, error) {
+	d := sql.fromEntity(data)
+	if err := sql.db.WithContext(ctx).Create(&d).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusCreated, nil
+}
+
+// Get to get dead letter list.
+func (sql *SQL) Get(ctx context.Context, data entity.GetRequest) ([]*entity.DeadLetter, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&DeadLetter{})
+
+	var d []DeadLetter
+	if err := query.Order("id asc").Offset((data.Page - 1) * data.Limit).Limit(data.Limit).Find(&d).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var total int64
+	if err := query.Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(d), int(total), http.StatusOK, nil
+}
+
+// GetByIDs to get dead letter by ids.
+func (sql *SQL) GetByIDs(ctx context.Context, ids []int64) ([]*entity.DeadLetter, int, error) {
+	var d []DeadLetter
+	if err := sql.db.WithContext(ctx).Where("id in ?", ids).Order("id asc").Find(&d).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return sql.toEntities(d), http.StatusOK, nil
+}
+
+// Delete to delete dead letter by ids.
+func (sql *SQL) Delete(ctx context.Context, ids []int64) (int, error) {
+	if err := sql.db.WithContext(ctx).Where("id in ?", ids).Delete(&DeadLetter{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
+
+// DeleteAll to delete all dead letters.
+func (sql *SQL) DeleteAll(ctx context.Context) (int, error) {
+	if err := sql.db.WithContext(ctx).Where("1 = 1").Delete(&DeadLetter{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
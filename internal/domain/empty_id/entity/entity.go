@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// EmptyID is entity for anime id not found in MyAnimeList.
+type EmptyID struct {
+	AnimeID   int64
+	CreatedAt time.Time
+}
+
+// GetRequest is get empty id list request model.
+type GetRequest struct {
+	Page  int
+	Limit int
+}
@@ -3,7 +3,9 @@ package cache
 import (
 	"context"
 	"net/http"
+	"time"
 
+	"github.com/rl404/akatsuki/internal/domain/empty_id/entity"
 	"github.com/rl404/akatsuki/internal/domain/empty_id/repository"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/akatsuki/internal/utils"
@@ -77,3 +79,24 @@ func (c *Cache) Delete(ctx context.Context, id int64) (int, error) {
 func (c *Cache) GetIDs(ctx context.Context) ([]int64, int, error) {
 	return c.repo.GetIDs(ctx)
 }
+
+// GetList to get empty id list.
+func (c *Cache) GetList(ctx context.Context, data entity.GetRequest) ([]*entity.EmptyID, int, int, error) {
+	return c.repo.GetList(ctx, data)
+}
+
+// DeleteOlderThan to delete old empty id.
+func (c *Cache) DeleteOlderThan(ctx context.Context, t time.Time) ([]int64, int, error) {
+	ids, code, err := c.repo.DeleteOlderThan(ctx, t)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	for _, id := range ids {
+		if err := c.cacher.Delete(ctx, utils.GetKey("empty-id", id)); err != nil {
+			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+		}
+	}
+
+	return ids, code, nil
+}
@@ -1,6 +1,11 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/empty_id/entity"
+)
 
 // Repository contains functions for empty_id domain.
 type Repository interface {
@@ -8,4 +13,6 @@ type Repository interface {
 	Create(ctx context.Context, id int64) (int, error)
 	Delete(ctx context.Context, id int64) (int, error)
 	GetIDs(ctx context.Context) ([]int64, int, error)
+	GetList(ctx context.Context, data entity.GetRequest) ([]*entity.EmptyID, int, int, error)
+	DeleteOlderThan(ctx context.Context, t time.Time) ([]int64, int, error)
 }
@@ -1,9 +1,24 @@
 package sql
 
-import "time"
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/empty_id/entity"
+)
 
 // EmptyID is empty_id database model.
 type EmptyID struct {
 	AnimeID   int64 `gorm:"primaryKey"`
 	CreatedAt time.Time
 }
+
+func (sql *SQL) toEntities(data []EmptyID) []*entity.EmptyID {
+	e := make([]*entity.EmptyID, len(data))
+	for i, ee := range data {
+		e[i] = &entity.EmptyID{
+			AnimeID:   ee.AnimeID,
+			CreatedAt: ee.CreatedAt,
+		}
+	}
+	return e
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	_errors "errors"
 	"net/http"
+	"time"
 
+	"github.com/rl404/akatsuki/internal/domain/empty_id/entity"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/fairy/errors/stack"
 	"gorm.io/gorm"
@@ -58,3 +60,40 @@ func (sql *SQL) GetIDs(ctx context.Context) ([]int64, int, error) {
 	}
 	return ids, http.StatusOK, nil
 }
+
+// GetList to get empty id list.
+// Oldest first.
+func (sql *SQL) GetList(ctx context.Context, data entity.GetRequest) ([]*entity.EmptyID, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&EmptyID{})
+
+	var e []EmptyID
+	if err := query.Order("created_at asc, anime_id asc").Offset((data.Page - 1) * data.Limit).Limit(data.Limit).Find(&e).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var total int64
+	if err := query.Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(e), int(total), http.StatusOK, nil
+}
+
+// DeleteOlderThan to delete empty id created before t.
+// Will return deleted ids.
+func (sql *SQL) DeleteOlderThan(ctx context.Context, t time.Time) ([]int64, int, error) {
+	var ids []int64
+	if err := sql.db.WithContext(ctx).Model(&EmptyID{}).Where("created_at < ?", t).Pluck("anime_id", &ids).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(ids) == 0 {
+		return nil, http.StatusOK, nil
+	}
+
+	if err := sql.db.WithContext(ctx).Where("anime_id in ?", ids).Delete(&EmptyID{}).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return ids, http.StatusOK, nil
+}
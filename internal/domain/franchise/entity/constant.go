@@ -0,0 +1,10 @@
+package entity
+
+// WatchOrder is franchise watch order.
+type WatchOrder string
+
+// Available franchise watch order.
+const (
+	WatchOrderRelease       WatchOrder = "RELEASE"
+	WatchOrderChronological WatchOrder = "CHRONOLOGICAL"
+)
@@ -0,0 +1,19 @@
+package entity
+
+// Franchise is entity for franchise.
+//
+// Franchise is anime connected by story relation.
+// Its id is the id of the earliest anime.
+type Franchise struct {
+	ID         int64
+	Name       string
+	AnimeCount int
+	AnimeIDs   []int64
+}
+
+// GetRequest is get franchise list request model.
+type GetRequest struct {
+	Name  string
+	Page  int
+	Limit int
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+)
+
+// Repository contains functions for franchise domain.
+type Repository interface {
+	Get(ctx context.Context, data entity.GetRequest) ([]*entity.Franchise, int, int, error)
+	GetByID(ctx context.Context, id int64) (*entity.Franchise, int, error)
+	GetIDsByAnimeIDs(ctx context.Context, animeIDs []int64) (map[int64]int64, int, error)
+	Replace(ctx context.Context, data []entity.Franchise) (int, error)
+}
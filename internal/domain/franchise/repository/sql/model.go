@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+)
+
+// Franchise is franchise database model.
+type Franchise struct {
+	ID         int64 `gorm:"primaryKey"`
+	Name       string
+	AnimeCount int
+	CreatedAt  time.Time
+}
+
+// FranchiseAnime is franchise_anime database model.
+type FranchiseAnime struct {
+	FranchiseID int64 `gorm:"index"`
+	AnimeID     int64 `gorm:"primaryKey"`
+}
+
+func (f *Franchise) toEntity() *entity.Franchise {
+	return &entity.Franchise{
+		ID:         f.ID,
+		Name:       f.Name,
+		AnimeCount: f.AnimeCount,
+	}
+}
+
+func (sql *SQL) toEntities(data []Franchise) []*entity.Franchise {
+	f := make([]*entity.Franchise, len(data))
+	for i, ff := range data {
+		f[i] = ff.toEntity()
+	}
+	return f
+}
@@ -0,0 +1,137 @@
+package sql
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+const batchSize = 1000
+
+// SQL contains functions for franchise sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new franchise database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Get to get franchise list.
+func (sql *SQL) Get(ctx context.Context, data entity.GetRequest) ([]*entity.Franchise, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&Franchise{})
+
+	if data.Name != "" {
+		query.Where("name ilike ?", "%"+data.Name+"%")
+	}
+
+	var f []Franchise
+	if err := query.Order("anime_count desc, id asc").Limit(data.Limit).Offset((data.Page - 1) * data.Limit).Find(&f).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var cnt int64
+	if err := query.Limit(-1).Offset(-1).Order("").Count(&cnt).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(f), int(cnt), http.StatusOK, nil
+}
+
+// GetByID to get franchise by id.
+func (sql *SQL) GetByID(ctx context.Context, id int64) (*entity.Franchise, int, error) {
+	var f Franchise
+	if err := sql.db.WithContext(ctx).Where("id = ?", id).First(&f).Error; err != nil {
+		if _errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, stack.Wrap(ctx, err, errors.ErrFranchiseNotFound)
+		}
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var fa []FranchiseAnime
+	if err := sql.db.WithContext(ctx).Where("franchise_id = ?", id).Order("anime_id asc").Find(&fa).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	franchise := f.toEntity()
+	franchise.AnimeIDs = make([]int64, len(fa))
+	for i, a := range fa {
+		franchise.AnimeIDs[i] = a.AnimeID
+	}
+
+	return franchise, http.StatusOK, nil
+}
+
+// GetIDsByAnimeIDs to get franchise id of the anime.
+// Anime without franchise will not be in the result.
+func (sql *SQL) GetIDsByAnimeIDs(ctx context.Context, animeIDs []int64) (map[int64]int64, int, error) {
+	var fa []FranchiseAnime
+	if err := sql.db.WithContext(ctx).Where("anime_id in ?", animeIDs).Find(&fa).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make(map[int64]int64)
+	for _, a := range fa {
+		res[a.AnimeID] = a.FranchiseID
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// Replace to replace all franchises.
+func (sql *SQL) Replace(ctx context.Context, data []entity.Franchise) (int, error) {
+	tx := sql.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, tx.Error, errors.ErrInternalDB)
+	}
+	defer tx.Rollback()
+
+	if err := tx.WithContext(ctx).Where("1 = 1").Delete(&FranchiseAnime{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if err := tx.WithContext(ctx).Where("1 = 1").Delete(&Franchise{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(data) > 0 {
+		franchises := make([]Franchise, len(data))
+		var franchiseAnime []FranchiseAnime
+		for i, f := range data {
+			franchises[i] = Franchise{
+				ID:         f.ID,
+				Name:       f.Name,
+				AnimeCount: len(f.AnimeIDs),
+			}
+
+			for _, id := range f.AnimeIDs {
+				franchiseAnime = append(franchiseAnime, FranchiseAnime{
+					FranchiseID: f.ID,
+					AnimeID:     id,
+				})
+			}
+		}
+
+		if err := tx.WithContext(ctx).CreateInBatches(franchises, batchSize).Error; err != nil {
+			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+		}
+
+		if err := tx.WithContext(ctx).CreateInBatches(franchiseAnime, batchSize).Error; err != nil {
+			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/cache/atomic"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Cache contains functions for in_flight cache.
+type Cache struct {
+	cacher atomic.Cacher
+	ttl    time.Duration
+}
+
+// New to create new in_flight cache.
+func New(cacher atomic.Cacher, ttl time.Duration) *Cache {
+	return &Cache{
+		cacher: cacher,
+		ttl:    ttl,
+	}
+}
+
+// Register to register key as in-flight.
+// Will return false if the key is already in-flight.
+func (c *Cache) Register(ctx context.Context, key string) (bool, int, error) {
+	ok, _, err := c.cacher.SetNX(ctx, utils.GetKey("in-flight", key), c.ttl)
+	if err != nil {
+		return false, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	if !ok {
+		return false, http.StatusOK, nil
+	}
+
+	return true, http.StatusCreated, nil
+}
+
+// IncrSuppressed to increment suppressed message count.
+func (c *Cache) IncrSuppressed(ctx context.Context, msgType string) (int, error) {
+	return c.incr(ctx, "in-flight-suppressed", msgType)
+}
+
+// GetSuppressed to get suppressed message count.
+func (c *Cache) GetSuppressed(ctx context.Context) (map[string]int, int, error) {
+	return c.get(ctx, "in-flight-suppressed")
+}
+
+// IncrPublished to increment published message count.
+func (c *Cache) IncrPublished(ctx context.Context, msgType string) (int, error) {
+	return c.incr(ctx, "in-flight-published", msgType)
+}
+
+// IncrFinished to increment finished message count.
+func (c *Cache) IncrFinished(ctx context.Context, msgType string) (int, error) {
+	return c.incr(ctx, "in-flight-finished", msgType)
+}
+
+// GetDepth to get queued but not finished message count.
+func (c *Cache) GetDepth(ctx context.Context) (map[string]int, int, error) {
+	published, code, err := c.get(ctx, "in-flight-published")
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	finished, code, err := c.get(ctx, "in-flight-finished")
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make(map[string]int)
+	for msgType, cnt := range published {
+		res[msgType] = max(0, cnt-finished[msgType])
+	}
+
+	return res, http.StatusOK, nil
+}
+
+func (c *Cache) incr(ctx context.Context, name, msgType string) (int, error) {
+	if err := c.cacher.HIncr(ctx, utils.GetKey(name), msgType); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+	return http.StatusOK, nil
+}
+
+func (c *Cache) get(ctx context.Context, name string) (map[string]int, int, error) {
+	data, err := c.cacher.HGetAll(ctx, utils.GetKey(name))
+	if err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+	return data, http.StatusOK, nil
+}
@@ -0,0 +1,51 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/in_flight/repository/cache"
+	"github.com/rl404/akatsuki/pkg/cache/atomic/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrent(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(inmemory.New(), time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	registered := 0
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ok, _, err := c.Register(ctx, "key")
+			assert.Nil(t, err)
+
+			if ok {
+				mu.Lock()
+				registered++
+				mu.Unlock()
+			}
+
+			_, err = c.IncrPublished(ctx, "anime")
+			assert.Nil(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	_, err := c.IncrFinished(ctx, "anime")
+	assert.Nil(t, err)
+
+	depth, _, err := c.GetDepth(ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, registered)
+	assert.Equal(t, map[string]int{"anime": 99}, depth)
+}
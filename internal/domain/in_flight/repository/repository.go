@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// Repository contains functions for in_flight domain.
+type Repository interface {
+	Register(ctx context.Context, key string) (bool, int, error)
+	IncrSuppressed(ctx context.Context, msgType string) (int, error)
+	GetSuppressed(ctx context.Context) (map[string]int, int, error)
+	IncrPublished(ctx context.Context, msgType string) (int, error)
+	IncrFinished(ctx context.Context, msgType string) (int, error)
+	GetDepth(ctx context.Context) (map[string]int, int, error)
+}
@@ -0,0 +1,17 @@
+package sql
+
+import "time"
+
+// InFlight is in_flight database model.
+type InFlight struct {
+	ID        string `gorm:"primaryKey;size:255"`
+	ExpiredAt time.Time
+}
+
+// InFlightStat is in_flight_stat database model.
+type InFlightStat struct {
+	Type       string `gorm:"primaryKey;size:50"`
+	Suppressed int
+	Published  int `gorm:"not null;default:0"`
+	Finished   int `gorm:"not null;default:0"`
+}
@@ -0,0 +1,106 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQL contains functions for in_flight sql database.
+type SQL struct {
+	db  *gorm.DB
+	ttl time.Duration
+}
+
+// New to create new in_flight database.
+func New(db *gorm.DB, ttl time.Duration) *SQL {
+	return &SQL{
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+// Register to register key as in-flight.
+// Will return false if the key is already in-flight.
+func (sql *SQL) Register(ctx context.Context, key string) (bool, int, error) {
+	now := time.Now()
+
+	// Delete expired key.
+	if err := sql.db.WithContext(ctx).Where("id = ? and expired_at < ?", key, now).Delete(&InFlight{}).Error; err != nil {
+		return false, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := sql.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&InFlight{
+		ID:        key,
+		ExpiredAt: now.Add(sql.ttl),
+	})
+	if res.Error != nil {
+		return false, http.StatusInternalServerError, stack.Wrap(ctx, res.Error, errors.ErrInternalDB)
+	}
+
+	if res.RowsAffected == 0 {
+		return false, http.StatusOK, nil
+	}
+
+	return true, http.StatusCreated, nil
+}
+
+// IncrSuppressed to increment suppressed message count.
+func (sql *SQL) IncrSuppressed(ctx context.Context, msgType string) (int, error) {
+	return sql.incr(ctx, InFlightStat{Type: msgType, Suppressed: 1}, "suppressed")
+}
+
+// IncrPublished to increment published message count.
+func (sql *SQL) IncrPublished(ctx context.Context, msgType string) (int, error) {
+	return sql.incr(ctx, InFlightStat{Type: msgType, Published: 1}, "published")
+}
+
+// IncrFinished to increment finished message count.
+func (sql *SQL) IncrFinished(ctx context.Context, msgType string) (int, error) {
+	return sql.incr(ctx, InFlightStat{Type: msgType, Finished: 1}, "finished")
+}
+
+func (sql *SQL) incr(ctx context.Context, stat InFlightStat, column string) (int, error) {
+	if err := sql.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "type"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{column: gorm.Expr("in_flight_stat." + column + " + 1")}),
+	}).Create(&stat).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
+
+// GetSuppressed to get suppressed message count.
+func (sql *SQL) GetSuppressed(ctx context.Context) (map[string]int, int, error) {
+	var stats []InFlightStat
+	if err := sql.db.WithContext(ctx).Find(&stats).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make(map[string]int)
+	for _, s := range stats {
+		res[s.Type] = s.Suppressed
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// GetDepth to get queued but not finished message count.
+func (sql *SQL) GetDepth(ctx context.Context) (map[string]int, int, error) {
+	var stats []InFlightStat
+	if err := sql.db.WithContext(ctx).Find(&stats).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make(map[string]int)
+	for _, s := range stats {
+		res[s.Type] = max(0, s.Published-s.Finished)
+	}
+
+	return res, http.StatusOK, nil
+}
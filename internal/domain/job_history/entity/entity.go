@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// JobHistory is entity for scheduled job run history.
+type JobHistory struct {
+	ID        int64
+	Name      string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Queued    int
+	Error     string
+}
+
+// GetRequest is get job history list request model.
+type GetRequest struct {
+	Name  string
+	Page  int
+	Limit int
+}
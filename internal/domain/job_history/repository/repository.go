@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+)
+
+// Repository contains functions for job_history domain.
+type Repository interface {
+	Create(ctx context.Context, data entity.JobHistory) (int64, int, error)
+	Update(ctx context.Context, data entity.JobHistory) (int, error)
+	Get(ctx context.Context, data entity.GetRequest) ([]*entity.JobHistory, int, int, error)
+}
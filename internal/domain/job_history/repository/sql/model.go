@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+)
+
+// JobHistory is job_history database model.
+type JobHistory struct {
+	ID        int64  `gorm:"primaryKey"`
+	Name      string `gorm:"index"`
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Queued    int
+	Error     string
+}
+
+func (sql *SQL) fromEntity(data entity.JobHistory) JobHistory {
+	return JobHistory{
+		ID:        data.ID,
+		Name:      data.Name,
+		StartedAt: data.StartedAt,
+		EndedAt:   data.EndedAt,
+		Queued:    data.Queued,
+		Error:     data.Error,
+	}
+}
+
+func (j *JobHistory) toEntity() *entity.JobHistory {
+	return &entity.JobHistory{
+		ID:        j.ID,
+		Name:      j.Name,
+		StartedAt: j.StartedAt,
+		EndedAt:   j.EndedAt,
+		Queued:    j.Queued,
+		Error:     j.Error,
+	}
+}
+
+func (sql *SQL) toEntities(data []JobHistory) []*entity.JobHistory {
+	j := make([]*entity.JobHistory, len(data))
+	for i, jj := range data {
+		j[i] = jj.toEntity()
+	}
+	return j
+}
@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+// SQL contains functions for job_history sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new job_history database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Create to create job history.
+func (sql *SQL) Create(ctx context.Context, data entity.JobHistory) (int64, int, error) {
+	j := sql.fromEntity(data)
+	if err := sql.db.WithContext(ctx).Create(&j).Error; err != nil {
+		return 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return j.ID, http.StatusCreated, nil
+}
+
+// Update to update job history result.
+func (sql *SQL) Update(ctx context.Context, data entity.JobHistory) (int, error) {
+	if err := sql.db.WithContext(ctx).Model(&JobHistory{}).Where("id = ?", data.ID).Updates(map[string]interface{}{
+		"ended_at": data.EndedAt,
+		"queued":   data.Queued,
+		"error":    data.Error,
+	}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusOK, nil
+}
+
+// Get to get job history list.
+// Latest run first.
+func (sql *SQL) Get(ctx context.Context, data entity.GetRequest) ([]*entity.JobHistory, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&JobHistory{})
+
+	if data.Name != "" {
+		query.Where("name = ?", data.Name)
+	}
+
+	var j []JobHistory
+	if err := query.Order("id desc").Offset((data.Page - 1) * data.Limit).Limit(data.Limit).Find(&j).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var total int64
+	if err := query.Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(j), int(total), http.StatusOK, nil
+}
@@ -0,0 +1,140 @@
+package sql_test
+
+import (
+	"context"
+	"database/sql/driver"
+	_errors "errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+	"github.com/rl404/akatsuki/internal/domain/job_history/repository/sql"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+type testSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	dbMock sqlmock.Sqlmock
+}
+
+func TestSQL(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (suite *testSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	suite.Require().Nil(err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	suite.Require().Nil(err)
+
+	suite.db, suite.dbMock = gormDB, mock
+}
+
+func (suite *testSuite) TearDownSuite() {
+	db, err := suite.db.DB()
+	require.Nil(suite.T(), err)
+	db.Close()
+}
+
+func (suite *testSuite) TestGet() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	tests := []struct {
+		name             string
+		param            entity.GetRequest
+		query            string
+		queryArgs        []driver.Value
+		queryReturn      []*sqlmock.Rows
+		queryError       error
+		queryCountCalled bool
+		queryCount       string
+		queryCountArgs   []driver.Value
+		queryCountReturn []*sqlmock.Rows
+		queryCountError  error
+		expectedData     []*entity.JobHistory
+		expectedTotal    int
+		expectedCode     int
+		expectedError    error
+	}{
+		{
+			name:          "err-select",
+			param:         entity.GetRequest{Page: 1, Limit: 10},
+			query:         `SELECT * FROM "job_history" ORDER BY id desc LIMIT $1`,
+			queryArgs:     []driver.Value{10},
+			queryReturn:   []*sqlmock.Rows{},
+			queryError:    errDummy,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: errors.ErrInternalDB,
+		},
+		{
+			name:             "err-count",
+			param:            entity.GetRequest{Page: 1, Limit: 10},
+			query:            `SELECT * FROM "job_history" ORDER BY id desc LIMIT $1`,
+			queryArgs:        []driver.Value{10},
+			queryReturn:      []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(1)},
+			queryCountCalled: true,
+			queryCount:       `SELECT count(*) FROM "job_history"`,
+			queryCountError:  errDummy,
+			expectedCode:     http.StatusInternalServerError,
+			expectedError:    errors.ErrInternalDB,
+		},
+		{
+			name:             "ok-page-2",
+			param:            entity.GetRequest{Name: "job", Page: 2, Limit: 10},
+			query:            `SELECT * FROM "job_history" WHERE name = $1 ORDER BY id desc LIMIT $2 OFFSET $3`,
+			queryArgs:        []driver.Value{"job", 10, 10},
+			queryReturn:      []*sqlmock.Rows{sqlmock.NewRows([]string{"id"}).AddRow(11)},
+			queryCountCalled: true,
+			queryCount:       `SELECT count(*) FROM "job_history" WHERE name = $1`,
+			queryCountArgs:   []driver.Value{"job"},
+			queryCountReturn: []*sqlmock.Rows{sqlmock.NewRows([]string{"count"}).AddRow(11)},
+			expectedData:     []*entity.JobHistory{{ID: 11}},
+			expectedTotal:    11,
+			expectedCode:     http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.dbMock.ExpectQuery(regexp.QuoteMeta(test.query)).
+				WithArgs(test.queryArgs...).
+				WillReturnRows(test.queryReturn...).
+				WillReturnError(test.queryError)
+
+			if test.queryCountCalled {
+				// Count must not be limited by the page.
+				suite.dbMock.ExpectQuery(regexp.QuoteMeta(test.queryCount) + "$").
+					WithArgs(test.queryCountArgs...).
+					WillReturnRows(test.queryCountReturn...).
+					WillReturnError(test.queryCountError)
+			}
+
+			sql := sql.New(suite.db)
+
+			data, total, code, err := sql.Get(ctx, test.param)
+			suite.Equal(test.expectedData, data)
+			suite.Equal(test.expectedTotal, total)
+			suite.Equal(test.expectedCode, code)
+			suite.ErrorIs(test.expectedError, err)
+			suite.Nil(suite.dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
-	"github.com/rl404/fairy/limit/atomic"
+	"github.com/rl404/akatsuki/pkg/limit"
 	"github.com/rl404/nagato"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // Client is client for mal.
@@ -15,13 +16,14 @@ type Client struct {
 }
 
 // New to create new mal client.
-func New(clientID string) *Client {
+func New(clientID string, limiter limit.Limiter) *Client {
 	c := nagato.New(clientID)
-	c.SetLimiter(atomic.New(1, time.Second))
+	c.SetLimiter(limiter)
 	c.SetHttpClient(&http.Client{
 		Timeout: 30 * time.Second,
 		Transport: newrelic.NewRoundTripper(&clientIDTransport{
-			clientID: clientID,
+			transport: otelhttp.NewTransport(http.DefaultTransport),
+			clientID:  clientID,
 		}),
 	})
 	return &Client{
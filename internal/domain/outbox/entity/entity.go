@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Type is outbox message type.
+type Type string
+
+// Available outbox message type.
+const (
+	TypeParseAnime     Type = "parse-anime"
+	TypeParseUserAnime Type = "parse-user-anime"
+	TypeRetry          Type = "retry"
+)
+
+// Outbox is entity for outbox message waiting to be published.
+// Relayed messages are always low priority except retried
+// messages which are republished as is.
+type Outbox struct {
+	ID        int64
+	Type      Type
+	AnimeID   int64
+	Username  string
+	Status    string
+	Message   []byte
+	PublishAt time.Time
+	CreatedAt time.Time
+}
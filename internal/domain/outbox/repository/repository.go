@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/outbox/entity"
+)
+
+// Repository contains functions for outbox domain.
+type Repository interface {
+	Create(ctx context.Context, data entity.Outbox) (int, error)
+	ClaimPending(ctx context.Context, limit int) ([]*entity.Outbox, int, error)
+	Delete(ctx context.Context, ids []int64) (int, error)
+}
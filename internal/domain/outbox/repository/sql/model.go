@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/outbox/entity"
+)
+
+// Outbox is outbox database model.
+type Outbox struct {
+	ID        int64 `gorm:"primaryKey"`
+	Type      string
+	AnimeID   int64
+	Username  string
+	Status    string
+	Message   []byte
+	PublishAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func fromEntities(data []entity.Outbox) []Outbox {
+	o := make([]Outbox, len(data))
+	for i, d := range data {
+		o[i] = fromEntity(d)
+	}
+	return o
+}
+
+func fromEntity(data entity.Outbox) Outbox {
+	publishAt := data.PublishAt
+	if publishAt.IsZero() {
+		publishAt = time.Now()
+	}
+
+	return Outbox{
+		Type:      string(data.Type),
+		AnimeID:   data.AnimeID,
+		Username:  data.Username,
+		Status:    data.Status,
+		Message:   data.Message,
+		PublishAt: publishAt,
+	}
+}
+
+func (o *Outbox) toEntity() *entity.Outbox {
+	return &entity.Outbox{
+		ID:        o.ID,
+		Type:      entity.Type(o.Type),
+		AnimeID:   o.AnimeID,
+		Username:  o.Username,
+		Status:    o.Status,
+		Message:   o.Message,
+		PublishAt: o.PublishAt,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+func (sql *SQL) toEntities(data []Outbox) []*entity.Outbox {
+	o := make([]*entity.Outbox, len(data))
+	for i, d := range data {
+		o[i] = d.toEntity()
+	}
+	return o
+}
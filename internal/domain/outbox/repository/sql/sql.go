@@ -0,0 +1,91 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/outbox/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// How long claimed messages are hidden from other relays.
+const claimLease = time.Minute
+
+// SQL contains functions for outbox sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new outbox database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Add to insert outbox messages using other domain's
+// transaction so they are saved together with the data.
+func Add(tx *gorm.DB, data ...entity.Outbox) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return tx.Create(fromEntities(data)).Error
+}
+
+// Create to insert outbox message.
+func (sql *SQL) Create(ctx context.Context, data entity.Outbox) (int, error) {
+	o := fromEntity(data)
+	if err := sql.db.WithContext(ctx).Create(&o).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return http.StatusCreated, nil
+}
+
+// ClaimPending to get oldest unpublished messages that
+// are ready to be published. Claimed messages are hidden
+// from other relays until the lease ends, so they will
+// be published again if not deleted in time.
+func (sql *SQL) ClaimPending(ctx context.Context, limit int) ([]*entity.Outbox, int, error) {
+	var o []Outbox
+	if err := sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("publish_at <= ?", now).
+			Order("id asc").
+			Limit(limit).
+			Find(&o).Error; err != nil {
+			return err
+		}
+
+		if len(o) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(o))
+		for i, oo := range o {
+			ids[i] = oo.ID
+		}
+
+		return tx.Model(&Outbox{}).Where("id in ?", ids).Update("publish_at", now.Add(claimLease)).Error
+	}); err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return sql.toEntities(o), http.StatusOK, nil
+}
+
+// Delete to delete published messages.
+func (sql *SQL) Delete(ctx context.Context, ids []int64) (int, error) {
+	if len(ids) == 0 {
+		return http.StatusOK, nil
+	}
+
+	if err := sql.db.WithContext(ctx).Where("id in ?", ids).Delete(&Outbox{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return http.StatusOK, nil
+}
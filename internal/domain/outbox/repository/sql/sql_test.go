@@ -0,0 +1,86 @@
+package sql_test
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rl404/akatsuki/internal/domain/outbox/repository/sql"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+type testSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	dbMock sqlmock.Sqlmock
+}
+
+func TestSQL(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (suite *testSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	suite.Require().Nil(err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	suite.Require().Nil(err)
+
+	suite.db, suite.dbMock = gormDB, mock
+}
+
+func (suite *testSuite) TearDownSuite() {
+	db, err := suite.db.DB()
+	require.Nil(suite.T(), err)
+	db.Close()
+}
+
+func (suite *testSuite) TestClaimPending() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	// Error select.
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox" WHERE publish_at <= $1 ORDER BY id asc LIMIT $2 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(sqlmock.AnyArg(), 10).
+		WillReturnError(errDummy)
+	suite.dbMock.ExpectRollback()
+
+	data, code, err := sql.New(suite.db).ClaimPending(ctx, 10)
+	suite.Nil(data)
+	suite.Equal(http.StatusInternalServerError, code)
+	suite.ErrorIs(err, errors.ErrInternalDB)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+
+	// Claimed rows are hidden until the lease ends.
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox" WHERE publish_at <= $1 ORDER BY id asc LIMIT $2 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	suite.dbMock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox" SET "publish_at"=$1 WHERE id in ($2,$3)`)).
+		WithArgs(sqlmock.AnyArg(), 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.dbMock.ExpectCommit()
+
+	data, code, err = sql.New(suite.db).ClaimPending(ctx, 10)
+	suite.Len(data, 2)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+}
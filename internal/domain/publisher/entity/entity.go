@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type messageType string
 
 // Available message type.
@@ -8,11 +10,27 @@ const (
 	TypeParseUserAnime messageType = "parse-user-anime"
 )
 
+// Priority is message priority.
+type Priority string
+
+// Available message priority.
+const (
+	PriorityHigh Priority = "high"
+	PriorityLow  Priority = "low"
+)
+
 // Message is entity for message.
 type Message struct {
-	Type     messageType `json:"type"`
-	ID       int64       `json:"id"`
-	Username string      `json:"username"`
-	Status   string      `json:"status"`
-	Forced   bool        `json:"forced"`
+	Type     messageType       `json:"type"`
+	ID       int64             `json:"id"`
+	Username string            `json:"username"`
+	Status   string            `json:"status"`
+	Forced   bool              `json:"forced"`
+	Priority Priority          `json:"priority"`
+	Trace    map[string]string `json:"trace"`
+
+	// Retry state.
+	Attempt int       `json:"attempt"`
+	RetryAt time.Time `json:"retry_at"`
+	Error   string    `json:"error"`
 }
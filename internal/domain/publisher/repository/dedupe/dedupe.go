@@ -0,0 +1,102 @@
+package dedupe
+
+import (
+	"context"
+	"fmt"
+
+	inFlightRepository "github.com/rl404/akatsuki/internal/domain/in_flight/repository"
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/publisher/repository"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Dedupe contains functions to suppress duplicate
+// messages that are still in-flight in the same lane.
+type Dedupe struct {
+	repo     repository.Repository
+	inFlight inFlightRepository.Repository
+}
+
+// New to create new publisher deduplication.
+func New(repo repository.Repository, inFlight inFlightRepository.Repository) *Dedupe {
+	return &Dedupe{
+		repo:     repo,
+		inFlight: inFlight,
+	}
+}
+
+// PublishParseAnime to publish parse anime.
+func (d *Dedupe) PublishParseAnime(ctx context.Context, id int64, forced bool, priority entity.Priority) (bool, error) {
+	if !forced {
+		ok, err := d.register(ctx, string(entity.TypeParseAnime), fmt.Sprintf("%s:%d:%s", entity.TypeParseAnime, id, priority))
+		if err != nil {
+			return false, stack.Wrap(ctx, err)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if _, err := d.repo.PublishParseAnime(ctx, id, forced, priority); err != nil {
+		return false, stack.Wrap(ctx, err)
+	}
+
+	d.published(ctx, string(entity.TypeParseAnime))
+	return true, nil
+}
+
+// PublishParseUserAnime to publish parse user anime.
+func (d *Dedupe) PublishParseUserAnime(ctx context.Context, username, status string, forced bool, priority entity.Priority) (bool, error) {
+	if !forced {
+		ok, err := d.register(ctx, string(entity.TypeParseUserAnime), fmt.Sprintf("%s:%s:%s:%s", entity.TypeParseUserAnime, username, status, priority))
+		if err != nil {
+			return false, stack.Wrap(ctx, err)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if _, err := d.repo.PublishParseUserAnime(ctx, username, status, forced, priority); err != nil {
+		return false, stack.Wrap(ctx, err)
+	}
+
+	d.published(ctx, string(entity.TypeParseUserAnime))
+	return true, nil
+}
+
+// PublishRetry to republish failed message. The message
+// is still registered as in-flight so it is not checked.
+func (d *Dedupe) PublishRetry(ctx context.Context, msg entity.Message) error {
+	if err := d.repo.PublishRetry(ctx, msg); err != nil {
+		return stack.Wrap(ctx, err)
+	}
+	return nil
+}
+
+func (d *Dedupe) register(ctx context.Context, msgType, key string) (bool, error) {
+	ok, _, err := d.inFlight.Register(ctx, key)
+	if err != nil {
+		return false, stack.Wrap(ctx, err)
+	}
+
+	if ok {
+		return true, nil
+	}
+
+	if _, err := d.inFlight.IncrSuppressed(ctx, msgType); err != nil {
+		return false, stack.Wrap(ctx, err)
+	}
+
+	return false, nil
+}
+
+// published to count queue depth.
+// The message is already published so the error is only logged.
+func (d *Dedupe) published(ctx context.Context, msgType string) {
+	if _, err := d.inFlight.IncrPublished(ctx, msgType); err != nil {
+		stack.Wrap(ctx, err)
+	}
+}
@@ -0,0 +1,91 @@
+package dedupe_test
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/publisher/repository/dedupe"
+	mockInFlight "github.com/rl404/akatsuki/tests/mocks/domain/in_flight"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	"github.com/stretchr/testify/suite"
+)
+
+type dedupeTestSuite struct {
+	suite.Suite
+}
+
+func TestDedupe(t *testing.T) {
+	suite.Run(t, new(dedupeTestSuite))
+}
+
+func (suite *dedupeTestSuite) TestPublishParseAnime() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	tests := []struct {
+		name            string
+		forced          bool
+		registerCalled  bool
+		registerReturn  []interface{}
+		suppressCalled  bool
+		publisherCalled bool
+		expectedOK      bool
+		expectedError   error
+	}{
+		{
+			name:            "forced",
+			forced:          true,
+			publisherCalled: true,
+			expectedOK:      true,
+		},
+		{
+			name:           "error-register",
+			registerCalled: true,
+			registerReturn: []interface{}{false, http.StatusInternalServerError, errDummy},
+			expectedError:  errDummy,
+		},
+		{
+			name:           "suppressed",
+			registerCalled: true,
+			registerReturn: []interface{}{false, http.StatusOK, nil},
+			suppressCalled: true,
+		},
+		{
+			name:            "ok",
+			registerCalled:  true,
+			registerReturn:  []interface{}{true, http.StatusCreated, nil},
+			publisherCalled: true,
+			expectedOK:      true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			inFlightMock := new(mockInFlight.Repository)
+			publisherMock := new(mockPublisher.Repository)
+
+			if test.registerCalled {
+				inFlightMock.On("Register", ctx, "parse-anime:1:low").Return(test.registerReturn...).Once()
+			}
+
+			if test.suppressCalled {
+				inFlightMock.On("IncrSuppressed", ctx, "parse-anime").Return(http.StatusOK, nil).Once()
+			}
+
+			if test.publisherCalled {
+				publisherMock.On("PublishParseAnime", ctx, int64(1), test.forced, entity.PriorityLow).Return(true, nil).Once()
+				inFlightMock.On("IncrPublished", ctx, "parse-anime").Return(http.StatusOK, nil).Once()
+			}
+
+			ok, err := dedupe.New(publisherMock, inFlightMock).PublishParseAnime(ctx, 1, test.forced, entity.PriorityLow)
+			suite.Equal(test.expectedOK, ok)
+			suite.ErrorIs(err, test.expectedError)
+
+			inFlightMock.AssertExpectations(suite.T())
+			publisherMock.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -6,55 +6,82 @@ import (
 
 	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
 	"github.com/rl404/fairy/pubsub"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Pubsub contains functions for pubsub.
 type Pubsub struct {
-	pubsub pubsub.PubSub
-	topic  string
+	pubsub    pubsub.PubSub
+	topic     string
+	highTopic string
 }
 
 // New to create new pubsub.
-func New(ps pubsub.PubSub, topic string) *Pubsub {
+// High priority messages will be published to highTopic.
+func New(ps pubsub.PubSub, topic, highTopic string) *Pubsub {
 	return &Pubsub{
-		pubsub: ps,
-		topic:  topic,
+		pubsub:    ps,
+		topic:     topic,
+		highTopic: highTopic,
 	}
 }
 
 // PublishParseAnime to publish parse anime.
-func (p *Pubsub) PublishParseAnime(ctx context.Context, id int64, forced bool) error {
-	d, err := json.Marshal(entity.Message{
-		Type:   entity.TypeParseAnime,
-		ID:     id,
-		Forced: forced,
-	})
-	if err != nil {
-		return stack.Wrap(ctx, err, errors.ErrInternalServer)
-	}
+func (p *Pubsub) PublishParseAnime(ctx context.Context, id int64, forced bool, priority entity.Priority) (_ bool, err error) {
+	ctx, span := utils.StartSpan(ctx, "PublishParseAnime", trace.WithSpanKind(trace.SpanKindProducer))
+	defer func() { utils.EndSpan(span, err) }()
 
-	if err := p.pubsub.Publish(ctx, p.topic, d); err != nil {
-		return stack.Wrap(ctx, err, errors.ErrInternalServer)
-	}
+	err = p.publish(ctx, entity.Message{
+		Type:     entity.TypeParseAnime,
+		ID:       id,
+		Forced:   forced,
+		Priority: priority,
+		Trace:    utils.InjectTrace(ctx),
+	})
 
-	return nil
+	return err == nil, err
 }
 
 // PublishParseUserAnime to publish parse user anime.
-func (p *Pubsub) PublishParseUserAnime(ctx context.Context, username, status string, forced bool) error {
-	d, err := json.Marshal(entity.Message{
+func (p *Pubsub) PublishParseUserAnime(ctx context.Context, username, status string, forced bool, priority entity.Priority) (_ bool, err error) {
+	ctx, span := utils.StartSpan(ctx, "PublishParseUserAnime", trace.WithSpanKind(trace.SpanKindProducer))
+	defer func() { utils.EndSpan(span, err) }()
+
+	err = p.publish(ctx, entity.Message{
 		Type:     entity.TypeParseUserAnime,
 		Username: username,
 		Status:   status,
 		Forced:   forced,
+		Priority: priority,
+		Trace:    utils.InjectTrace(ctx),
 	})
+
+	return err == nil, err
+}
+
+// PublishRetry to republish failed message as is.
+func (p *Pubsub) PublishRetry(ctx context.Context, msg entity.Message) (err error) {
+	ctx, span := utils.StartSpan(ctx, "PublishRetry", trace.WithSpanKind(trace.SpanKindProducer))
+	defer func() { utils.EndSpan(span, err) }()
+
+	return p.publish(ctx, msg)
+}
+
+func (p *Pubsub) publish(ctx context.Context, msg entity.Message) error {
+	d, err := json.Marshal(msg)
 	if err != nil {
 		return stack.Wrap(ctx, err, errors.ErrInternalServer)
 	}
 
-	if err := p.pubsub.Publish(ctx, p.topic, d); err != nil {
+	topic := p.topic
+	if msg.Priority == entity.PriorityHigh {
+		topic = p.highTopic
+	}
+
+	if err := p.pubsub.Publish(ctx, topic, d); err != nil {
 		return stack.Wrap(ctx, err, errors.ErrInternalServer)
 	}
 
@@ -0,0 +1,112 @@
+package pubsub_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/publisher/repository/pubsub"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/akatsuki/pkg/tracer"
+	_pubsub "github.com/rl404/fairy/pubsub"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type dummyPubsub struct {
+	topics   []string
+	messages [][]byte
+}
+
+func (d *dummyPubsub) Use(...func(_pubsub.HandlerFunc) _pubsub.HandlerFunc) {}
+
+func (d *dummyPubsub) Publish(_ context.Context, topic string, message []byte) error {
+	d.topics = append(d.topics, topic)
+	d.messages = append(d.messages, message)
+	return nil
+}
+
+func (d *dummyPubsub) Subscribe(context.Context, string, _pubsub.HandlerFunc) error { return nil }
+
+func (d *dummyPubsub) Close() error { return nil }
+
+type pubsubTestSuite struct {
+	suite.Suite
+	exporter *tracetest.InMemoryExporter
+	provider *trace.TracerProvider
+}
+
+func TestPubsub(t *testing.T) {
+	suite.Run(t, new(pubsubTestSuite))
+}
+
+func (suite *pubsubTestSuite) SetupSuite() {
+	suite.exporter = tracetest.NewInMemoryExporter()
+	suite.provider = tracer.NewWithExporter("akatsuki-test", suite.exporter)
+	otel.SetTracerProvider(suite.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (suite *pubsubTestSuite) TestPublishParseAnime() {
+	ps := &dummyPubsub{}
+
+	ctx, span := utils.StartSpan(context.Background(), "HandleGetAnimeByID")
+	ok, err := pubsub.New(ps, "test", "test-high").PublishParseAnime(ctx, 1, false, entity.PriorityLow)
+	suite.Nil(err)
+	suite.True(ok)
+	span.End()
+
+	suite.Len(ps.messages, 1)
+
+	var msg entity.Message
+	suite.Nil(json.Unmarshal(ps.messages[0], &msg))
+	suite.Equal(int64(1), msg.ID)
+	suite.Contains(msg.Trace["traceparent"], span.SpanContext().TraceID().String())
+
+	// Consumer should continue the same trace.
+	ctx = utils.ExtractTrace(context.Background(), msg.Trace)
+	_, consumerSpan := utils.StartSpan(ctx, "Consume parse-anime")
+	consumerSpan.End()
+
+	suite.Equal(span.SpanContext().TraceID(), consumerSpan.SpanContext().TraceID())
+
+	suite.Nil(suite.provider.ForceFlush(context.Background()))
+
+	spans := suite.exporter.GetSpans()
+	suite.Len(spans, 3)
+	for _, s := range spans {
+		suite.Equal(span.SpanContext().TraceID(), s.SpanContext.TraceID())
+	}
+}
+
+func (suite *pubsubTestSuite) TestPublishPriority() {
+	ps := &dummyPubsub{}
+	p := pubsub.New(ps, "test", "test-high")
+
+	_, err := p.PublishParseAnime(context.Background(), 1, false, entity.PriorityHigh)
+	suite.Nil(err)
+	_, err = p.PublishParseUserAnime(context.Background(), "user", "", false, entity.PriorityLow)
+	suite.Nil(err)
+	suite.Equal([]string{"test-high", "test"}, ps.topics)
+
+	var msg entity.Message
+	suite.Nil(json.Unmarshal(ps.messages[0], &msg))
+	suite.Equal(entity.PriorityHigh, msg.Priority)
+}
+
+func (suite *pubsubTestSuite) TestPublishRetry() {
+	ps := &dummyPubsub{}
+	p := pubsub.New(ps, "test", "test-high")
+
+	suite.Nil(p.PublishRetry(context.Background(), entity.Message{Type: entity.TypeParseAnime, ID: 1, Priority: entity.PriorityHigh, Attempt: 2, Error: "dummy error"}))
+	suite.Equal([]string{"test-high"}, ps.topics)
+
+	var msg entity.Message
+	suite.Nil(json.Unmarshal(ps.messages[0], &msg))
+	suite.Equal(2, msg.Attempt)
+	suite.Equal("dummy error", msg.Error)
+}
@@ -2,10 +2,16 @@ package repository
 
 import (
 	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
 )
 
 // Repository contains functions for publisher domain.
+//
+// Parse functions return false if the message is not published
+// because the same message is still in-flight.
 type Repository interface {
-	PublishParseAnime(ctx context.Context, id int64, forced bool) error
-	PublishParseUserAnime(ctx context.Context, username, status string, forced bool) error
+	PublishParseAnime(ctx context.Context, id int64, forced bool, priority entity.Priority) (bool, error)
+	PublishParseUserAnime(ctx context.Context, username, status string, forced bool, priority entity.Priority) (bool, error)
+	PublishRetry(ctx context.Context, msg entity.Message) error
 }
@@ -0,0 +1,11 @@
+package entity
+
+// Similarity is entity for anime similarity.
+//
+// Score is blended from user score similarity
+// and genre & studio similarity.
+type Similarity struct {
+	AnimeID1 int64
+	AnimeID2 int64
+	Score    float64
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+)
+
+// Repository contains functions for recommendation domain.
+type Repository interface {
+	GetSimilarities(ctx context.Context, animeIDs []int64) ([]*entity.Similarity, int, error)
+	ReplaceSimilarities(ctx context.Context, data []entity.Similarity) (int, error)
+}
@@ -0,0 +1,39 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+)
+
+// AnimeSimilarity is anime_similarity database model.
+type AnimeSimilarity struct {
+	AnimeID1  int64 `gorm:"primaryKey"`
+	AnimeID2  int64 `gorm:"primaryKey"`
+	Score     float64
+	CreatedAt time.Time
+}
+
+func (sql *SQL) fromEntity(data entity.Similarity) AnimeSimilarity {
+	return AnimeSimilarity{
+		AnimeID1: data.AnimeID1,
+		AnimeID2: data.AnimeID2,
+		Score:    data.Score,
+	}
+}
+
+func (a *AnimeSimilarity) toEntity() *entity.Similarity {
+	return &entity.Similarity{
+		AnimeID1: a.AnimeID1,
+		AnimeID2: a.AnimeID2,
+		Score:    a.Score,
+	}
+}
+
+func (sql *SQL) toEntities(data []AnimeSimilarity) []*entity.Similarity {
+	a := make([]*entity.Similarity, len(data))
+	for i, aa := range data {
+		a[i] = aa.toEntity()
+	}
+	return a
+}
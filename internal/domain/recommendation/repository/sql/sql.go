@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+const batchSize = 1000
+
+// SQL contains functions for recommendation sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new recommendation database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// GetSimilarities to get similar anime of the anime ids.
+func (sql *SQL) GetSimilarities(ctx context.Context, animeIDs []int64) ([]*entity.Similarity, int, error) {
+	var a []AnimeSimilarity
+	if err := sql.db.WithContext(ctx).Where("anime_id1 in ?", animeIDs).Find(&a).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return sql.toEntities(a), http.StatusOK, nil
+}
+
+// ReplaceSimilarities to replace all anime similarities.
+func (sql *SQL) ReplaceSimilarities(ctx context.Context, data []entity.Similarity) (int, error) {
+	tx := sql.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, tx.Error, errors.ErrInternalDB)
+	}
+	defer tx.Rollback()
+
+	if err := tx.WithContext(ctx).Where("1 = 1").Delete(&AnimeSimilarity{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(data) > 0 {
+		a := make([]AnimeSimilarity, len(data))
+		for i, d := range data {
+			a[i] = sql.fromEntity(d)
+		}
+
+		if err := tx.WithContext(ctx).CreateInBatches(a, batchSize).Error; err != nil {
+			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return http.StatusOK, nil
+}
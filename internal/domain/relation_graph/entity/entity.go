@@ -0,0 +1,17 @@
+package entity
+
+import animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+
+// Graph is entity for user anime relation graph.
+type Graph struct {
+	AnimeIDs  []int64
+	Links     []Link
+	Truncated bool
+}
+
+// Link is entity for relation graph link.
+type Link struct {
+	AnimeID1 int64
+	AnimeID2 int64
+	Relation animeEntity.Relation
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/relation_graph/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/cache"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Cache contains functions for relation graph cache.
+//
+// Each user has a version key. Graphs are saved under
+// the version so deleting the version invalidates all
+// graph variants of the user at once.
+type Cache struct {
+	cacher cache.Cacher
+}
+
+// New to create new relation graph cache.
+func New(cacher cache.Cacher) *Cache {
+	return &Cache{
+		cacher: cacher,
+	}
+}
+
+// Get to get user relation graph.
+// Will return nil if not cached.
+func (c *Cache) Get(ctx context.Context, username, key string) (*entity.Graph, int, error) {
+	var version int64
+	if c.cacher.Get(ctx, utils.GetKey("relation-graph", username), &version) != nil {
+		return nil, http.StatusOK, nil
+	}
+
+	var data entity.Graph
+	if c.cacher.Get(ctx, utils.GetKey("relation-graph", username, version, key), &data) != nil {
+		return nil, http.StatusOK, nil
+	}
+
+	return &data, http.StatusOK, nil
+}
+
+// Set to save user relation graph.
+func (c *Cache) Set(ctx context.Context, username, key string, data entity.Graph) (int, error) {
+	versionKey := utils.GetKey("relation-graph", username)
+
+	var version int64
+	if c.cacher.Get(ctx, versionKey, &version) != nil {
+		version = time.Now().UnixNano()
+		if err := c.cacher.Set(ctx, versionKey, version); err != nil {
+			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+		}
+	}
+
+	if err := c.cacher.Set(ctx, utils.GetKey("relation-graph", username, version, key), data); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeleteByUsername to invalidate all relation graphs of the user.
+func (c *Cache) DeleteByUsername(ctx context.Context, username string) (int, error) {
+	if err := c.cacher.Delete(ctx, utils.GetKey("relation-graph", username)); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalCache)
+	}
+	return http.StatusOK, nil
+}
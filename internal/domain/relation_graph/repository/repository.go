@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/relation_graph/entity"
+)
+
+// Repository contains functions for relation_graph domain.
+type Repository interface {
+	Get(ctx context.Context, username, key string) (*entity.Graph, int, error)
+	Set(ctx context.Context, username, key string, data entity.Graph) (int, error)
+	DeleteByUsername(ctx context.Context, username string) (int, error)
+}
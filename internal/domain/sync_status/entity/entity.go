@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Type is synced data type.
+type Type string
+
+// Available synced data types.
+const (
+	TypeAnime     Type = "ANIME"
+	TypeUserAnime Type = "USER_ANIME"
+)
+
+// SyncStatus is entity for data sync status.
+type SyncStatus struct {
+	Type          Type
+	Key           string
+	Attempt       int
+	LastAttemptAt time.Time
+	LastSuccessAt *time.Time
+	LastError     string
+}
+
+// Stats is entity for sync stats per type.
+type Stats struct {
+	Type    Type
+	Total   int
+	Attempt int
+	Failed  int
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+)
+
+// Repository contains functions for sync_status domain.
+type Repository interface {
+	Record(ctx context.Context, _type entity.Type, key string, syncErr error) (int, error)
+	GetByKey(ctx context.Context, _type entity.Type, key string) (*entity.SyncStatus, int, error)
+	GetStats(ctx context.Context) ([]entity.Stats, int, error)
+}
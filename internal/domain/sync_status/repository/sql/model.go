@@ -0,0 +1,35 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+)
+
+// SyncStatus is sync_status database model.
+type SyncStatus struct {
+	Type          entity.Type `gorm:"primaryKey;size:50"`
+	Key           string      `gorm:"primaryKey;size:255;column:sync_key"` // key is reserved in mysql
+	Attempt       int
+	LastAttemptAt time.Time
+	LastSuccessAt *time.Time
+	LastError     string
+}
+
+func (s *SyncStatus) toEntity() *entity.SyncStatus {
+	return &entity.SyncStatus{
+		Type:          s.Type,
+		Key:           s.Key,
+		Attempt:       s.Attempt,
+		LastAttemptAt: s.LastAttemptAt,
+		LastSuccessAt: s.LastSuccessAt,
+		LastError:     s.LastError,
+	}
+}
+
+type syncStats struct {
+	Type    entity.Type
+	Total   int
+	Attempt int
+	Failed  int
+}
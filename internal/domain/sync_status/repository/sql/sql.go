@@ -0,0 +1,96 @@
+package sql
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQL contains functions for sync_status sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new sync_status database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Record to record sync attempt result.
+func (sql *SQL) Record(ctx context.Context, _type entity.Type, key string, syncErr error) (int, error) {
+	now := time.Now()
+	data := SyncStatus{
+		Type:          _type,
+		Key:           key,
+		Attempt:       1,
+		LastAttemptAt: now,
+	}
+
+	updates := map[string]interface{}{
+		"attempt":         gorm.Expr("sync_status.attempt + 1"),
+		"last_attempt_at": now,
+	}
+
+	if syncErr != nil {
+		data.LastError = syncErr.Error()
+		updates["last_error"] = data.LastError
+	} else {
+		data.LastSuccessAt = &now
+		updates["last_success_at"] = now
+		updates["last_error"] = ""
+	}
+
+	if err := sql.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "type"}, {Name: "sync_key"}},
+		DoUpdates: clause.Assignments(updates),
+	}).Create(&data).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetByKey to get sync status by type and key.
+func (sql *SQL) GetByKey(ctx context.Context, _type entity.Type, key string) (*entity.SyncStatus, int, error) {
+	var s SyncStatus
+	if err := sql.db.WithContext(ctx).Where("type = ? and sync_key = ?", _type, key).First(&s).Error; err != nil {
+		if _errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, stack.Wrap(ctx, err, errors.ErrSyncStatusNotFound)
+		}
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+	return s.toEntity(), http.StatusOK, nil
+}
+
+// GetStats to get sync stats per type.
+func (sql *SQL) GetStats(ctx context.Context) ([]entity.Stats, int, error) {
+	var stats []syncStats
+	if err := sql.db.WithContext(ctx).Model(&SyncStatus{}).
+		Select("type, count(*) as total, sum(attempt) as attempt, sum(case when last_error <> '' then 1 else 0 end) as failed").
+		Group("type").
+		Order("type asc").
+		Find(&stats).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]entity.Stats, len(stats))
+	for i, s := range stats {
+		res[i] = entity.Stats{
+			Type:    s.Type,
+			Total:   s.Total,
+			Attempt: s.Attempt,
+			Failed:  s.Failed,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
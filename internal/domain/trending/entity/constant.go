@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Window is trending time window.
+type Window string
+
+// Available trending time window.
+const (
+	Window7Day  Window = "7d"
+	Window30Day Window = "30d"
+)
+
+// Windows is all trending time window.
+var Windows = []Window{Window7Day, Window30Day}
+
+// Duration to get window duration.
+func (w Window) Duration() time.Duration {
+	switch w {
+	case Window30Day:
+		return 30 * 24 * time.Hour
+	default:
+		return 7 * 24 * time.Hour
+	}
+}
+
+// Sort is trending sorting.
+type Sort string
+
+// Available trending sorting.
+const (
+	SortMember Sort = "MEMBER"
+	SortRank   Sort = "RANK"
+	SortMean   Sort = "MEAN"
+)
@@ -0,0 +1,20 @@
+package entity
+
+// Trending is entity for trending anime.
+//
+// Rank change is positive when the rank climbs.
+type Trending struct {
+	AnimeID    int64
+	Window     Window
+	MemberGain int
+	RankChange int
+	MeanChange float64
+}
+
+// GetRequest is get trending anime request model.
+type GetRequest struct {
+	Window Window
+	Sort   Sort
+	Page   int
+	Limit  int
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rl404/akatsuki/internal/domain/trending/entity"
+)
+
+// Repository contains functions for trending domain.
+type Repository interface {
+	Get(ctx context.Context, data entity.GetRequest) ([]*entity.Trending, int, int, error)
+	Replace(ctx context.Context, window entity.Window, data []entity.Trending) (int, error)
+}
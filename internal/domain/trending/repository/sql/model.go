@@ -0,0 +1,46 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/trending/entity"
+)
+
+// AnimeTrending is anime_trending database model.
+type AnimeTrending struct {
+	TimeWindow string `gorm:"primaryKey"`
+	AnimeID    int64  `gorm:"primaryKey"`
+	MemberGain int
+	RankChange int
+	MeanChange float64
+	CreatedAt  time.Time
+}
+
+func (a *AnimeTrending) toEntity() *entity.Trending {
+	return &entity.Trending{
+		AnimeID:    a.AnimeID,
+		Window:     entity.Window(a.TimeWindow),
+		MemberGain: a.MemberGain,
+		RankChange: a.RankChange,
+		MeanChange: a.MeanChange,
+	}
+}
+
+func (sql *SQL) toEntities(data []AnimeTrending) []*entity.Trending {
+	res := make([]*entity.Trending, len(data))
+	for i, d := range data {
+		res[i] = d.toEntity()
+	}
+	return res
+}
+
+func (sql *SQL) convertSort(sort entity.Sort) string {
+	switch sort {
+	case entity.SortRank:
+		return "rank_change desc, anime_id asc"
+	case entity.SortMean:
+		return "mean_change desc, anime_id asc"
+	default:
+		return "member_gain desc, anime_id asc"
+	}
+}
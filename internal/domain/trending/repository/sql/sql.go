@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rl404/akatsuki/internal/domain/trending/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+	"gorm.io/gorm"
+)
+
+const batchSize = 1000
+
+// SQL contains functions for trending sql database.
+type SQL struct {
+	db *gorm.DB
+}
+
+// New to create new trending database.
+func New(db *gorm.DB) *SQL {
+	return &SQL{
+		db: db,
+	}
+}
+
+// Get to get trending anime list.
+func (sql *SQL) Get(ctx context.Context, data entity.GetRequest) ([]*entity.Trending, int, int, error) {
+	query := sql.db.WithContext(ctx).Model(&AnimeTrending{}).Where("time_window = ?", data.Window)
+
+	switch data.Sort {
+	case entity.SortRank:
+		query.Where("rank_change > 0")
+	case entity.SortMean:
+		query.Where("mean_change > 0")
+	default:
+		query.Where("member_gain > 0")
+	}
+
+	var at []AnimeTrending
+	if err := query.Order(sql.convertSort(data.Sort)).Limit(data.Limit).Offset((data.Page - 1) * data.Limit).Find(&at).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	var cnt int64
+	if err := query.Limit(-1).Offset(-1).Order("").Count(&cnt).Error; err != nil {
+		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return sql.toEntities(at), int(cnt), http.StatusOK, nil
+}
+
+// Replace to replace all trending anime of the window.
+func (sql *SQL) Replace(ctx context.Context, window entity.Window, data []entity.Trending) (int, error) {
+	tx := sql.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, tx.Error, errors.ErrInternalDB)
+	}
+	defer tx.Rollback()
+
+	if err := tx.WithContext(ctx).Where("time_window = ?", window).Delete(&AnimeTrending{}).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(data) > 0 {
+		trending := make([]AnimeTrending, len(data))
+		for i, t := range data {
+			trending[i] = AnimeTrending{
+				TimeWindow: string(window),
+				AnimeID:    t.AnimeID,
+				MemberGain: t.MemberGain,
+				RankChange: t.RankChange,
+				MeanChange: t.MeanChange,
+			}
+		}
+
+		if err := tx.WithContext(ctx).CreateInBatches(trending, batchSize).Error; err != nil {
+			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	return http.StatusOK, nil
+}
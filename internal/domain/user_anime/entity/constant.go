@@ -33,3 +33,19 @@ const (
 	RewatchValueHigh     RewatchValue = "HIGH"
 	RewatchValueVeryHigh RewatchValue = "VERY_HIGH"
 )
+
+// Sort is user anime sorting.
+type Sort string
+
+// Available user anime sorting.
+const (
+	SortScore     Sort = "SCORE"
+	SortUpdatedAt Sort = "UPDATED_AT"
+	SortProgress  Sort = "PROGRESS"
+	SortTitle     Sort = "TITLE"
+)
+
+// IsAnime to check if sorting uses anime data.
+func (s Sort) IsAnime() bool {
+	return s == SortTitle || s == "-"+SortTitle
+}
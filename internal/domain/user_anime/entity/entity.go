@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+)
 
 // UserAnime is user anime entity.
 type UserAnime struct {
@@ -25,9 +29,29 @@ type UserAnime struct {
 	UpdatedAt    time.Time
 }
 
+// Rating is user anime score entity.
+type Rating struct {
+	Username string
+	AnimeID  int64
+	Score    int
+}
+
 // GetUserAnimeRequest is get user anime request model.
+// Title, anime type, season and genre are
+// filtered from anime data.
 type GetUserAnimeRequest struct {
-	Username string
-	Page     int
-	Limit    int
+	Username     string
+	Status       Status
+	StartScore   int
+	EndScore     int
+	Tags         []string
+	IsRewatching *bool
+	Title        string
+	AnimeType    animeEntity.Type
+	Season       animeEntity.Season
+	SeasonYear   int
+	GenreID      int64
+	Sort         Sort
+	Page         int
+	Limit        int
 }
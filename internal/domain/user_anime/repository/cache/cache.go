@@ -32,6 +32,16 @@ func (c *Cache) Update(ctx context.Context, data entity.UserAnime) (int, error)
 	return c.repo.Update(ctx, data)
 }
 
+// GetRatingCounts to get scoring user count of each anime.
+func (c *Cache) GetRatingCounts(ctx context.Context) (map[int64]int, int, error) {
+	return c.repo.GetRatingCounts(ctx)
+}
+
+// GetRatings to get scored user anime of the next users.
+func (c *Cache) GetRatings(ctx context.Context, lastUsername string, limit int) ([]*entity.Rating, int, error) {
+	return c.repo.GetRatings(ctx, lastUsername, limit)
+}
+
 // IsOld to check if old.
 func (c *Cache) IsOld(ctx context.Context, username string) (bool, int, error) {
 	return c.repo.IsOld(ctx, username)
@@ -10,6 +10,8 @@ import (
 type Repository interface {
 	Get(ctx context.Context, data entity.GetUserAnimeRequest) ([]*entity.UserAnime, int, int, error)
 	Update(ctx context.Context, data entity.UserAnime) (int, error)
+	GetRatingCounts(ctx context.Context) (map[int64]int, int, error)
+	GetRatings(ctx context.Context, lastUsername string, limit int) ([]*entity.Rating, int, error)
 	IsOld(ctx context.Context, username string) (bool, int, error)
 	GetOldUsernames(ctx context.Context) ([]string, int, error)
 	DeleteNotInList(ctx context.Context, username string, ids []int64, status entity.Status) (int, error)
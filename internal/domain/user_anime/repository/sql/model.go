@@ -2,6 +2,7 @@ package sql
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
@@ -92,3 +93,27 @@ func (sql *SQL) userAnimeFromEntity(anime entity.UserAnime) *UserAnime {
 		Comment:      anime.Comment,
 	}
 }
+
+func (sql *SQL) convertSort(sort entity.Sort) string {
+	if sort == "" {
+		return "user_anime.id asc"
+	}
+
+	suffix := "asc"
+	if sort[0] == '-' {
+		sort, suffix = sort[1:], "desc"
+	}
+
+	switch sort {
+	case entity.SortScore:
+		return fmt.Sprintf("user_anime.score %s, user_anime.id asc", suffix)
+	case entity.SortUpdatedAt:
+		return fmt.Sprintf("user_anime.updated_at %s, user_anime.id asc", suffix)
+	case entity.SortProgress:
+		return fmt.Sprintf("user_anime.episode %s, user_anime.id asc", suffix)
+	case entity.SortTitle:
+		return fmt.Sprintf("lower(anime.title) %s, user_anime.id asc", suffix)
+	default:
+		return "user_anime.id asc"
+	}
+}
@@ -2,16 +2,24 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	_errors "errors"
 	"net/http"
+	"strings"
 	"time"
 
+	outboxEntity "github.com/rl404/akatsuki/internal/domain/outbox/entity"
+	outboxSQL "github.com/rl404/akatsuki/internal/domain/outbox/repository/sql"
 	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/fairy/errors/stack"
 	"gorm.io/gorm"
 )
 
+// likeEscaper escapes like wildcards with '!' which
+// works the same in mysql and postgresql.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 // SQL contains functions for user sql database.
 type SQL struct {
 	db  *gorm.DB
@@ -29,28 +37,129 @@ func New(db *gorm.DB, age int) *SQL {
 // Get to get user anime.
 func (sql *SQL) Get(ctx context.Context, data entity.GetUserAnimeRequest) ([]*entity.UserAnime, int, int, error) {
 	var a []UserAnime
-	query := sql.db.WithContext(ctx).Model(&UserAnime{})
+	query := sql.db.WithContext(ctx).Model(&UserAnime{}).Select("user_anime.*")
 
 	if data.Username != "" {
-		query.Where("username = ?", data.Username)
+		query.Where("user_anime.username = ?", data.Username)
+	}
+
+	if data.Status != "" {
+		query.Where("user_anime.status = ?", data.Status)
+	}
+
+	if data.StartScore > 0 {
+		query.Where("user_anime.score >= ?", data.StartScore)
+	}
+
+	if data.EndScore > 0 {
+		query.Where("user_anime.score <= ?", data.EndScore)
+	}
+
+	// Tags are saved as json array. Encode the tag the same
+	// way so it only matches a whole element.
+	for _, tag := range data.Tags {
+		t, _ := json.Marshal(tag)
+		query.Where("user_anime.tags like ? escape '!'", "%"+likeEscaper.Replace(string(t))+"%")
+	}
+
+	if data.IsRewatching != nil {
+		query.Where("user_anime.is_rewatching = ?", data.IsRewatching)
+	}
+
+	if data.Title != "" || data.AnimeType != "" || data.Season != "" || data.SeasonYear != 0 || data.Sort.IsAnime() {
+		query.Joins("join anime on anime.id = user_anime.anime_id")
+	}
+
+	if data.Title != "" {
+		query.Where("anime.title ilike ? or anime.title_synonym ilike ? or anime.title_english ilike ? or anime.title_japanese ilike ?", "%"+data.Title+"%", "%"+data.Title+"%", "%"+data.Title+"%", "%"+data.Title+"%")
+	}
+
+	if data.AnimeType != "" {
+		query.Where("anime.type = ?", data.AnimeType)
+	}
+
+	if data.Season != "" {
+		query.Where("anime.season = ?", data.Season)
+	}
+
+	if data.SeasonYear != 0 {
+		query.Where("anime.season_year = ?", data.SeasonYear)
 	}
 
-	if err := query.Limit(data.Limit).Offset((data.Page - 1) * data.Limit).Find(&a).Error; err != nil {
+	if data.GenreID != 0 {
+		query.Joins("join anime_genre ag on ag.anime_id = user_anime.anime_id and ag.genre_id = ?", data.GenreID)
+	}
+
+	if err := query.Order(sql.convertSort(data.Sort)).Limit(data.Limit).Offset((data.Page - 1) * data.Limit).Find(&a).Error; err != nil {
 		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
 
 	var cnt int64
-	if err := query.Limit(-1).Count(&cnt).Error; err != nil {
+	if err := query.Select("user_anime.id").Limit(-1).Offset(-1).Order("").Count(&cnt).Error; err != nil {
 		return nil, 0, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
 
 	return sql.userAnimeToEntities(a), int(cnt), http.StatusOK, nil
 }
 
+// GetRatingCounts to get scoring user count of each anime.
+func (sql *SQL) GetRatingCounts(ctx context.Context) (map[int64]int, int, error) {
+	var counts []struct {
+		AnimeID int64
+		Count   int
+	}
+
+	if err := sql.db.WithContext(ctx).Model(&UserAnime{}).Select("anime_id, count(*) as count").Where("score > 0").Group("anime_id").Find(&counts).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make(map[int64]int, len(counts))
+	for _, c := range counts {
+		res[c.AnimeID] = c.Count
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// GetRatings to get scored user anime of the next
+// limit users after lastUsername, ordered by username.
+func (sql *SQL) GetRatings(ctx context.Context, lastUsername string, limit int) ([]*entity.Rating, int, error) {
+	var usernames []string
+	if err := sql.db.WithContext(ctx).Model(&UserAnime{}).Distinct("username").Where("score > 0 and username > ?", lastUsername).Order("username").Limit(limit).Pluck("username", &usernames).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if len(usernames) == 0 {
+		return []*entity.Rating{}, http.StatusOK, nil
+	}
+
+	var a []UserAnime
+	if err := sql.db.WithContext(ctx).Select("username, anime_id, score").Where("score > 0 and username in ?", usernames).Order("username, anime_id").Find(&a).Error; err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	res := make([]*entity.Rating, len(a))
+	for i, ua := range a {
+		res[i] = &entity.Rating{
+			Username: ua.Username,
+			AnimeID:  ua.AnimeID,
+			Score:    ua.Score,
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
 // Update to update user anime.
 func (sql *SQL) Update(ctx context.Context, data entity.UserAnime) (int, error) {
+	tx := sql.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, tx.Error, errors.ErrInternalDB)
+	}
+	defer tx.Rollback()
+
 	var ua UserAnime
-	if err := sql.db.WithContext(ctx).Select("id, created_at").Where("username = ? and anime_id = ?", data.Username, data.AnimeID).First(&ua).Error; err != nil {
+	if err := tx.WithContext(ctx).Select("id, created_at").Where("username = ? and anime_id = ?", data.Username, data.AnimeID).First(&ua).Error; err != nil {
 		if !_errors.Is(err, gorm.ErrRecordNotFound) {
 			return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 		}
@@ -61,7 +170,19 @@ func (sql *SQL) Update(ctx context.Context, data entity.UserAnime) (int, error)
 	userAnime.CreatedAt = ua.CreatedAt
 	userAnime.UpdatedAt = time.Now()
 
-	if err := sql.db.WithContext(ctx).Save(userAnime).Error; err != nil {
+	if err := tx.WithContext(ctx).Save(userAnime).Error; err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	// Queue the anime.
+	if err := outboxSQL.Add(tx.WithContext(ctx), outboxEntity.Outbox{
+		Type:    outboxEntity.TypeParseAnime,
+		AnimeID: data.AnimeID,
+	}); err != nil {
+		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalDB)
 	}
 
@@ -0,0 +1,76 @@
+package sql_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/repository/sql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+type testSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	dbMock sqlmock.Sqlmock
+}
+
+func TestSQL(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (suite *testSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	suite.Require().Nil(err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	suite.Require().Nil(err)
+
+	suite.db, suite.dbMock = gormDB, mock
+}
+
+func (suite *testSuite) TearDownSuite() {
+	db, err := suite.db.DB()
+	require.Nil(suite.T(), err)
+	db.Close()
+}
+
+func (suite *testSuite) TestGetTags() {
+	ctx := context.Background()
+	tag := `50%_off! "<a>"`
+	arg := `%"50!%!_off!! \"\u003ca\u003e\""%`
+
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT user_anime.* FROM "user_anime" WHERE user_anime.tags like $1 escape '!' AND "user_anime"."deleted_at" IS NULL ORDER BY user_anime.id asc LIMIT $2`)).
+		WithArgs(arg, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "anime_id", "tags"}).AddRow(1, "rl404", 1, `["50%_off! \"\u003ca\u003e\""]`))
+	suite.dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT("user_anime"."id") FROM "user_anime" WHERE user_anime.tags like $1 escape '!' AND "user_anime"."deleted_at" IS NULL`)).
+		WithArgs(arg).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	data, total, code, err := sql.New(suite.db, 1).Get(ctx, entity.GetUserAnimeRequest{
+		Tags:  []string{tag},
+		Page:  1,
+		Limit: 10,
+	})
+	suite.Require().Nil(err)
+	suite.Require().Len(data, 1)
+	suite.Equal([]string{tag}, data[0].Tags)
+	suite.Equal(1, total)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+}
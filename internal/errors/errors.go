@@ -17,8 +17,21 @@ var (
 	ErrInvalidAnimeID       = errors.New("invalid anime id")
 	ErrInvalidGenreID       = errors.New("invalid genre id")
 	ErrInvalidStudioID      = errors.New("invalid studio id")
+	ErrInvalidFranchiseID   = errors.New("invalid franchise id")
+	ErrInvalidTimezone      = errors.New("invalid timezone")
 	ErrAnimeNotFound        = errors.New("anime not found")
+	ErrFranchiseNotFound    = errors.New("franchise not found")
+	ErrSyncStatusNotFound   = errors.New("sync status not found")
 	ErrDataStillNew         = errors.New("data is still new")
+	ErrRefreshRangeTooLarge = errors.New("refresh id range is too large")
+	ErrInvalidAPIKey        = errors.New("invalid api key")
+	ErrInvalidAPIKeyID      = errors.New("invalid api key id")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrForbiddenScope       = errors.New("api key does not have access to this scope")
+	ErrTooManyRequests      = errors.New("too many requests")
+	ErrUpdateCooldown       = errors.New("data was just forced to update, try again later")
+	ErrConsumerClosed       = errors.New("consumer is closed")
+	ErrInMemoryPubsub       = errors.New("inmemory pubsub can only be used with all-in-one")
 )
 
 // ErrRequiredField is error for missing field.
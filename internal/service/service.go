@@ -2,15 +2,28 @@ package service
 
 import (
 	"context"
+	"time"
 
 	animeRepository "github.com/rl404/akatsuki/internal/domain/anime/repository"
+	apiKeyRepository "github.com/rl404/akatsuki/internal/domain/api_key/repository"
+	cooldownRepository "github.com/rl404/akatsuki/internal/domain/cooldown/repository"
+	deadLetterRepository "github.com/rl404/akatsuki/internal/domain/dead_letter/repository"
 	emptyIDRepository "github.com/rl404/akatsuki/internal/domain/empty_id/repository"
+	franchiseRepository "github.com/rl404/akatsuki/internal/domain/franchise/repository"
 	genreRepository "github.com/rl404/akatsuki/internal/domain/genre/repository"
+	inFlightRepository "github.com/rl404/akatsuki/internal/domain/in_flight/repository"
+	jobHistoryRepository "github.com/rl404/akatsuki/internal/domain/job_history/repository"
 	malRepository "github.com/rl404/akatsuki/internal/domain/mal/repository"
+	outboxRepository "github.com/rl404/akatsuki/internal/domain/outbox/repository"
 	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	publisherRepository "github.com/rl404/akatsuki/internal/domain/publisher/repository"
+	recommendationRepository "github.com/rl404/akatsuki/internal/domain/recommendation/repository"
+	relationGraphRepository "github.com/rl404/akatsuki/internal/domain/relation_graph/repository"
 	studioRepository "github.com/rl404/akatsuki/internal/domain/studio/repository"
+	syncStatusRepository "github.com/rl404/akatsuki/internal/domain/sync_status/repository"
+	trendingRepository "github.com/rl404/akatsuki/internal/domain/trending/repository"
 	userAnimeRepository "github.com/rl404/akatsuki/internal/domain/user_anime/repository"
+	"github.com/rl404/akatsuki/pkg/limit/bucket"
 )
 
 // Service contains functions for service.
@@ -18,7 +31,22 @@ type Service interface {
 	GetAnime(ctx context.Context, data GetAnimeRequest) ([]Anime, *Pagination, int, error)
 	GetAnimeByID(ctx context.Context, id int64) (*Anime, int, error)
 	GetAnimeHistoriesByID(ctx context.Context, data GetAnimeHistoriesRequest) ([]AnimeHistory, int, error)
-	UpdateAnimeByID(ctx context.Context, id int64) (int, error)
+	UpdateAnimeByID(ctx context.Context, id int64) (*Cooldown, int, error)
+	RefreshAnime(ctx context.Context, data RefreshAnimeRequest) (int, int, error)
+
+	GetFranchises(ctx context.Context, data GetFranchisesRequest) ([]Franchise, *Pagination, int, error)
+	GetFranchiseByID(ctx context.Context, id int64) (*FranchiseDetail, int, error)
+	UpdateFranchises(ctx context.Context) (int, int, error)
+	GetWatchOrder(ctx context.Context, data GetWatchOrderRequest) (*WatchOrder, int, error)
+
+	GetSeasons(ctx context.Context) ([]SeasonCount, int, error)
+	GetSeason(ctx context.Context, data GetSeasonRequest) (*SeasonDetail, int, error)
+
+	GetSchedule(ctx context.Context, data GetScheduleRequest) (*Schedule, int, error)
+	GetScheduleCalendar(ctx context.Context, data GetScheduleCalendarRequest) ([]byte, int, error)
+
+	GetTrendingAnime(ctx context.Context, data GetTrendingAnimeRequest) ([]TrendingAnime, *Pagination, int, error)
+	UpdateTrending(ctx context.Context) (int, int, error)
 
 	GetGenres(ctx context.Context, data GetGenresRequest) ([]Genre, *Pagination, int, error)
 	GetGenreByID(ctx context.Context, id int64) (*Genre, int, error)
@@ -29,45 +57,112 @@ type Service interface {
 	GetStudioHistoriesByID(ctx context.Context, data GetStudioHistoriesRequest) ([]StudioHistory, int, error)
 
 	GetUserAnime(ctx context.Context, data GetUserAnimeRequest) ([]UserAnime, *Pagination, int, error)
-	GetUserAnimeRelations(ctx context.Context, username string) (*UserAnimeRelation, int, error)
-	UpdateUserAnime(ctx context.Context, username string) (int, error)
+	GetUserAnimeRelations(ctx context.Context, data GetUserAnimeRelationsRequest) (*UserAnimeRelation, int, error)
+	GetUserAnimeStats(ctx context.Context, username string) (*UserAnimeStats, int, error)
+	CompareUserAnime(ctx context.Context, username1, username2 string) (*UserAnimeCompare, int, error)
+	GetUserRecommendations(ctx context.Context, data GetUserRecommendationsRequest) ([]Recommendation, int, error)
+	UpdateRecommendations(ctx context.Context, limit int) (int, int, error)
+	UpdateUserAnime(ctx context.Context, username string) (*Cooldown, int, error)
+
+	GetEmptyIDs(ctx context.Context, data GetEmptyIDsRequest) ([]EmptyID, *Pagination, int, error)
+	RecheckEmptyIDs(ctx context.Context, data RecheckEmptyIDsRequest) (int, int, error)
+	ExpireEmptyIDs(ctx context.Context, data ExpireEmptyIDsRequest) (int, int, error)
 
 	ConsumeMessage(ctx context.Context, msg entity.Message) error
 
-	QueueOldReleasingAnime(ctx context.Context, limit int) (int, int, error)
-	QueueOldFinishedAnime(ctx context.Context, limit int) (int, int, error)
-	QueueOldNotYetAnime(ctx context.Context, limit int) (int, int, error)
+	SaveDeadLetter(ctx context.Context, msg entity.Message) error
+	GetDeadLetters(ctx context.Context, data GetDeadLettersRequest) ([]DeadLetter, *Pagination, int, error)
+	ReplayDeadLetters(ctx context.Context, ids []int64) (int, int, error)
+	PurgeDeadLetters(ctx context.Context, ids []int64) (int, error)
+
+	QueueOldAnime(ctx context.Context, limit int) (int, int, error)
+	QueueAiredAnime(ctx context.Context, window time.Duration, limit int) (int, int, error)
 	QueueMissingAnime(ctx context.Context, limit int) (int, int, error)
 	QueueOldUserAnime(ctx context.Context, limit int) (int, int, error)
+	GetQueueStats(ctx context.Context) (*QueueStats, int, error)
+	GetAnimeSyncStatus(ctx context.Context, id int64) (*SyncStatus, int, error)
+
+	RetryMessage(ctx context.Context, data entity.Message) error
+	RelayOutbox(ctx context.Context, limit int) (int, int, error)
+
+	StartJob(ctx context.Context, name string) (int64, int, error)
+	FinishJob(ctx context.Context, id int64, queued int, jobErr error) (int, error)
+	GetJobs(ctx context.Context, data GetJobsRequest) ([]Job, *Pagination, int, error)
+
+	CreateAPIKey(ctx context.Context, data CreateAPIKeyRequest) (*APIKey, int, error)
+	GetAPIKeys(ctx context.Context, data GetAPIKeysRequest) ([]APIKey, *Pagination, int, error)
+	DeleteAPIKey(ctx context.Context, id int64) (int, error)
+	Authenticate(ctx context.Context, data AuthenticateRequest) (time.Duration, int, error)
 }
 
 type service struct {
-	anime     animeRepository.Repository
-	genre     genreRepository.Repository
-	studio    studioRepository.Repository
-	userAnime userAnimeRepository.Repository
-	emptyID   emptyIDRepository.Repository
-	publisher publisherRepository.Repository
-	mal       malRepository.Repository
+	anime          animeRepository.Repository
+	genre          genreRepository.Repository
+	studio         studioRepository.Repository
+	userAnime      userAnimeRepository.Repository
+	emptyID        emptyIDRepository.Repository
+	publisher      publisherRepository.Repository
+	mal            malRepository.Repository
+	deadLetter     deadLetterRepository.Repository
+	inFlight       inFlightRepository.Repository
+	outbox         outboxRepository.Repository
+	jobHistory     jobHistoryRepository.Repository
+	syncStatus     syncStatusRepository.Repository
+	apiKey         apiKeyRepository.Repository
+	cooldown       cooldownRepository.Repository
+	recommendation recommendationRepository.Repository
+	franchise      franchiseRepository.Repository
+	relationGraph  relationGraphRepository.Repository
+	trending       trendingRepository.Repository
+
+	apiKeyLimiter *bucket.Bucket
+}
+
+// Deps is service dependencies.
+// All of them are required.
+type Deps struct {
+	Anime          animeRepository.Repository
+	Genre          genreRepository.Repository
+	Studio         studioRepository.Repository
+	UserAnime      userAnimeRepository.Repository
+	EmptyID        emptyIDRepository.Repository
+	Publisher      publisherRepository.Repository
+	MAL            malRepository.Repository
+	DeadLetter     deadLetterRepository.Repository
+	InFlight       inFlightRepository.Repository
+	Outbox         outboxRepository.Repository
+	JobHistory     jobHistoryRepository.Repository
+	SyncStatus     syncStatusRepository.Repository
+	APIKey         apiKeyRepository.Repository
+	Cooldown       cooldownRepository.Repository
+	Recommendation recommendationRepository.Repository
+	Franchise      franchiseRepository.Repository
+	RelationGraph  relationGraphRepository.Repository
+	Trending       trendingRepository.Repository
 }
 
 // New to create new service.
-func New(
-	anime animeRepository.Repository,
-	genre genreRepository.Repository,
-	studio studioRepository.Repository,
-	userAnime userAnimeRepository.Repository,
-	emptyID emptyIDRepository.Repository,
-	publisher publisherRepository.Repository,
-	mal malRepository.Repository,
-) Service {
+func New(deps Deps) Service {
 	return &service{
-		anime:     anime,
-		genre:     genre,
-		studio:    studio,
-		userAnime: userAnime,
-		emptyID:   emptyID,
-		publisher: publisher,
-		mal:       mal,
+		anime:          deps.Anime,
+		genre:          deps.Genre,
+		studio:         deps.Studio,
+		userAnime:      deps.UserAnime,
+		emptyID:        deps.EmptyID,
+		publisher:      deps.Publisher,
+		mal:            deps.MAL,
+		deadLetter:     deps.DeadLetter,
+		inFlight:       deps.InFlight,
+		outbox:         deps.Outbox,
+		jobHistory:     deps.JobHistory,
+		syncStatus:     deps.SyncStatus,
+		apiKey:         deps.APIKey,
+		cooldown:       deps.Cooldown,
+		recommendation: deps.Recommendation,
+		franchise:      deps.Franchise,
+		relationGraph:  deps.RelationGraph,
+		trending:       deps.Trending,
+
+		apiKeyLimiter: bucket.New(),
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
@@ -39,6 +40,7 @@ type Anime struct {
 	Pictures          []string         `json:"pictures"`
 	Related           []AnimeRelated   `json:"related"`
 	Studios           []AnimeStudio    `json:"studios"`
+	FranchiseID       *int64           `json:"franchise_id"`
 }
 
 // GetAnimeRequest is get anime list request model.
@@ -66,7 +68,29 @@ func (s *service) GetAnime(ctx context.Context, data GetAnimeRequest) ([]Anime,
 		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
 	}
 
-	anime, total, code, err := s.anime.Get(ctx, entity.GetRequest{
+	anime, total, code, err := s.anime.Get(ctx, s.animeGetRequest(data))
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]Anime, len(anime))
+	for i, a := range anime {
+		res[i] = s.animeFromEntity(a)
+	}
+
+	if code, err := s.getFranchiseIDs(ctx, res); err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+func (s *service) animeGetRequest(data GetAnimeRequest) entity.GetRequest {
+	return entity.GetRequest{
 		Title:           data.Title,
 		NSFW:            data.NSFW,
 		Type:            data.Type,
@@ -82,21 +106,7 @@ func (s *service) GetAnime(ctx context.Context, data GetAnimeRequest) ([]Anime,
 		Sort:            data.Sort,
 		Page:            data.Page,
 		Limit:           data.Limit,
-	})
-	if err != nil {
-		return nil, nil, code, stack.Wrap(ctx, err)
-	}
-
-	res := make([]Anime, len(anime))
-	for i, a := range anime {
-		res[i] = s.animeFromEntity(a)
 	}
-
-	return res, &Pagination{
-		Page:  data.Page,
-		Limit: data.Limit,
-		Total: total,
-	}, http.StatusOK, nil
 }
 
 // GetAnimeByID to get anime by id.
@@ -110,7 +120,7 @@ func (s *service) GetAnimeByID(ctx context.Context, id int64) (*Anime, int, erro
 	if err != nil {
 		if code == http.StatusNotFound {
 			// Queue to parse.
-			if err := s.publisher.PublishParseAnime(ctx, id, false); err != nil {
+			if _, err := s.publisher.PublishParseAnime(ctx, id, false, publisherEntity.PriorityHigh); err != nil {
 				return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
 			}
 			return nil, http.StatusAccepted, nil
@@ -177,7 +187,13 @@ func (s *service) GetAnimeByID(ctx context.Context, id int64) (*Anime, int, erro
 		}
 	}
 
-	return &anime, http.StatusOK, nil
+	// Get franchise.
+	res := []Anime{anime}
+	if code, err := s.getFranchiseIDs(ctx, res); err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	return &res[0], http.StatusOK, nil
 }
 
 func (s *service) validateID(ctx context.Context, id int64) (int, error) {
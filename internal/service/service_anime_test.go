@@ -8,11 +8,13 @@ import (
 
 	"github.com/rl404/akatsuki/internal/domain/anime/entity"
 	entityGenre "github.com/rl404/akatsuki/internal/domain/genre/entity"
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	entityStudio "github.com/rl404/akatsuki/internal/domain/studio/entity"
 	"github.com/rl404/akatsuki/internal/errors"
 	"github.com/rl404/akatsuki/internal/service"
 	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
 	mockEmptyID "github.com/rl404/akatsuki/tests/mocks/domain/empty_id"
+	mockFranchise "github.com/rl404/akatsuki/tests/mocks/domain/franchise"
 	mockGenre "github.com/rl404/akatsuki/tests/mocks/domain/genre"
 	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
 	mockStudio "github.com/rl404/akatsuki/tests/mocks/domain/studio"
@@ -26,6 +28,7 @@ type animeTestSuite struct {
 	genreMock     *mockGenre.Repository
 	studioMock    *mockStudio.Repository
 	publisherMock *mockPublisher.Repository
+	franchiseMock *mockFranchise.Repository
 }
 
 func TestAnime(t *testing.T) {
@@ -35,12 +38,14 @@ func TestAnime(t *testing.T) {
 		genreMock:     new(mockGenre.Repository),
 		studioMock:    new(mockStudio.Repository),
 		publisherMock: new(mockPublisher.Repository),
+		franchiseMock: new(mockFranchise.Repository),
 	})
 }
 
 func (suite *animeTestSuite) TestGetAnime() {
 	ctx := context.Background()
 	errDummy := _errors.New("dummy error")
+	franchiseID := int64(5)
 
 	tests := []struct {
 		name               string
@@ -48,6 +53,9 @@ func (suite *animeTestSuite) TestGetAnime() {
 		repoCalled         bool
 		repoParams         []interface{}
 		repoReturn         []interface{}
+		franchiseCalled    bool
+		franchiseParams    []interface{}
+		franchiseReturn    []interface{}
 		expectedReturn     []service.Anime
 		expectedPagination *service.Pagination
 		expectedCode       int
@@ -78,7 +86,10 @@ func (suite *animeTestSuite) TestGetAnime() {
 			repoCalled:         true,
 			repoParams:         []interface{}{ctx, entity.GetRequest{Sort: "RANK", Page: 1, Limit: 20}},
 			repoReturn:         []interface{}{[]*entity.Anime{{ID: 1}}, 1, http.StatusOK, nil},
-			expectedReturn:     []service.Anime{{ID: 1, Genres: []service.AnimeGenre{}, Related: []service.AnimeRelated{}, Studios: []service.AnimeStudio{}}},
+			franchiseCalled:    true,
+			franchiseParams:    []interface{}{ctx, []int64{1}},
+			franchiseReturn:    []interface{}{map[int64]int64{1: 5}, http.StatusOK, nil},
+			expectedReturn:     []service.Anime{{ID: 1, Genres: []service.AnimeGenre{}, Related: []service.AnimeRelated{}, Studios: []service.AnimeStudio{}, FranchiseID: &franchiseID}},
 			expectedPagination: &service.Pagination{Page: 1, Limit: 20, Total: 1},
 			expectedCode:       http.StatusOK,
 			expectedError:      nil,
@@ -91,7 +102,14 @@ func (suite *animeTestSuite) TestGetAnime() {
 				suite.animeMock.On("Get", test.repoParams...).Return(test.repoReturn...).Once()
 			}
 
-			s := service.New(suite.animeMock, nil, nil, nil, nil, nil, nil)
+			if test.franchiseCalled {
+				suite.franchiseMock.On("GetIDsByAnimeIDs", test.franchiseParams...).Return(test.franchiseReturn...).Once()
+			}
+
+			s := service.New(service.Deps{
+				Anime:     suite.animeMock,
+				Franchise: suite.franchiseMock,
+			})
 
 			data, pagination, code, err := s.GetAnime(ctx, test.param)
 			suite.Equal(test.expectedReturn, data)
@@ -127,6 +145,9 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 		repoGenreParams     []interface{}
 		repoGenreReturn     []interface{}
 		repoRelatedCalled   bool
+		franchiseCalled     bool
+		franchiseParams     []interface{}
+		franchiseReturn     []interface{}
 		repoRelatedParams   []interface{}
 		repoRelatedReturn   []interface{}
 		repoStudioCalled    bool
@@ -173,8 +194,8 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 			repoAnimeParams:     []interface{}{ctx, int64(1)},
 			repoAnimeReturn:     []interface{}{nil, http.StatusNotFound, errDummy},
 			repoPublisherCalled: true,
-			repoPublisherParams: []interface{}{ctx, int64(1), false},
-			repoPublisherReturn: []interface{}{errDummy},
+			repoPublisherParams: []interface{}{ctx, int64(1), false, entityPublisher.PriorityHigh},
+			repoPublisherReturn: []interface{}{false, errDummy},
 			expectedReturn:      nil,
 			expectedCode:        http.StatusInternalServerError,
 			expectedError:       errDummy,
@@ -189,8 +210,8 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 			repoAnimeParams:     []interface{}{ctx, int64(1)},
 			repoAnimeReturn:     []interface{}{nil, http.StatusNotFound, errDummy},
 			repoPublisherCalled: true,
-			repoPublisherParams: []interface{}{ctx, int64(1), false},
-			repoPublisherReturn: []interface{}{nil},
+			repoPublisherParams: []interface{}{ctx, int64(1), false, entityPublisher.PriorityHigh},
+			repoPublisherReturn: []interface{}{true, nil},
 			expectedReturn:      nil,
 			expectedCode:        http.StatusAccepted,
 			expectedError:       nil,
@@ -283,6 +304,9 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 			repoStudioCalled:  true,
 			repoStudioParams:  []interface{}{ctx, []int64{4}},
 			repoStudioReturn:  []interface{}{[]*entityStudio.Studio{{ID: 4, Name: "studio"}}, http.StatusOK, nil},
+			franchiseCalled:   true,
+			franchiseParams:   []interface{}{ctx, []int64{1}},
+			franchiseReturn:   []interface{}{map[int64]int64{}, http.StatusOK, nil},
 			expectedReturn: &service.Anime{
 				ID:      1,
 				Genres:  []service.AnimeGenre{{ID: 2, Name: "genre"}},
@@ -320,7 +344,18 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 				suite.studioMock.On("GetByIDs", test.repoStudioParams...).Return(test.repoStudioReturn...).Once()
 			}
 
-			s := service.New(suite.animeMock, suite.genreMock, suite.studioMock, nil, suite.emptyIDMock, suite.publisherMock, nil)
+			if test.franchiseCalled {
+				suite.franchiseMock.On("GetIDsByAnimeIDs", test.franchiseParams...).Return(test.franchiseReturn...).Once()
+			}
+
+			s := service.New(service.Deps{
+				Anime:     suite.animeMock,
+				Genre:     suite.genreMock,
+				Studio:    suite.studioMock,
+				EmptyID:   suite.emptyIDMock,
+				Publisher: suite.publisherMock,
+				Franchise: suite.franchiseMock,
+			})
 
 			data, code, err := s.GetAnimeByID(ctx, test.param)
 			suite.Equal(test.expectedReturn, data)
@@ -334,3 +369,93 @@ func (suite *animeTestSuite) TestGetAnimeByID() {
 		})
 	}
 }
+
+func (suite *animeTestSuite) TestRefreshAnime() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	tests := []struct {
+		name                string
+		param               service.RefreshAnimeRequest
+		repoAnimeCalled     bool
+		repoAnimeParams     []interface{}
+		repoAnimeReturn     []interface{}
+		repoPublisherParams [][]interface{}
+		repoPublisherReturn []interface{}
+		expectedReturn      int
+		expectedCode        int
+		expectedError       error
+	}{
+		{
+			name:           "invalid-range",
+			param:          service.RefreshAnimeRequest{StartID: 10, EndID: 5},
+			expectedReturn: 0,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.ErrGTEField("end_id", "start_id"),
+		},
+		{
+			name:           "range-too-large",
+			param:          service.RefreshAnimeRequest{StartID: 1, EndID: 1001},
+			expectedReturn: 0,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.ErrRefreshRangeTooLarge,
+		},
+		{
+			name:                "ok-range",
+			param:               service.RefreshAnimeRequest{StartID: 1, EndID: 2},
+			repoPublisherParams: [][]interface{}{{ctx, int64(1), true, entityPublisher.PriorityLow}, {ctx, int64(2), true, entityPublisher.PriorityLow}},
+			repoPublisherReturn: []interface{}{true, nil},
+			expectedReturn:      2,
+			expectedCode:        http.StatusAccepted,
+		},
+		{
+			name:            "error-get",
+			param:           service.RefreshAnimeRequest{},
+			repoAnimeCalled: true,
+			repoAnimeParams: []interface{}{ctx, entity.GetRequest{Sort: "RANK", Page: 1, Limit: 20}},
+			repoAnimeReturn: []interface{}{nil, 0, http.StatusInternalServerError, errDummy},
+			expectedReturn:  0,
+			expectedCode:    http.StatusInternalServerError,
+			expectedError:   errDummy,
+		},
+		{
+			name:                "error-publisher",
+			param:               service.RefreshAnimeRequest{Filter: service.GetAnimeRequest{Status: "releasing"}},
+			repoAnimeCalled:     true,
+			repoAnimeParams:     []interface{}{ctx, entity.GetRequest{Status: entity.StatusReleasing, Sort: "RANK", Page: 1, Limit: 20}},
+			repoAnimeReturn:     []interface{}{[]*entity.Anime{{ID: 3}}, 1, http.StatusOK, nil},
+			repoPublisherParams: [][]interface{}{{ctx, int64(3), true, entityPublisher.PriorityLow}},
+			repoPublisherReturn: []interface{}{false, errDummy},
+			expectedReturn:      0,
+			expectedCode:        http.StatusInternalServerError,
+			expectedError:       errDummy,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			if test.repoAnimeCalled {
+				suite.animeMock.On("Get", test.repoAnimeParams...).Return(test.repoAnimeReturn...).Once()
+			}
+
+			for _, p := range test.repoPublisherParams {
+				suite.publisherMock.On("PublishParseAnime", p...).Return(test.repoPublisherReturn...).Once()
+			}
+
+			s := service.New(service.Deps{
+				Anime:     suite.animeMock,
+				Publisher: suite.publisherMock,
+			})
+
+			cnt, code, err := s.RefreshAnime(ctx, test.param)
+			suite.Equal(test.expectedReturn, cnt)
+			suite.Equal(test.expectedCode, code)
+
+			if test.expectedError != nil {
+				suite.ErrorContains(test.expectedError, err.Error())
+			} else {
+				suite.Nil(err)
+			}
+		})
+	}
+}
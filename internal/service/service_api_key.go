@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const apiKeyPrefix = "ak_"
+
+// APIKey is api key model.
+type APIKey struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Key          string     `json:"key,omitempty"`
+	Scopes       []string   `json:"scopes"`
+	Rate         float64    `json:"rate"`
+	Burst        int        `json:"burst"`
+	RequestCount int        `json:"request_count"`
+	LimitedCount int        `json:"limited_count"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (s *service) apiKeyFromEntity(a entity.APIKey) APIKey {
+	scopes := make([]string, len(a.Scopes))
+	for i, sc := range a.Scopes {
+		scopes[i] = string(sc)
+	}
+
+	return APIKey{
+		ID:           a.ID,
+		Name:         a.Name,
+		Scopes:       scopes,
+		Rate:         a.Rate,
+		Burst:        a.Burst,
+		RequestCount: a.RequestCount,
+		LimitedCount: a.LimitedCount,
+		LastUsedAt:   a.LastUsedAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// CreateAPIKeyRequest is create api key request model.
+type CreateAPIKeyRequest struct {
+	Name   string   `validate:"required" mod:"trim"`
+	Scopes []string `validate:"required,min=1,dive,oneof=READ TRIGGER_UPDATE ADMIN" mod:"dive,ucase,no_space"`
+	Rate   float64  `validate:"gt=0" mod:"default=5"` // request per second
+	Burst  int      `validate:"gt=0" mod:"default=10"`
+}
+
+// CreateAPIKey to create new api key.
+// The key is only returned here, only its hash is saved.
+func (s *service) CreateAPIKey(ctx context.Context, data CreateAPIKeyRequest) (*APIKey, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalServer)
+	}
+
+	scopes := make([]entity.Scope, len(data.Scopes))
+	for i, sc := range data.Scopes {
+		scopes[i] = entity.Scope(sc)
+	}
+
+	apiKey, code, err := s.apiKey.Create(ctx, entity.APIKey{
+		Name:   data.Name,
+		Hash:   hashAPIKey(key),
+		Scopes: scopes,
+		Rate:   data.Rate,
+		Burst:  data.Burst,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := s.apiKeyFromEntity(*apiKey)
+	res.Key = key
+
+	return &res, http.StatusCreated, nil
+}
+
+// GetAPIKeysRequest is get api key list request model.
+type GetAPIKeysRequest struct {
+	Page  int `validate:"required,gte=1" mod:"default=1"`
+	Limit int `validate:"required,gte=-1" mod:"default=20"`
+}
+
+// GetAPIKeys to get api key list with their usage.
+func (s *service) GetAPIKeys(ctx context.Context, data GetAPIKeysRequest) ([]APIKey, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	apiKeys, total, code, err := s.apiKey.Get(ctx, entity.GetRequest{
+		Page:  data.Page,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]APIKey, len(apiKeys))
+	for i, a := range apiKeys {
+		res[i] = s.apiKeyFromEntity(*a)
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+// DeleteAPIKey to delete api key.
+func (s *service) DeleteAPIKey(ctx context.Context, id int64) (int, error) {
+	if code, err := s.apiKey.Delete(ctx, id); err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+	return http.StatusOK, nil
+}
+
+// AuthenticateRequest is authenticate request model.
+type AuthenticateRequest struct {
+	Key   string
+	Scope entity.Scope
+}
+
+// Authenticate to check api key scope and rate limit.
+// Will return how long to wait if rate limited.
+func (s *service) Authenticate(ctx context.Context, data AuthenticateRequest) (time.Duration, int, error) {
+	if data.Key == "" {
+		return 0, http.StatusUnauthorized, stack.Wrap(ctx, errors.ErrInvalidAPIKey)
+	}
+
+	apiKey, code, err := s.apiKey.GetByHash(ctx, hashAPIKey(data.Key))
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	if !apiKey.HasScope(data.Scope) {
+		return 0, http.StatusForbidden, stack.Wrap(ctx, errors.ErrForbiddenScope)
+	}
+
+	ok, wait := s.apiKeyLimiter.Allow(apiKey.Hash, apiKey.Rate, apiKey.Burst)
+
+	usage := entity.Usage{ID: apiKey.ID, Request: 1, LastUsedAt: time.Now()}
+	if !ok {
+		usage.Limited = 1
+	}
+
+	// Counting usage should not block the request.
+	if _, err := s.apiKey.AddUsage(ctx, usage); err != nil {
+		stack.Wrap(ctx, err)
+	}
+
+	if !ok {
+		return wait, http.StatusTooManyRequests, stack.Wrap(ctx, errors.ErrTooManyRequests)
+	}
+
+	return 0, http.StatusOK, nil
+}
+
+func generateAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+func hashAPIKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/api_key/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAPIKey "github.com/rl404/akatsuki/tests/mocks/domain/api_key"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type apiKeyTestSuite struct {
+	suite.Suite
+	apiKeyMock *mockAPIKey.Repository
+	service    service.Service
+}
+
+func TestAPIKey(t *testing.T) {
+	suite.Run(t, new(apiKeyTestSuite))
+}
+
+func (suite *apiKeyTestSuite) SetupTest() {
+	suite.apiKeyMock = new(mockAPIKey.Repository)
+	suite.service = service.New(service.Deps{
+		APIKey: suite.apiKeyMock,
+	})
+}
+
+func (suite *apiKeyTestSuite) TestCreateAPIKey() {
+	ctx := context.Background()
+
+	_, code, err := suite.service.CreateAPIKey(ctx, service.CreateAPIKeyRequest{Name: "test", Scopes: []string{"random"}})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	suite.apiKeyMock.On("Create", ctx, mock.MatchedBy(func(data entity.APIKey) bool {
+		return data.Name == "test" && len(data.Hash) == 64 && data.Rate == 5 && data.Burst == 10 &&
+			len(data.Scopes) == 2 && data.Scopes[0] == entity.ScopeRead && data.Scopes[1] == entity.ScopeTriggerUpdate
+	})).Return(&entity.APIKey{ID: 1, Name: "test", Scopes: []entity.Scope{entity.ScopeRead, entity.ScopeTriggerUpdate}, Rate: 5, Burst: 10}, http.StatusCreated, nil).Once()
+
+	apiKey, code, err := suite.service.CreateAPIKey(ctx, service.CreateAPIKeyRequest{Name: "test", Scopes: []string{"read", "trigger_update"}})
+	suite.Equal(http.StatusCreated, code)
+	suite.Nil(err)
+	suite.Equal(int64(1), apiKey.ID)
+	suite.True(strings.HasPrefix(apiKey.Key, "ak_"))
+	suite.apiKeyMock.AssertExpectations(suite.T())
+}
+
+func (suite *apiKeyTestSuite) TestAuthenticate() {
+	ctx := context.Background()
+	apiKey := &entity.APIKey{ID: 1, Hash: "hash", Scopes: []entity.Scope{entity.ScopeRead}, Rate: 1, Burst: 1}
+
+	_, code, err := suite.service.Authenticate(ctx, service.AuthenticateRequest{Scope: entity.ScopeRead})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.ErrorIs(err, errors.ErrInvalidAPIKey)
+
+	suite.apiKeyMock.On("GetByHash", ctx, mock.Anything).Return(apiKey, http.StatusOK, nil)
+
+	_, code, err = suite.service.Authenticate(ctx, service.AuthenticateRequest{Key: "key", Scope: entity.ScopeAdmin})
+	suite.Equal(http.StatusForbidden, code)
+	suite.ErrorIs(err, errors.ErrForbiddenScope)
+
+	suite.apiKeyMock.On("AddUsage", ctx, mock.MatchedBy(func(data entity.Usage) bool {
+		return data.ID == 1 && data.Request == 1 && data.Limited == 0
+	})).Return(http.StatusOK, nil).Once()
+
+	wait, code, err := suite.service.Authenticate(ctx, service.AuthenticateRequest{Key: "key", Scope: entity.ScopeRead})
+	suite.Zero(wait)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.apiKeyMock.On("AddUsage", ctx, mock.MatchedBy(func(data entity.Usage) bool {
+		return data.ID == 1 && data.Request == 1 && data.Limited == 1
+	})).Return(http.StatusOK, nil).Once()
+
+	wait, code, err = suite.service.Authenticate(ctx, service.AuthenticateRequest{Key: "key", Scope: entity.ScopeRead})
+	suite.Greater(wait.Nanoseconds(), int64(0))
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.ErrorIs(err, errors.ErrTooManyRequests)
+	suite.apiKeyMock.AssertExpectations(suite.T())
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	syncEntity "github.com/rl404/akatsuki/internal/domain/sync_status/entity"
 	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
 	"github.com/rl404/nagato"
 )
@@ -12,17 +15,36 @@ import (
 // ConsumeMessage to consume message from queue.
 // Each message type will be handled differently.
 func (s *service) ConsumeMessage(ctx context.Context, data entity.Message) error {
+	var err error
 	switch data.Type {
 	case entity.TypeParseAnime:
-		return stack.Wrap(ctx, s.consumeParseAnime(ctx, data))
+		err = s.consumeParseAnime(ctx, data)
 	case entity.TypeParseUserAnime:
-		return stack.Wrap(ctx, s.consumeParseUserAnime(ctx, data))
+		err = s.consumeParseUserAnime(ctx, data)
 	default:
-		return stack.Wrap(ctx, errors.ErrInvalidMessageType)
+		err = errors.ErrInvalidMessageType
+	}
+
+	if err != nil {
+		return stack.Wrap(ctx, err)
+	}
+
+	s.finished(ctx, data)
+	return nil
+}
+
+// finished to count queue depth.
+// Failed messages are finished when moved to dead letter.
+func (s *service) finished(ctx context.Context, data entity.Message) {
+	if _, err := s.inFlight.IncrFinished(ctx, string(data.Type)); err != nil {
+		stack.Wrap(ctx, err)
 	}
 }
 
-func (s *service) consumeParseAnime(ctx context.Context, data entity.Message) error {
+func (s *service) consumeParseAnime(ctx context.Context, data entity.Message) (err error) {
+	ctx, span := utils.StartSpan(ctx, "consumeParseAnime")
+	defer func() { utils.EndSpan(span, err) }()
+
 	if !data.Forced {
 		isOld, _, err := s.anime.IsOld(ctx, data.ID)
 		if err != nil {
@@ -34,6 +56,8 @@ func (s *service) consumeParseAnime(ctx context.Context, data entity.Message) er
 		}
 	}
 
+	defer func() { s.recordSync(ctx, syncEntity.TypeAnime, strconv.FormatInt(data.ID, 10), err) }()
+
 	// Delete existing empty id.
 	if _, err := s.emptyID.Delete(ctx, data.ID); err != nil {
 		return stack.Wrap(ctx, err)
@@ -46,7 +70,10 @@ func (s *service) consumeParseAnime(ctx context.Context, data entity.Message) er
 	return nil
 }
 
-func (s *service) consumeParseUserAnime(ctx context.Context, data entity.Message) error {
+func (s *service) consumeParseUserAnime(ctx context.Context, data entity.Message) (err error) {
+	ctx, span := utils.StartSpan(ctx, "consumeParseUserAnime")
+	defer func() { utils.EndSpan(span, err) }()
+
 	if !data.Forced {
 		isOld, _, err := s.userAnime.IsOld(ctx, data.Username)
 		if err != nil {
@@ -59,7 +86,9 @@ func (s *service) consumeParseUserAnime(ctx context.Context, data entity.Message
 	}
 
 	if data.Status != "" {
-		if _, err := s.updateUserAnime(ctx, data.Username, data.Status); err != nil {
+		_, err := s.updateUserAnime(ctx, data.Username, data.Status)
+		s.recordSync(ctx, syncEntity.TypeUserAnime, data.Username, err)
+		if err != nil {
 			return stack.Wrap(ctx, err)
 		}
 		return nil
@@ -74,7 +103,7 @@ func (s *service) consumeParseUserAnime(ctx context.Context, data entity.Message
 	}
 
 	for _, status := range statuses {
-		if err := s.publisher.PublishParseUserAnime(ctx, data.Username, string(status), true); err != nil {
+		if _, err := s.publisher.PublishParseUserAnime(ctx, data.Username, string(status), true, data.Priority); err != nil {
 			return stack.Wrap(ctx, err)
 		}
 	}
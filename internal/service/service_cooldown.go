@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Cooldown is forced update cooldown model.
+type Cooldown struct {
+	// Seconds until the data can be updated again.
+	RetryAfter int `json:"retry_after"`
+	// Last time the data was fetched from MyAnimeList.
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// takeCooldown to make sure the data is not forced
+// to update too often. Will return cooldown if the
+// data is still in cooldown window.
+func (s *service) takeCooldown(ctx context.Context, _type entity.Type, key string) (*Cooldown, int, error) {
+	wait, code, err := s.cooldown.Take(ctx, utils.GetKey(_type, key))
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if wait <= 0 {
+		return nil, http.StatusOK, nil
+	}
+
+	cooldown := Cooldown{RetryAfter: int(math.Ceil(wait.Seconds()))}
+
+	// Sync status is only for information.
+	if status, _, err := s.syncStatus.GetByKey(ctx, _type, key); err == nil {
+		cooldown.UpdatedAt = status.LastSuccessAt
+	}
+
+	return &cooldown, http.StatusTooManyRequests, stack.Wrap(ctx, errors.ErrUpdateCooldown)
+}
+
+// releaseCooldown to let the data be forced to update
+// again right away. The error is only logged.
+func (s *service) releaseCooldown(ctx context.Context, _type entity.Type, key string) {
+	if _, err := s.cooldown.Release(ctx, utils.GetKey(_type, key)); err != nil {
+		stack.Wrap(ctx, err)
+	}
+}
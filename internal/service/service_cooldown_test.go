@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	mockCooldown "github.com/rl404/akatsuki/tests/mocks/domain/cooldown"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	mockSyncStatus "github.com/rl404/akatsuki/tests/mocks/domain/sync_status"
+	"github.com/stretchr/testify/suite"
+)
+
+type cooldownTestSuite struct {
+	suite.Suite
+	publisherMock  *mockPublisher.Repository
+	syncStatusMock *mockSyncStatus.Repository
+	cooldownMock   *mockCooldown.Repository
+	service        service.Service
+}
+
+func TestCooldown(t *testing.T) {
+	suite.Run(t, new(cooldownTestSuite))
+}
+
+func (suite *cooldownTestSuite) SetupTest() {
+	suite.publisherMock = new(mockPublisher.Repository)
+	suite.syncStatusMock = new(mockSyncStatus.Repository)
+	suite.cooldownMock = new(mockCooldown.Repository)
+	suite.service = service.New(service.Deps{
+		Publisher:  suite.publisherMock,
+		SyncStatus: suite.syncStatusMock,
+		Cooldown:   suite.cooldownMock,
+	})
+}
+
+func (suite *cooldownTestSuite) TestUpdateAnimeByID() {
+	ctx := context.Background()
+	now := time.Now()
+
+	suite.cooldownMock.On("Take", ctx, "akatsuki:ANIME:1").Return(time.Duration(0), http.StatusCreated, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(1), true, entityPublisher.PriorityHigh).Return(true, nil).Once()
+
+	cooldown, code, err := suite.service.UpdateAnimeByID(ctx, 1)
+	suite.Nil(cooldown)
+	suite.Equal(http.StatusAccepted, code)
+	suite.Nil(err)
+
+	suite.cooldownMock.On("Take", ctx, "akatsuki:ANIME:1").Return(90*time.Second+time.Millisecond, http.StatusOK, nil).Once()
+	suite.syncStatusMock.On("GetByKey", ctx, entity.TypeAnime, "1").Return(&entity.SyncStatus{LastSuccessAt: &now}, http.StatusOK, nil).Once()
+
+	cooldown, code, err = suite.service.UpdateAnimeByID(ctx, 1)
+	suite.Equal(&service.Cooldown{RetryAfter: 91, UpdatedAt: &now}, cooldown)
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.ErrorIs(err, errors.ErrUpdateCooldown)
+
+	suite.cooldownMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+	suite.syncStatusMock.AssertExpectations(suite.T())
+}
+
+func (suite *cooldownTestSuite) TestUpdateAnimeByIDPublishError() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	suite.cooldownMock.On("Take", ctx, "akatsuki:ANIME:1").Return(time.Duration(0), http.StatusCreated, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(1), true, entityPublisher.PriorityHigh).Return(false, errDummy).Once()
+	suite.cooldownMock.On("Release", ctx, "akatsuki:ANIME:1").Return(http.StatusOK, nil).Once()
+
+	cooldown, code, err := suite.service.UpdateAnimeByID(ctx, 1)
+	suite.Nil(cooldown)
+	suite.Equal(http.StatusInternalServerError, code)
+	suite.ErrorIs(err, errDummy)
+
+	suite.cooldownMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
+
+func (suite *cooldownTestSuite) TestUpdateUserAnime() {
+	ctx := context.Background()
+
+	suite.cooldownMock.On("Take", ctx, "akatsuki:USER_ANIME:rl404").Return(time.Minute, http.StatusOK, nil).Once()
+	suite.syncStatusMock.On("GetByKey", ctx, entity.TypeUserAnime, "rl404").Return(nil, http.StatusNotFound, errors.ErrSyncStatusNotFound).Once()
+
+	cooldown, code, err := suite.service.UpdateUserAnime(ctx, "RL404")
+	suite.Equal(&service.Cooldown{RetryAfter: 60}, cooldown)
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.ErrorIs(err, errors.ErrUpdateCooldown)
+
+	suite.cooldownMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	deadLetterEntity "github.com/rl404/akatsuki/internal/domain/dead_letter/entity"
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// DeadLetter is dead letter model.
+type DeadLetter struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	AnimeID   int64     `json:"anime_id"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	Forced    bool      `json:"forced"`
+	Attempt   int       `json:"attempt"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SaveDeadLetter to save message from dead letter queue.
+func (s *service) SaveDeadLetter(ctx context.Context, data entity.Message) error {
+	d, err := json.Marshal(data)
+	if err != nil {
+		return stack.Wrap(ctx, err, errors.ErrInternalServer)
+	}
+
+	if _, err := s.deadLetter.Create(ctx, deadLetterEntity.DeadLetter{
+		Type:    string(data.Type),
+		Message: d,
+		Error:   data.Error,
+		Attempt: data.Attempt,
+	}); err != nil {
+		return stack.Wrap(ctx, err)
+	}
+
+	s.finished(ctx, data)
+	return nil
+}
+
+// GetDeadLettersRequest is get dead letter list request model.
+type GetDeadLettersRequest struct {
+	Page  int `validate:"required,gte=1" mod:"default=1"`
+	Limit int `validate:"required,gte=-1" mod:"default=20"`
+}
+
+// GetDeadLetters to get dead letter list.
+func (s *service) GetDeadLetters(ctx context.Context, data GetDeadLettersRequest) ([]DeadLetter, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	deadLetters, total, code, err := s.deadLetter.Get(ctx, deadLetterEntity.GetRequest{
+		Page:  data.Page,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]DeadLetter, len(deadLetters))
+	for i, d := range deadLetters {
+		var msg entity.Message
+		if err := json.Unmarshal(d.Message, &msg); err != nil {
+			return nil, nil, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalServer)
+		}
+
+		res[i] = DeadLetter{
+			ID:        d.ID,
+			Type:      d.Type,
+			AnimeID:   msg.ID,
+			Username:  msg.Username,
+			Status:    msg.Status,
+			Forced:    msg.Forced,
+			Attempt:   d.Attempt,
+			Error:     d.Error,
+			CreatedAt: d.CreatedAt,
+		}
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+// ReplayDeadLetters to requeue dead letters.
+// Empty ids means replay all dead letters.
+func (s *service) ReplayDeadLetters(ctx context.Context, ids []int64) (int, int, error) {
+	var cnt int
+
+	deadLetters, code, err := s.getDeadLetters(ctx, ids)
+	if err != nil {
+		return cnt, code, stack.Wrap(ctx, err)
+	}
+
+	for _, d := range deadLetters {
+		var msg entity.Message
+		if err := json.Unmarshal(d.Message, &msg); err != nil {
+			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err, errors.ErrInternalServer)
+		}
+
+		switch msg.Type {
+		case entity.TypeParseAnime:
+			_, err = s.publisher.PublishParseAnime(ctx, msg.ID, msg.Forced, msg.Priority)
+		case entity.TypeParseUserAnime:
+			_, err = s.publisher.PublishParseUserAnime(ctx, msg.Username, msg.Status, msg.Forced, msg.Priority)
+		default:
+			err = errors.ErrInvalidMessageType
+		}
+		if err != nil {
+			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+
+		if code, err := s.deadLetter.Delete(ctx, []int64{d.ID}); err != nil {
+			return cnt, code, stack.Wrap(ctx, err)
+		}
+
+		cnt++
+	}
+
+	return cnt, http.StatusOK, nil
+}
+
+// PurgeDeadLetters to delete dead letters.
+// Empty ids means purge all dead letters.
+func (s *service) PurgeDeadLetters(ctx context.Context, ids []int64) (int, error) {
+	if len(ids) == 0 {
+		if code, err := s.deadLetter.DeleteAll(ctx); err != nil {
+			return code, stack.Wrap(ctx, err)
+		}
+		return http.StatusOK, nil
+	}
+
+	if code, err := s.deadLetter.Delete(ctx, ids); err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (s *service) getDeadLetters(ctx context.Context, ids []int64) ([]*deadLetterEntity.DeadLetter, int, error) {
+	if len(ids) > 0 {
+		return s.deadLetter.GetByIDs(ctx, ids)
+	}
+
+	deadLetters, _, code, err := s.deadLetter.Get(ctx, deadLetterEntity.GetRequest{Page: 1, Limit: -1})
+	return deadLetters, code, err
+}
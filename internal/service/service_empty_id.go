@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/empty_id/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// EmptyID is anime id not found in MyAnimeList model.
+type EmptyID struct {
+	AnimeID   int64     `json:"anime_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetEmptyIDsRequest is get empty id list request model.
+type GetEmptyIDsRequest struct {
+	Page  int `validate:"required,gte=1" mod:"default=1"`
+	Limit int `validate:"required,gte=-1" mod:"default=20"`
+}
+
+// GetEmptyIDs to get empty id list.
+// Oldest first.
+func (s *service) GetEmptyIDs(ctx context.Context, data GetEmptyIDsRequest) ([]EmptyID, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	ids, total, code, err := s.emptyID.GetList(ctx, entity.GetRequest{
+		Page:  data.Page,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]EmptyID, len(ids))
+	for i, id := range ids {
+		res[i] = EmptyID{
+			AnimeID:   id.AnimeID,
+			CreatedAt: id.CreatedAt,
+		}
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+// RecheckEmptyIDsRequest is recheck empty id request model.
+type RecheckEmptyIDsRequest struct {
+	Limit int `validate:"required,gte=-1" mod:"default=100"`
+}
+
+// RecheckEmptyIDs to queue oldest empty ids to be parsed again.
+// Ids still not found will be saved again with new created date.
+// Will return queued count.
+func (s *service) RecheckEmptyIDs(ctx context.Context, data RecheckEmptyIDsRequest) (int, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return 0, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	ids, _, code, err := s.emptyID.GetList(ctx, entity.GetRequest{
+		Page:  1,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	for i, id := range ids {
+		if _, err := s.publisher.PublishParseAnime(ctx, id.AnimeID, true, publisherEntity.PriorityLow); err != nil {
+			return i, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+	}
+
+	return len(ids), http.StatusAccepted, nil
+}
+
+// ExpireEmptyIDsRequest is expire empty id request model.
+type ExpireEmptyIDsRequest struct {
+	Days int `validate:"required,gt=0"`
+}
+
+// ExpireEmptyIDs to delete empty ids older than n days
+// so they will be queued again by fill cron.
+// Will return deleted count.
+func (s *service) ExpireEmptyIDs(ctx context.Context, data ExpireEmptyIDsRequest) (int, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return 0, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	ids, code, err := s.emptyID.DeleteOlderThan(ctx, time.Now().AddDate(0, 0, -data.Days))
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	return len(ids), http.StatusOK, nil
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Max anime ids in one query.
+const franchiseChunk = 1000
+
+// Franchise is franchise model.
+type Franchise struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	AnimeCount int    `json:"anime_count"`
+}
+
+// GetFranchisesRequest is get franchise list request model.
+type GetFranchisesRequest struct {
+	Name  string `mod:"trim"`
+	Page  int    `validate:"required,gte=1" mod:"default=1"`
+	Limit int    `validate:"required,gte=-1" mod:"default=20"`
+}
+
+// GetFranchises to get franchise list.
+func (s *service) GetFranchises(ctx context.Context, data GetFranchisesRequest) ([]Franchise, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	franchises, total, code, err := s.franchise.Get(ctx, entity.GetRequest{
+		Name:  data.Name,
+		Page:  data.Page,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]Franchise, len(franchises))
+	for i, f := range franchises {
+		res[i] = Franchise{
+			ID:         f.ID,
+			Name:       f.Name,
+			AnimeCount: f.AnimeCount,
+		}
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+// FranchiseDetail is franchise detail model.
+type FranchiseDetail struct {
+	ID    int64            `json:"id"`
+	Name  string           `json:"name"`
+	Anime []franchiseAnime `json:"anime"`
+}
+
+type franchiseAnime struct {
+	ID        int64              `json:"id"`
+	Title     string             `json:"title"`
+	Picture   string             `json:"picture"`
+	Type      animeEntity.Type   `json:"type" swaggertype:"string"`
+	Status    animeEntity.Status `json:"status" swaggertype:"string"`
+	StartDate Date               `json:"start_date"`
+}
+
+// GetFranchiseByID to get franchise by id.
+// Anime are sorted by start date.
+func (s *service) GetFranchiseByID(ctx context.Context, id int64) (*FranchiseDetail, int, error) {
+	if id <= 0 {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, errors.ErrInvalidFranchiseID)
+	}
+
+	franchise, code, err := s.franchise.GetByID(ctx, id)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	anime, code, err := s.anime.GetByIDs(ctx, franchise.AnimeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	sort.Slice(anime, func(i, j int) bool {
+		return s.isAnimeEarlier(anime[i], anime[j])
+	})
+
+	res := FranchiseDetail{
+		ID:    franchise.ID,
+		Name:  franchise.Name,
+		Anime: make([]franchiseAnime, len(anime)),
+	}
+
+	for i, a := range anime {
+		res.Anime[i] = franchiseAnime{
+			ID:      a.ID,
+			Title:   a.Title,
+			Picture: a.Picture,
+			Type:    a.Type,
+			Status:  a.Status,
+			StartDate: Date{
+				Year:  a.StartDate.Year,
+				Month: a.StartDate.Month,
+				Day:   a.StartDate.Day,
+			},
+		}
+	}
+
+	return &res, http.StatusOK, nil
+}
+
+// UpdateFranchises to recompute franchises from anime
+// story relations. Standalone anime are not franchise.
+// Will return total saved franchises.
+func (s *service) UpdateFranchises(ctx context.Context) (int, int, error) {
+	related, code, err := s.anime.GetAllRelated(ctx)
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	// Union find.
+	parents := make(map[int64]int64)
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		if _, ok := parents[id]; !ok {
+			parents[id] = id
+		}
+		if parents[id] != id {
+			parents[id] = find(parents[id])
+		}
+		return parents[id]
+	}
+
+	for _, r := range related {
+		if !r.Relation.IsStory() {
+			continue
+		}
+
+		if root1, root2 := find(r.AnimeID1), find(r.AnimeID2); root1 != root2 {
+			parents[root1] = root2
+		}
+	}
+
+	components := make(map[int64][]int64)
+	for id := range parents {
+		root := find(id)
+		components[root] = append(components[root], id)
+	}
+
+	// Get anime to pick franchise id and name.
+	animeIDs := make([]int64, 0, len(parents))
+	for id := range parents {
+		animeIDs = append(animeIDs, id)
+	}
+
+	animeMap := make(map[int64]*animeEntity.Anime)
+	for i := 0; i < len(animeIDs); i += franchiseChunk {
+		anime, code, err := s.anime.GetByIDs(ctx, animeIDs[i:min(i+franchiseChunk, len(animeIDs))])
+		if err != nil {
+			return 0, code, stack.Wrap(ctx, err)
+		}
+
+		for _, a := range anime {
+			animeMap[a.ID] = a
+		}
+	}
+
+	var franchises []entity.Franchise
+	for _, ids := range components {
+		var first *animeEntity.Anime
+		for _, id := range ids {
+			if a := animeMap[id]; a != nil && (first == nil || s.isAnimeEarlier(a, first)) {
+				first = a
+			}
+		}
+
+		// Related anime not parsed yet.
+		if len(ids) < 2 || first == nil {
+			continue
+		}
+
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		franchises = append(franchises, entity.Franchise{
+			ID:       first.ID,
+			Name:     first.Title,
+			AnimeIDs: ids,
+		})
+	}
+
+	if code, err := s.franchise.Replace(ctx, franchises); err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	return len(franchises), http.StatusOK, nil
+}
+
+// isAnimeEarlier to check if anime a started before b.
+// Unknown start date is treated as the latest.
+func (s *service) isAnimeEarlier(a, b *animeEntity.Anime) bool {
+	dateA := [3]int{a.StartDate.Year, a.StartDate.Month, a.StartDate.Day}
+	dateB := [3]int{b.StartDate.Year, b.StartDate.Month, b.StartDate.Day}
+
+	for i := range dateA {
+		if dateA[i] == dateB[i] {
+			continue
+		}
+		if dateA[i] == 0 {
+			return false
+		}
+		if dateB[i] == 0 {
+			return true
+		}
+		return dateA[i] < dateB[i]
+	}
+
+	return a.ID < b.ID
+}
+
+func (s *service) getFranchiseIDs(ctx context.Context, anime []Anime) (int, error) {
+	if len(anime) == 0 {
+		return http.StatusOK, nil
+	}
+
+	ids := make([]int64, len(anime))
+	for i, a := range anime {
+		ids[i] = a.ID
+	}
+
+	franchiseIDs, code, err := s.franchise.GetIDsByAnimeIDs(ctx, ids)
+	if err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
+	for i := range anime {
+		if id, ok := franchiseIDs[anime[i].ID]; ok {
+			anime[i].FranchiseID = &id
+		}
+	}
+
+	return http.StatusOK, nil
+}
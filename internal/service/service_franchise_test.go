@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	entityAnime "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	entityUserAnime "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockFranchise "github.com/rl404/akatsuki/tests/mocks/domain/franchise"
+	mockUserAnime "github.com/rl404/akatsuki/tests/mocks/domain/user_anime"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type franchiseTestSuite struct {
+	suite.Suite
+	animeMock     *mockAnime.Repository
+	franchiseMock *mockFranchise.Repository
+	userAnimeMock *mockUserAnime.Repository
+	service       service.Service
+}
+
+func TestFranchise(t *testing.T) {
+	suite.Run(t, new(franchiseTestSuite))
+}
+
+func (suite *franchiseTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.franchiseMock = new(mockFranchise.Repository)
+	suite.userAnimeMock = new(mockUserAnime.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:     suite.animeMock,
+		UserAnime: suite.userAnimeMock,
+		Franchise: suite.franchiseMock,
+	})
+}
+
+func (suite *franchiseTestSuite) TestGetFranchiseByID() {
+	ctx := context.Background()
+
+	// Invalid id.
+	_, code, err := suite.service.GetFranchiseByID(ctx, 0)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.ErrorIs(err, errors.ErrInvalidFranchiseID)
+
+	// Anime sorted by start date.
+	suite.franchiseMock.On("GetByID", ctx, int64(1)).Return(&entity.Franchise{ID: 1, Name: "A", AnimeCount: 3, AnimeIDs: []int64{1, 2, 3}}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1, 2, 3}).Return([]*entityAnime.Anime{
+		{ID: 3, Title: "C"},
+		{ID: 2, Title: "B", StartDate: entityAnime.Date{Year: 2010, Month: 5}},
+		{ID: 1, Title: "A", StartDate: entityAnime.Date{Year: 2010, Month: 4}},
+	}, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetFranchiseByID(ctx, 1)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal("A", data.Name)
+	suite.Len(data.Anime, 3)
+	suite.Equal([]int64{1, 2, 3}, []int64{data.Anime[0].ID, data.Anime[1].ID, data.Anime[2].ID})
+
+	suite.franchiseMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+}
+
+func (suite *franchiseTestSuite) TestUpdateFranchises() {
+	ctx := context.Background()
+
+	// 1-2-3 is one franchise, 4 is only linked
+	// by character relation.
+	suite.animeMock.On("GetAllRelated", ctx).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel},
+		{AnimeID1: 3, AnimeID2: 2, Relation: entityAnime.RelationSideStory},
+		{AnimeID1: 3, AnimeID2: 4, Relation: entityAnime.RelationCharacter},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, mock.Anything).Return([]*entityAnime.Anime{
+		{ID: 1, Title: "A", StartDate: entityAnime.Date{Year: 2012}},
+		{ID: 2, Title: "B", StartDate: entityAnime.Date{Year: 2010}},
+		{ID: 3, Title: "C", StartDate: entityAnime.Date{Year: 2011}},
+	}, http.StatusOK, nil).Once()
+	suite.franchiseMock.On("Replace", ctx, []entity.Franchise{{ID: 2, Name: "B", AnimeIDs: []int64{1, 2, 3}}}).Return(http.StatusOK, nil).Once()
+
+	cnt, code, err := suite.service.UpdateFranchises(ctx)
+	suite.Equal(1, cnt)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.franchiseMock.AssertExpectations(suite.T())
+}
+
+func (suite *franchiseTestSuite) TestGetWatchOrder() {
+	ctx := context.Background()
+
+	// Missing id.
+	_, code, err := suite.service.GetWatchOrder(ctx, service.GetWatchOrderRequest{})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	// Side story 3 released before sequel 2
+	// but after its parent 1. Prequel 4 has
+	// unknown start date.
+	anime := []*entityAnime.Anime{
+		{ID: 1, Title: "A", StartDate: entityAnime.Date{Year: 2010}},
+		{ID: 2, Title: "B", StartDate: entityAnime.Date{Year: 2013}},
+		{ID: 3, Title: "C", StartDate: entityAnime.Date{Year: 2011}},
+		{ID: 4, Title: "D"},
+	}
+
+	suite.franchiseMock.On("GetIDsByAnimeIDs", ctx, []int64{2}).Return(map[int64]int64{2: 1}, http.StatusOK, nil).Twice()
+	suite.franchiseMock.On("GetByID", ctx, int64(1)).Return(&entity.Franchise{ID: 1, AnimeIDs: []int64{1, 2, 3, 4}}, http.StatusOK, nil).Twice()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1, 2, 3, 4}).Return(anime, http.StatusOK, nil).Twice()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{1, 2, 3, 4}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel},
+		{AnimeID1: 2, AnimeID2: 1, Relation: entityAnime.RelationPrequel},
+		{AnimeID1: 3, AnimeID2: 1, Relation: entityAnime.RelationParentStory},
+		{AnimeID1: 1, AnimeID2: 4, Relation: entityAnime.RelationPrequel},
+		{AnimeID1: 1, AnimeID2: 5, Relation: entityAnime.RelationSequel},
+	}, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("Get", ctx, entityUserAnime.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: -1}).Return([]*entityUserAnime.UserAnime{
+		{AnimeID: 1, Status: entityUserAnime.StatusCompleted},
+	}, 1, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetWatchOrder(ctx, service.GetWatchOrderRequest{AnimeID: 2, Username: "RL404"})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal(int64(1), *data.FranchiseID)
+	suite.Equal(entity.WatchOrderChronological, data.Order)
+	suite.Equal([]int64{4, 1, 3, 2}, suite.getWatchOrderIDs(data))
+	suite.Equal(entityUserAnime.StatusCompleted, data.Anime[1].UserStatus)
+	suite.Empty(data.Anime[0].UserStatus)
+
+	data, code, err = suite.service.GetWatchOrder(ctx, service.GetWatchOrderRequest{AnimeID: 2, Order: "release"})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal([]int64{1, 3, 2, 4}, suite.getWatchOrderIDs(data))
+
+	suite.franchiseMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.userAnimeMock.AssertExpectations(suite.T())
+}
+
+func (suite *franchiseTestSuite) getWatchOrderIDs(data *service.WatchOrder) []int64 {
+	ids := make([]int64, len(data.Anime))
+	for i, a := range data.Anime {
+		ids[i] = a.ID
+	}
+	return ids
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	userAnimeEntity "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// WatchOrder is franchise watch order model.
+type WatchOrder struct {
+	FranchiseID *int64            `json:"franchise_id"`
+	Order       entity.WatchOrder `json:"order" swaggertype:"string"`
+	Anime       []watchOrderAnime `json:"anime"`
+}
+
+type watchOrderAnime struct {
+	ID         int64                  `json:"id"`
+	Title      string                 `json:"title"`
+	Picture    string                 `json:"picture"`
+	Type       animeEntity.Type       `json:"type" swaggertype:"string"`
+	Status     animeEntity.Status     `json:"status" swaggertype:"string"`
+	StartDate  Date                   `json:"start_date"`
+	UserStatus userAnimeEntity.Status `json:"user_status,omitempty" swaggertype:"string"`
+}
+
+// GetWatchOrderRequest is get watch order request model.
+// Either franchise id or anime id is required.
+type GetWatchOrderRequest struct {
+	FranchiseID int64             `validate:"required_without=AnimeID,gte=0"`
+	AnimeID     int64             `validate:"required_without=FranchiseID,gte=0"`
+	Order       entity.WatchOrder `validate:"oneof=RELEASE CHRONOLOGICAL" mod:"no_space,ucase,default=CHRONOLOGICAL"`
+	Username    string            `mod:"trim,lcase"`
+}
+
+// GetWatchOrder to get suggested watch order of a franchise.
+//
+// Release order follows anime start date. Chronological
+// order follows story relations (prequel before sequel,
+// parent story before side story, full story before
+// summary) with start date as the tiebreaker.
+// Anime not in any franchise is returned alone.
+func (s *service) GetWatchOrder(ctx context.Context, data GetWatchOrderRequest) (*WatchOrder, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	franchiseID := data.FranchiseID
+	if franchiseID == 0 {
+		franchiseIDs, code, err := s.franchise.GetIDsByAnimeIDs(ctx, []int64{data.AnimeID})
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+		franchiseID = franchiseIDs[data.AnimeID]
+	}
+
+	animeIDs := []int64{data.AnimeID}
+	if franchiseID > 0 {
+		franchise, code, err := s.franchise.GetByID(ctx, franchiseID)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+		animeIDs = franchise.AnimeIDs
+	}
+
+	anime, code, err := s.anime.GetByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(anime) == 0 {
+		return nil, http.StatusNotFound, stack.Wrap(ctx, errors.ErrAnimeNotFound)
+	}
+
+	switch data.Order {
+	case entity.WatchOrderRelease:
+		anime = s.sortAnimeByRelease(anime)
+	default:
+		anime, code, err = s.sortAnimeByStory(ctx, anime)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+	}
+
+	statusMap, code, err := s.getWatchOrderUserStatus(ctx, data.Username)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := WatchOrder{
+		Order: data.Order,
+		Anime: make([]watchOrderAnime, len(anime)),
+	}
+
+	if franchiseID > 0 {
+		res.FranchiseID = &franchiseID
+	}
+
+	for i, a := range anime {
+		res.Anime[i] = watchOrderAnime{
+			ID:      a.ID,
+			Title:   a.Title,
+			Picture: a.Picture,
+			Type:    a.Type,
+			Status:  a.Status,
+			StartDate: Date{
+				Year:  a.StartDate.Year,
+				Month: a.StartDate.Month,
+				Day:   a.StartDate.Day,
+			},
+			UserStatus: statusMap[a.ID],
+		}
+	}
+
+	return &res, http.StatusOK, nil
+}
+
+func (s *service) sortAnimeByRelease(anime []*animeEntity.Anime) []*animeEntity.Anime {
+	res := make([]*animeEntity.Anime, len(anime))
+	copy(res, anime)
+
+	sort.Slice(res, func(i, j int) bool {
+		return s.isAnimeEarlier(res[i], res[j])
+	})
+
+	return res
+}
+
+// sortAnimeByStory to topologically sort anime by
+// story relations. The earliest released anime is
+// picked first when several are ready, and also to
+// break relation cycles.
+func (s *service) sortAnimeByStory(ctx context.Context, anime []*animeEntity.Anime) ([]*animeEntity.Anime, int, error) {
+	animeMap := make(map[int64]*animeEntity.Anime)
+	ids := make([]int64, len(anime))
+	for i, a := range anime {
+		animeMap[a.ID] = a
+		ids[i] = a.ID
+	}
+
+	related, code, err := s.anime.GetRelatedByIDs(ctx, ids)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	edges := make(map[[2]int64]bool)
+	for _, r := range related {
+		if animeMap[r.AnimeID2] == nil {
+			continue
+		}
+
+		switch r.Relation {
+		case animeEntity.RelationSequel, animeEntity.RelationSideStory, animeEntity.RelationSummary:
+			edges[[2]int64{r.AnimeID1, r.AnimeID2}] = true
+		case animeEntity.RelationPrequel, animeEntity.RelationParentStory, animeEntity.RelationFullStory:
+			edges[[2]int64{r.AnimeID2, r.AnimeID1}] = true
+		}
+	}
+
+	inDegree := make(map[int64]int)
+	nexts := make(map[int64][]int64)
+	for e := range edges {
+		inDegree[e[1]]++
+		nexts[e[0]] = append(nexts[e[0]], e[1])
+	}
+
+	res := make([]*animeEntity.Anime, 0, len(anime))
+	done := make(map[int64]bool)
+
+	for len(res) < len(anime) {
+		var next, fallback *animeEntity.Anime
+		for _, a := range anime {
+			if done[a.ID] {
+				continue
+			}
+
+			if inDegree[a.ID] == 0 && (next == nil || s.isAnimeEarlier(a, next)) {
+				next = a
+			}
+
+			if fallback == nil || s.isAnimeEarlier(a, fallback) {
+				fallback = a
+			}
+		}
+
+		// Relation cycle.
+		if next == nil {
+			next = fallback
+		}
+
+		done[next.ID] = true
+		res = append(res, next)
+
+		for _, id := range nexts[next.ID] {
+			inDegree[id]--
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
+func (s *service) getWatchOrderUserStatus(ctx context.Context, username string) (map[int64]userAnimeEntity.Status, int, error) {
+	statusMap := make(map[int64]userAnimeEntity.Status)
+	if username == "" {
+		return statusMap, http.StatusOK, nil
+	}
+
+	userAnime, _, code, err := s.userAnime.Get(ctx, userAnimeEntity.GetUserAnimeRequest{
+		Username: username,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(userAnime) == 0 {
+		// Queue to parse.
+		if _, err := s.publisher.PublishParseUserAnime(ctx, username, "", false, publisherEntity.PriorityHigh); err != nil {
+			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+	}
+
+	for _, ua := range userAnime {
+		statusMap[ua.AnimeID] = ua.Status
+	}
+
+	return statusMap, http.StatusOK, nil
+}
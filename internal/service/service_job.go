@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Job is scheduled job run model.
+type Job struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Queued    int        `json:"queued"`
+	Error     string     `json:"error"`
+}
+
+// StartJob to save scheduled job start time.
+// Will return job history id.
+func (s *service) StartJob(ctx context.Context, name string) (int64, int, error) {
+	id, code, err := s.jobHistory.Create(ctx, entity.JobHistory{
+		Name:      name,
+		StartedAt: time.Now(),
+	})
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+	return id, http.StatusCreated, nil
+}
+
+// FinishJob to save scheduled job end time and result.
+func (s *service) FinishJob(ctx context.Context, id int64, queued int, jobErr error) (int, error) {
+	now := time.Now()
+	data := entity.JobHistory{
+		ID:      id,
+		EndedAt: &now,
+		Queued:  queued,
+	}
+
+	if jobErr != nil {
+		data.Error = jobErr.Error()
+	}
+
+	if code, err := s.jobHistory.Update(ctx, data); err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetJobsRequest is get job run list request model.
+type GetJobsRequest struct {
+	Name  string
+	Page  int `validate:"required,gte=1" mod:"default=1"`
+	Limit int `validate:"required,gte=-1" mod:"default=20"`
+}
+
+// GetJobs to get scheduled job run list.
+// Latest run first.
+func (s *service) GetJobs(ctx context.Context, data GetJobsRequest) ([]Job, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	jobs, total, code, err := s.jobHistory.Get(ctx, entity.GetRequest{
+		Name:  data.Name,
+		Page:  data.Page,
+		Limit: data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]Job, len(jobs))
+	for i, j := range jobs {
+		res[i] = Job{
+			ID:        j.ID,
+			Name:      j.Name,
+			StartedAt: j.StartedAt,
+			EndedAt:   j.EndedAt,
+			Queued:    j.Queued,
+			Error:     j.Error,
+		}
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
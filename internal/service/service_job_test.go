@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/job_history/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockJobHistory "github.com/rl404/akatsuki/tests/mocks/domain/job_history"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type jobTestSuite struct {
+	suite.Suite
+	jobHistoryMock *mockJobHistory.Repository
+	service        service.Service
+}
+
+func TestJob(t *testing.T) {
+	suite.Run(t, new(jobTestSuite))
+}
+
+func (suite *jobTestSuite) SetupTest() {
+	suite.jobHistoryMock = new(mockJobHistory.Repository)
+	suite.service = service.New(service.Deps{
+		JobHistory: suite.jobHistoryMock,
+	})
+}
+
+func (suite *jobTestSuite) TestStartJob() {
+	ctx := context.Background()
+
+	suite.jobHistoryMock.On("Create", ctx, mock.MatchedBy(func(data entity.JobHistory) bool {
+		return data.Name == "update" && !data.StartedAt.IsZero() && data.EndedAt == nil
+	})).Return(int64(1), http.StatusCreated, nil).Once()
+
+	id, code, err := suite.service.StartJob(ctx, "update")
+	suite.Equal(int64(1), id)
+	suite.Equal(http.StatusCreated, code)
+	suite.Nil(err)
+	suite.jobHistoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *jobTestSuite) TestFinishJob() {
+	ctx := context.Background()
+
+	suite.jobHistoryMock.On("Update", ctx, mock.MatchedBy(func(data entity.JobHistory) bool {
+		return data.ID == 1 && data.EndedAt != nil && data.Queued == 5 && data.Error == "dummy error"
+	})).Return(http.StatusOK, nil).Once()
+
+	code, err := suite.service.FinishJob(ctx, 1, 5, _errors.New("dummy error"))
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.jobHistoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *jobTestSuite) TestGetJobs() {
+	ctx := context.Background()
+
+	suite.jobHistoryMock.On("Get", ctx, entity.GetRequest{
+		Name:  "update",
+		Page:  1,
+		Limit: 20,
+	}).Return([]*entity.JobHistory{{ID: 2, Name: "update", Queued: 3}}, 1, http.StatusOK, nil).Once()
+
+	jobs, pagination, code, err := suite.service.GetJobs(ctx, service.GetJobsRequest{Name: "update"})
+	suite.Equal([]service.Job{{ID: 2, Name: "update", Queued: 3}}, jobs)
+	suite.Equal(&service.Pagination{Page: 1, Limit: 20, Total: 1}, pagination)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.jobHistoryMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	outboxEntity "github.com/rl404/akatsuki/internal/domain/outbox/entity"
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// RelayOutbox to publish pending outbox messages.
+func (s *service) RelayOutbox(ctx context.Context, limit int) (int, int, error) {
+	messages, code, err := s.outbox.ClaimPending(ctx, limit)
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	ids := make([]int64, 0, len(messages))
+	for _, msg := range messages {
+		if err = s.publishOutbox(ctx, *msg); err != nil {
+			break
+		}
+		ids = append(ids, msg.ID)
+	}
+
+	// Delete published messages even if some failed.
+	// The rest will be retried after their claim ends.
+	if code, errDel := s.outbox.Delete(ctx, ids); errDel != nil {
+		return len(ids), code, stack.Wrap(ctx, errDel)
+	}
+
+	if err != nil {
+		return len(ids), http.StatusInternalServerError, stack.Wrap(ctx, err)
+	}
+
+	return len(ids), http.StatusOK, nil
+}
+
+// RetryMessage to save failed message in outbox so
+// it will be republished by relay at its retry time.
+func (s *service) RetryMessage(ctx context.Context, data entity.Message) error {
+	d, err := json.Marshal(data)
+	if err != nil {
+		return stack.Wrap(ctx, err, errors.ErrInternalServer)
+	}
+
+	if _, err := s.outbox.Create(ctx, outboxEntity.Outbox{
+		Type:      outboxEntity.TypeRetry,
+		Message:   d,
+		PublishAt: data.RetryAt,
+	}); err != nil {
+		return stack.Wrap(ctx, err)
+	}
+
+	return nil
+}
+
+func (s *service) publishOutbox(ctx context.Context, msg outboxEntity.Outbox) error {
+	switch msg.Type {
+	case outboxEntity.TypeRetry:
+		var retry entity.Message
+		if err := json.Unmarshal(msg.Message, &retry); err != nil {
+			return stack.Wrap(ctx, err, errors.ErrInternalServer)
+		}
+		return s.publisher.PublishRetry(ctx, retry)
+	case outboxEntity.TypeParseUserAnime:
+		_, err := s.publisher.PublishParseUserAnime(ctx, msg.Username, msg.Status, false, entity.PriorityLow)
+		return err
+	default:
+		_, err := s.publisher.PublishParseAnime(ctx, msg.AnimeID, false, entity.PriorityLow)
+		return err
+	}
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	_errors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/outbox/entity"
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockOutbox "github.com/rl404/akatsuki/tests/mocks/domain/outbox"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type outboxTestSuite struct {
+	suite.Suite
+	outboxMock    *mockOutbox.Repository
+	publisherMock *mockPublisher.Repository
+}
+
+func TestOutbox(t *testing.T) {
+	suite.Run(t, new(outboxTestSuite))
+}
+
+func (suite *outboxTestSuite) SetupTest() {
+	suite.outboxMock = new(mockOutbox.Repository)
+	suite.publisherMock = new(mockPublisher.Repository)
+}
+
+func (suite *outboxTestSuite) TestRelayOutbox() {
+	ctx := context.Background()
+	errDummy := _errors.New("dummy error")
+
+	suite.outboxMock.On("ClaimPending", ctx, 10).Return([]*entity.Outbox{
+		{ID: 4, Type: entity.TypeRetry, Message: []byte(`{"type":"parse-anime","id":44,"priority":"high","attempt":2}`)},
+		{ID: 1, Type: entity.TypeParseAnime, AnimeID: 11},
+		{ID: 2, Type: entity.TypeParseUserAnime, Username: "user"},
+		{ID: 3, Type: entity.TypeParseAnime, AnimeID: 33},
+	}, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishRetry", ctx, entityPublisher.Message{Type: entityPublisher.TypeParseAnime, ID: 44, Priority: entityPublisher.PriorityHigh, Attempt: 2}).Return(nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(11), false, entityPublisher.PriorityLow).Return(true, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "user", "", false, entityPublisher.PriorityLow).Return(false, errDummy).Once()
+
+	// Only published messages are deleted.
+	suite.outboxMock.On("Delete", ctx, []int64{4, 1}).Return(http.StatusOK, nil).Once()
+
+	s := service.New(service.Deps{
+		Publisher: suite.publisherMock,
+		Outbox:    suite.outboxMock,
+	})
+	cnt, code, err := s.RelayOutbox(ctx, 10)
+	suite.Equal(2, cnt)
+	suite.Equal(http.StatusInternalServerError, code)
+	suite.ErrorIs(err, errDummy)
+
+	suite.outboxMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertNotCalled(suite.T(), "PublishParseAnime", ctx, int64(33), mock.Anything, mock.Anything)
+}
+
+func (suite *outboxTestSuite) TestRetryMessage() {
+	ctx := context.Background()
+	retryAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.outboxMock.On("Create", ctx, entity.Outbox{
+		Type:      entity.TypeRetry,
+		Message:   []byte(`{"type":"parse-anime","id":1,"username":"","status":"","forced":false,"priority":"high","trace":null,"attempt":1,"retry_at":"2024-01-01T00:00:00Z","error":"dummy error"}`),
+		PublishAt: retryAt,
+	}).Return(http.StatusCreated, nil).Once()
+
+	s := service.New(service.Deps{Outbox: suite.outboxMock})
+	suite.Nil(s.RetryMessage(ctx, entityPublisher.Message{
+		Type:     entityPublisher.TypeParseAnime,
+		ID:       1,
+		Priority: entityPublisher.PriorityHigh,
+		Attempt:  1,
+		RetryAt:  retryAt,
+		Error:    "dummy error",
+	}))
+
+	suite.outboxMock.AssertExpectations(suite.T())
+}
@@ -3,59 +3,56 @@ package service
 import (
 	"context"
 	"net/http"
+	"time"
 
+	"github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	"github.com/rl404/fairy/errors/stack"
 )
 
-// QueueOldReleasingAnime to queue old releasing anime data.
-func (s *service) QueueOldReleasingAnime(ctx context.Context, limit int) (int, int, error) {
+// QueueOldAnime to queue anime data which are due to refresh
+// in priority order. Anime which are still in-flight are not counted.
+func (s *service) QueueOldAnime(ctx context.Context, limit int) (int, int, error) {
 	var cnt int
 
-	ids, code, err := s.anime.GetOldReleasingIDs(ctx)
+	ids, code, err := s.anime.GetOldIDs(ctx, limit)
 	if err != nil {
 		return cnt, code, stack.Wrap(ctx, err)
 	}
 
-	for i := 0; i < len(ids) && cnt < limit; i, cnt = i+1, cnt+1 {
-		if err := s.publisher.PublishParseAnime(ctx, ids[i], false); err != nil {
+	for i := 0; i < len(ids) && cnt < limit; i++ {
+		ok, err := s.publisher.PublishParseAnime(ctx, ids[i], false, entity.PriorityLow)
+		if err != nil {
 			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
-	}
 
-	return cnt, http.StatusOK, nil
-}
-
-// QueueOldFinishedAnime to queue old finished anime data.
-func (s *service) QueueOldFinishedAnime(ctx context.Context, limit int) (int, int, error) {
-	var cnt int
-
-	ids, code, err := s.anime.GetOldFinishedIDs(ctx)
-	if err != nil {
-		return cnt, code, stack.Wrap(ctx, err)
-	}
-
-	for i := 0; i < len(ids) && cnt < limit; i, cnt = i+1, cnt+1 {
-		if err := s.publisher.PublishParseAnime(ctx, ids[i], false); err != nil {
-			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		if ok {
+			cnt++
 		}
 	}
 
 	return cnt, http.StatusOK, nil
 }
 
-// QueueOldNotYetAnime to queue old not yet released anime data.
-func (s *service) QueueOldNotYetAnime(ctx context.Context, limit int) (int, int, error) {
+// QueueAiredAnime to queue releasing anime which aired
+// within the window and not updated yet. Anime which are
+// still in-flight are not counted.
+func (s *service) QueueAiredAnime(ctx context.Context, window time.Duration, limit int) (int, int, error) {
 	var cnt int
 
-	ids, code, err := s.anime.GetOldFinishedIDs(ctx)
+	ids, code, err := s.anime.GetAiredIDs(ctx, time.Now().Add(-window))
 	if err != nil {
 		return cnt, code, stack.Wrap(ctx, err)
 	}
 
-	for i := 0; i < len(ids) && cnt < limit; i, cnt = i+1, cnt+1 {
-		if err := s.publisher.PublishParseAnime(ctx, ids[i], false); err != nil {
+	for i := 0; i < len(ids) && cnt < limit; i++ {
+		ok, err := s.publisher.PublishParseAnime(ctx, ids[i], false, entity.PriorityLow)
+		if err != nil {
 			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
+
+		if ok {
+			cnt++
+		}
 	}
 
 	return cnt, http.StatusOK, nil
@@ -97,11 +94,14 @@ func (s *service) QueueMissingAnime(ctx context.Context, limit int) (int, int, e
 			continue
 		}
 
-		if err := s.publisher.PublishParseAnime(ctx, id, false); err != nil {
+		ok, err := s.publisher.PublishParseAnime(ctx, id, false, entity.PriorityLow)
+		if err != nil {
 			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
 
-		cnt++
+		if ok {
+			cnt++
+		}
 	}
 
 	return cnt, http.StatusOK, nil
@@ -116,11 +116,66 @@ func (s *service) QueueOldUserAnime(ctx context.Context, limit int) (int, int, e
 		return cnt, code, stack.Wrap(ctx, err)
 	}
 
-	for i := 0; i < len(usernames) && cnt < limit; i, cnt = i+1, cnt+1 {
-		if err := s.publisher.PublishParseUserAnime(ctx, usernames[i], "", false); err != nil {
+	for i := 0; i < len(usernames) && cnt < limit; i++ {
+		ok, err := s.publisher.PublishParseUserAnime(ctx, usernames[i], "", false, entity.PriorityLow)
+		if err != nil {
 			return cnt, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
+
+		if ok {
+			cnt++
+		}
 	}
 
 	return cnt, http.StatusOK, nil
 }
+
+// QueueStats is queue stats model.
+type QueueStats struct {
+	// Published but not finished message count per type.
+	Depth      map[string]int `json:"depth"`
+	Suppressed map[string]int `json:"suppressed"`
+	Processed  []SyncStats    `json:"processed"`
+}
+
+// SyncStats is processed data stats model.
+type SyncStats struct {
+	Type    string `json:"type"`
+	Total   int    `json:"total"`
+	Attempt int    `json:"attempt"`
+	Failed  int    `json:"failed"`
+}
+
+// GetQueueStats to get queue stats.
+func (s *service) GetQueueStats(ctx context.Context) (*QueueStats, int, error) {
+	depth, code, err := s.inFlight.GetDepth(ctx)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	suppressed, code, err := s.inFlight.GetSuppressed(ctx)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	stats, code, err := s.syncStatus.GetStats(ctx)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	processed := make([]SyncStats, len(stats))
+	for i, st := range stats {
+		processed[i] = SyncStats{
+			Type:    string(st.Type),
+			Total:   st.Total,
+			Attempt: st.Attempt,
+			Failed:  st.Failed,
+		}
+	}
+
+	return &QueueStats{
+		Depth:      depth,
+		Suppressed: suppressed,
+		Processed:  processed,
+	}, http.StatusOK, nil
+}
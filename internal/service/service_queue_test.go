@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type queueTestSuite struct {
+	suite.Suite
+	animeMock     *mockAnime.Repository
+	publisherMock *mockPublisher.Repository
+	service       service.Service
+}
+
+func TestQueue(t *testing.T) {
+	suite.Run(t, new(queueTestSuite))
+}
+
+func (suite *queueTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.publisherMock = new(mockPublisher.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:     suite.animeMock,
+		Publisher: suite.publisherMock,
+	})
+}
+
+func (suite *queueTestSuite) TestQueueOldAnime() {
+	ctx := context.Background()
+
+	suite.animeMock.On("GetOldIDs", ctx, 3).Return([]int64{1, 2, 3}, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(1), false, entityPublisher.PriorityLow).Return(true, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(2), false, entityPublisher.PriorityLow).Return(false, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(3), false, entityPublisher.PriorityLow).Return(true, nil).Once()
+
+	// Anime 2 is still in-flight.
+	cnt, code, err := suite.service.QueueOldAnime(ctx, 3)
+	suite.Equal(2, cnt)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
+
+func (suite *queueTestSuite) TestQueueAiredAnime() {
+	ctx := context.Background()
+
+	suite.animeMock.On("GetAiredIDs", ctx, mock.Anything).Return([]int64{1, 2}, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(1), false, entityPublisher.PriorityLow).Return(false, nil).Once()
+	suite.publisherMock.On("PublishParseAnime", ctx, int64(2), false, entityPublisher.PriorityLow).Return(true, nil).Once()
+
+	// Anime 1 is still queued from previous run.
+	cnt, code, err := suite.service.QueueAiredAnime(ctx, time.Hour, 2)
+	suite.Equal(1, cnt)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	recommendationEntity "github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const (
+	// Minimum scoring users for anime to be compared.
+	recommendationMinUser = 3
+	// Damp similarity of anime with few shared users.
+	recommendationShrink = 5
+	// Content similarity weight when blending.
+	recommendationContentWeight = 0.3
+	// Max anime ids in one query.
+	recommendationChunk = 1000
+	// Users whose ratings are loaded at once.
+	recommendationUserChunk = 1000
+	// Max anime of each user to be paired. Pairs grow
+	// quadratically with user list size.
+	recommendationMaxUserAnime = 300
+)
+
+// Recommendation is recommendation model.
+type Recommendation struct {
+	AnimeID int64   `json:"anime_id"`
+	Title   string  `json:"title"`
+	Score   float64 `json:"score"`
+}
+
+// GetUserRecommendationsRequest is get user recommendations request model.
+type GetUserRecommendationsRequest struct {
+	Username string `validate:"required" mod:"trim,lcase"`
+	Limit    int    `validate:"required,gte=1,lte=100" mod:"default=20"`
+}
+
+// GetUserRecommendations to get anime recommendations
+// for user from precomputed anime similarities.
+//
+// Anime the user scored above their mean score pull
+// their similar anime up, anime scored below pull down.
+// Anime already in the list are excluded.
+func (s *service) GetUserRecommendations(ctx context.Context, data GetUserRecommendationsRequest) ([]Recommendation, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	userAnime, _, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
+		Username: data.Username,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(userAnime) == 0 {
+		// Queue to parse.
+		if _, err := s.publisher.PublishParseUserAnime(ctx, data.Username, "", false, publisherEntity.PriorityHigh); err != nil {
+			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+		return nil, http.StatusAccepted, nil
+	}
+
+	weights := s.getRecommendationWeights(userAnime)
+	if len(weights) == 0 {
+		return []Recommendation{}, http.StatusOK, nil
+	}
+
+	seedIDs := make([]int64, 0, len(weights))
+	for id := range weights {
+		seedIDs = append(seedIDs, id)
+	}
+
+	similarities, code, err := s.recommendation.GetSimilarities(ctx, seedIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	listMap := make(map[int64]bool)
+	for _, ua := range userAnime {
+		listMap[ua.AnimeID] = true
+	}
+
+	scores := make(map[int64]float64)
+	for _, sim := range similarities {
+		if !listMap[sim.AnimeID2] {
+			scores[sim.AnimeID2] += sim.Score * weights[sim.AnimeID1]
+		}
+	}
+
+	res := make([]Recommendation, 0, len(scores))
+	for id, score := range scores {
+		if score > 0 {
+			res = append(res, Recommendation{
+				AnimeID: id,
+				Score:   s.round(score),
+			})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].AnimeID < res[j].AnimeID
+	})
+
+	if len(res) > data.Limit {
+		res = res[:data.Limit]
+	}
+
+	if len(res) == 0 {
+		return res, http.StatusOK, nil
+	}
+
+	animeIDs := make([]int64, len(res))
+	for i, r := range res {
+		animeIDs[i] = r.AnimeID
+	}
+
+	anime, code, err := s.anime.GetByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	titleMap := make(map[int64]string)
+	for _, a := range anime {
+		titleMap[a.ID] = a.Title
+	}
+
+	for i := range res {
+		res[i].Title = titleMap[res[i].AnimeID]
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// getRecommendationWeights to get how much each anime in
+// user list affects the recommendation.
+// Unscored list falls back to all watched anime.
+func (s *service) getRecommendationWeights(userAnime []*entity.UserAnime) map[int64]float64 {
+	var sum, cnt int
+	for _, ua := range userAnime {
+		if ua.Score > 0 {
+			sum += ua.Score
+			cnt++
+		}
+	}
+
+	weights := make(map[int64]float64)
+
+	if cnt > 0 {
+		mean := float64(sum) / float64(cnt)
+		for _, ua := range userAnime {
+			if ua.Score > 0 && float64(ua.Score) != mean {
+				weights[ua.AnimeID] = float64(ua.Score) - mean
+			}
+		}
+	}
+
+	if len(weights) > 0 {
+		return weights
+	}
+
+	for _, ua := range userAnime {
+		if ua.Status != entity.StatusPlanned && ua.Status != entity.StatusDropped {
+			weights[ua.AnimeID] = 1
+		}
+	}
+
+	return weights
+}
+
+type recommendationPair struct {
+	dot   float64
+	count int
+}
+
+// UpdateRecommendations to recompute anime similarities.
+//
+// User score similarity is adjusted cosine between anime
+// scored by the same users. Content similarity is jaccard
+// of anime genres and studios. Only the top limit similar
+// anime of each anime are saved.
+//
+// Ratings are loaded in chunks of users and each user only
+// contributes their strongest opinions. After each chunk,
+// pairs which can't reach the min shared users anymore are
+// removed so only pairs which may still be saved are kept.
+// Will return total saved similarities.
+func (s *service) UpdateRecommendations(ctx context.Context, limit int) (int, int, error) {
+	// Count scoring users of each anime.
+	userCnt, code, err := s.userAnime.GetRatingCounts(ctx)
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	// Scoring users of each anime not loaded yet.
+	remaining := make(map[int64]int, len(userCnt))
+	for id, cnt := range userCnt {
+		remaining[id] = cnt
+	}
+
+	norms := make(map[int64]float64)
+	pairs := make(map[[2]int64]*recommendationPair)
+
+	var lastUsername string
+	for {
+		ratings, code, err := s.userAnime.GetRatings(ctx, lastUsername, recommendationUserChunk)
+		if err != nil {
+			return 0, code, stack.Wrap(ctx, err)
+		}
+
+		if len(ratings) == 0 {
+			break
+		}
+
+		// Ratings are ordered by username.
+		for i := 0; i < len(ratings); {
+			j := i
+			for j < len(ratings) && ratings[j].Username == ratings[i].Username {
+				j++
+			}
+
+			s.addRecommendationPairs(ratings[i:j], userCnt, norms, pairs)
+			i = j
+		}
+
+		for _, r := range ratings {
+			remaining[r.AnimeID]--
+		}
+
+		// Remove pairs with too few shared users even if
+		// all remaining users score both anime. Ratings
+		// added while running may make remaining negative.
+		for key, p := range pairs {
+			if p.count+max(min(remaining[key[0]], remaining[key[1]]), 0) < recommendationMinUser {
+				delete(pairs, key)
+			}
+		}
+
+		lastUsername = ratings[len(ratings)-1].Username
+	}
+
+	animeIDMap := make(map[int64]bool)
+	for key := range pairs {
+		animeIDMap[key[0]] = true
+		animeIDMap[key[1]] = true
+	}
+
+	features, code, err := s.getRecommendationFeatures(ctx, animeIDMap)
+	if err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	neighbors := make(map[int64][]recommendationEntity.Similarity)
+	for key, p := range pairs {
+		if norms[key[0]] == 0 || norms[key[1]] == 0 {
+			continue
+		}
+
+		cf := p.dot / math.Sqrt(norms[key[0]]*norms[key[1]])
+		cf *= float64(p.count) / float64(p.count+recommendationShrink)
+
+		score := (1-recommendationContentWeight)*cf + recommendationContentWeight*s.jaccard(features[key[0]], features[key[1]])
+		if score <= 0 {
+			continue
+		}
+
+		neighbors[key[0]] = append(neighbors[key[0]], recommendationEntity.Similarity{AnimeID1: key[0], AnimeID2: key[1], Score: score})
+		neighbors[key[1]] = append(neighbors[key[1]], recommendationEntity.Similarity{AnimeID1: key[1], AnimeID2: key[0], Score: score})
+	}
+
+	var similarities []recommendationEntity.Similarity
+	for _, n := range neighbors {
+		sort.Slice(n, func(i, j int) bool {
+			if n[i].Score != n[j].Score {
+				return n[i].Score > n[j].Score
+			}
+			return n[i].AnimeID2 < n[j].AnimeID2
+		})
+
+		if len(n) > limit {
+			n = n[:limit]
+		}
+
+		similarities = append(similarities, n...)
+	}
+
+	if code, err := s.recommendation.ReplaceSimilarities(ctx, similarities); err != nil {
+		return 0, code, stack.Wrap(ctx, err)
+	}
+
+	return len(similarities), http.StatusOK, nil
+}
+
+// addRecommendationPairs to add a user scores to anime
+// norms and pairs. Scores are centered with the user mean
+// score and only the anime furthest from the mean are
+// paired.
+func (s *service) addRecommendationPairs(ratings []*entity.Rating, userCnt map[int64]int, norms map[int64]float64, pairs map[[2]int64]*recommendationPair) {
+	var sum float64
+	for _, r := range ratings {
+		sum += float64(r.Score)
+	}
+	mean := sum / float64(len(ratings))
+
+	type dev struct {
+		animeID int64
+		value   float64
+	}
+
+	devs := make([]dev, 0, len(ratings))
+	for _, r := range ratings {
+		if userCnt[r.AnimeID] >= recommendationMinUser {
+			devs = append(devs, dev{animeID: r.AnimeID, value: float64(r.Score) - mean})
+		}
+	}
+
+	if len(devs) > recommendationMaxUserAnime {
+		sort.Slice(devs, func(i, j int) bool {
+			if math.Abs(devs[i].value) != math.Abs(devs[j].value) {
+				return math.Abs(devs[i].value) > math.Abs(devs[j].value)
+			}
+			return devs[i].animeID < devs[j].animeID
+		})
+		devs = devs[:recommendationMaxUserAnime]
+	}
+
+	for i := range devs {
+		norms[devs[i].animeID] += devs[i].value * devs[i].value
+		for j := i + 1; j < len(devs); j++ {
+			key := s.getRecommendationPairKey(devs[i].animeID, devs[j].animeID)
+			if pairs[key] == nil {
+				pairs[key] = &recommendationPair{}
+			}
+			pairs[key].dot += devs[i].value * devs[j].value
+			pairs[key].count++
+		}
+	}
+}
+
+func (s *service) getRecommendationPairKey(id1, id2 int64) [2]int64 {
+	if id1 > id2 {
+		id1, id2 = id2, id1
+	}
+	return [2]int64{id1, id2}
+}
+
+// getRecommendationFeatures to get genre and studio
+// ids of the anime. Studio ids are negated so they
+// don't collide with genre ids.
+func (s *service) getRecommendationFeatures(ctx context.Context, animeIDMap map[int64]bool) (map[int64]map[int64]bool, int, error) {
+	animeIDs := make([]int64, 0, len(animeIDMap))
+	for id := range animeIDMap {
+		animeIDs = append(animeIDs, id)
+	}
+
+	features := make(map[int64]map[int64]bool)
+	add := func(animeID, featureID int64) {
+		if features[animeID] == nil {
+			features[animeID] = make(map[int64]bool)
+		}
+		features[animeID][featureID] = true
+	}
+
+	for i := 0; i < len(animeIDs); i += recommendationChunk {
+		ids := animeIDs[i:min(i+recommendationChunk, len(animeIDs))]
+
+		genres, code, err := s.anime.GetGenresByIDs(ctx, ids)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+
+		for _, g := range genres {
+			add(g.AnimeID, g.GenreID)
+		}
+
+		studios, code, err := s.anime.GetStudiosByIDs(ctx, ids)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+
+		for _, st := range studios {
+			add(st.AnimeID, -st.StudioID)
+		}
+	}
+
+	return features, http.StatusOK, nil
+}
+
+func (s *service) jaccard(a, b map[int64]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	var intersect int
+	for k := range a {
+		if b[k] {
+			intersect++
+		}
+	}
+
+	return float64(intersect) / float64(len(a)+len(b)-intersect)
+}
@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	entityAnime "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	entityRecommendation "github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	mockRecommendation "github.com/rl404/akatsuki/tests/mocks/domain/recommendation"
+	mockUserAnime "github.com/rl404/akatsuki/tests/mocks/domain/user_anime"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type recommendationTestSuite struct {
+	suite.Suite
+	animeMock          *mockAnime.Repository
+	userAnimeMock      *mockUserAnime.Repository
+	publisherMock      *mockPublisher.Repository
+	recommendationMock *mockRecommendation.Repository
+	service            service.Service
+}
+
+func TestRecommendation(t *testing.T) {
+	suite.Run(t, new(recommendationTestSuite))
+}
+
+func (suite *recommendationTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.userAnimeMock = new(mockUserAnime.Repository)
+	suite.publisherMock = new(mockPublisher.Repository)
+	suite.recommendationMock = new(mockRecommendation.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:          suite.animeMock,
+		UserAnime:      suite.userAnimeMock,
+		Publisher:      suite.publisherMock,
+		Recommendation: suite.recommendationMock,
+	})
+}
+
+func (suite *recommendationTestSuite) TestGetUserRecommendations() {
+	ctx := context.Background()
+
+	// Not parsed user.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "new", Page: 1, Limit: -1}).Return(nil, 0, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "new", "", false, entityPublisher.PriorityHigh).Return(true, nil).Once()
+
+	_, code, err := suite.service.GetUserRecommendations(ctx, service.GetUserRecommendationsRequest{Username: "new"})
+	suite.Equal(http.StatusAccepted, code)
+	suite.Nil(err)
+
+	// Anime scored below user mean pull their similar anime down.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: -1}).Return([]*entity.UserAnime{
+		{AnimeID: 1, Score: 9},
+		{AnimeID: 4, Score: 5},
+	}, 2, http.StatusOK, nil).Once()
+	suite.recommendationMock.On("GetSimilarities", ctx, mock.Anything).Return([]*entityRecommendation.Similarity{
+		{AnimeID1: 1, AnimeID2: 2, Score: 0.8},
+		{AnimeID1: 1, AnimeID2: 3, Score: 0.5},
+		{AnimeID1: 1, AnimeID2: 4, Score: 0.4},
+		{AnimeID1: 4, AnimeID2: 3, Score: 0.5},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{2}).Return([]*entityAnime.Anime{{ID: 2, Title: "B"}}, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetUserRecommendations(ctx, service.GetUserRecommendationsRequest{Username: "rl404"})
+	suite.Equal([]service.Recommendation{{AnimeID: 2, Title: "B", Score: 1.6}}, data)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+	suite.recommendationMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+}
+
+func (suite *recommendationTestSuite) TestUpdateRecommendations() {
+	ctx := context.Background()
+
+	suite.userAnimeMock.On("GetRatingCounts", ctx).Return(map[int64]int{1: 3, 2: 3, 3: 3, 4: 1}, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("GetRatings", ctx, "", 1000).Return([]*entity.Rating{
+		{Username: "a", AnimeID: 1, Score: 10},
+		{Username: "a", AnimeID: 2, Score: 9},
+		{Username: "a", AnimeID: 3, Score: 4},
+		{Username: "b", AnimeID: 1, Score: 8},
+		{Username: "b", AnimeID: 2, Score: 8},
+		{Username: "b", AnimeID: 3, Score: 2},
+		{Username: "c", AnimeID: 1, Score: 6},
+		{Username: "c", AnimeID: 2, Score: 7},
+		{Username: "c", AnimeID: 3, Score: 8},
+		{Username: "c", AnimeID: 4, Score: 8},
+	}, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("GetRatings", ctx, "c", 1000).Return([]*entity.Rating{}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetGenresByIDs", ctx, mock.Anything).Return([]*entityAnime.AnimeGenre{
+		{AnimeID: 1, GenreID: 1},
+		{AnimeID: 2, GenreID: 1},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetStudiosByIDs", ctx, mock.Anything).Return(nil, http.StatusOK, nil).Once()
+	suite.recommendationMock.On("ReplaceSimilarities", ctx, mock.MatchedBy(func(data []entityRecommendation.Similarity) bool {
+		return len(data) == 2 &&
+			data[0].AnimeID1+data[1].AnimeID1 == 3 &&
+			data[0].AnimeID2 == data[1].AnimeID1 &&
+			data[0].Score == data[1].Score &&
+			data[0].Score > 0.3
+	})).Return(http.StatusOK, nil).Once()
+
+	cnt, code, err := suite.service.UpdateRecommendations(ctx, 10)
+	suite.Equal(2, cnt)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.recommendationMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	userAnimeEntity "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// Order of days in schedule.
+var scheduleDays = []entity.Day{
+	entity.DayMonday,
+	entity.DayTuesday,
+	entity.DayWednesday,
+	entity.DayThursday,
+	entity.DayFriday,
+	entity.DaySaturday,
+	entity.DaySunday,
+	entity.DayOther,
+}
+
+// Schedule is weekly broadcast schedule model.
+type Schedule struct {
+	Timezone string        `json:"timezone"`
+	Days     []ScheduleDay `json:"days"`
+}
+
+// ScheduleDay is broadcast schedule of a day.
+type ScheduleDay struct {
+	Day   entity.Day      `json:"day" swaggertype:"string"`
+	Anime []scheduleAnime `json:"anime"`
+}
+
+type scheduleAnime struct {
+	ID      int64       `json:"id"`
+	Title   string      `json:"title"`
+	Picture string      `json:"picture"`
+	Type    entity.Type `json:"type" swaggertype:"string"`
+	Time    string      `json:"time"`
+	Episode int         `json:"episode"`
+	Mean    float64     `json:"mean"`
+	Member  int         `json:"member"`
+}
+
+// GetScheduleRequest is get schedule request model.
+type GetScheduleRequest struct {
+	Timezone string `mod:"trim,default=Asia/Tokyo"`
+	Username string `mod:"trim,lcase"`
+}
+
+// GetSchedule to get weekly broadcast schedule of
+// releasing anime.
+//
+// Broadcast day and time are converted from JST to
+// the timezone, so the day may roll over. Anime without
+// known broadcast day are grouped in OTHER. If username
+// is set, only anime in user's watching list are shown.
+func (s *service) GetSchedule(ctx context.Context, data GetScheduleRequest) (*Schedule, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	loc, err := time.LoadLocation(data.Timezone)
+	if err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err, errors.ErrInvalidTimezone)
+	}
+
+	anime, code, err := s.getScheduleAnime(ctx, data.Username)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if anime == nil {
+		return nil, http.StatusAccepted, nil
+	}
+
+	dayMap := make(map[entity.Day][]scheduleAnime)
+	now := time.Now()
+
+	for _, a := range anime {
+		day, broadcastTime := entity.DayOther, ""
+		if t, ok := a.Broadcast.SlotInWeek(now); ok {
+			t = t.In(loc)
+			day, broadcastTime = entity.DayFromWeekday(t.Weekday()), t.Format("15:04")
+		} else if _, ok := a.Broadcast.Day.Weekday(); ok {
+			// Unknown time, keep the day in JST.
+			day = a.Broadcast.Day
+		}
+
+		dayMap[day] = append(dayMap[day], scheduleAnime{
+			ID:      a.ID,
+			Title:   a.Title,
+			Picture: a.Picture,
+			Type:    a.Type,
+			Time:    broadcastTime,
+			Episode: a.Episode.Count,
+			Mean:    a.Mean,
+			Member:  a.Member,
+		})
+	}
+
+	res := Schedule{Timezone: loc.String()}
+	for _, day := range scheduleDays {
+		if day == entity.DayOther && len(dayMap[day]) == 0 {
+			continue
+		}
+
+		dayAnime := dayMap[day]
+		if dayAnime == nil {
+			dayAnime = []scheduleAnime{}
+		}
+
+		sort.Slice(dayAnime, func(i, j int) bool {
+			if dayAnime[i].Time != dayAnime[j].Time {
+				// Unknown time last.
+				if dayAnime[i].Time == "" || dayAnime[j].Time == "" {
+					return dayAnime[j].Time == ""
+				}
+				return dayAnime[i].Time < dayAnime[j].Time
+			}
+			return dayAnime[i].Member > dayAnime[j].Member
+		})
+
+		res.Days = append(res.Days, ScheduleDay{
+			Day:   day,
+			Anime: dayAnime,
+		})
+	}
+
+	return &res, http.StatusOK, nil
+}
+
+// getScheduleAnime to get releasing anime. Will return
+// nil if the user list is not parsed yet.
+func (s *service) getScheduleAnime(ctx context.Context, username string) ([]*entity.Anime, int, error) {
+	if username == "" {
+		anime, _, code, err := s.anime.Get(ctx, entity.GetRequest{
+			Status: entity.StatusReleasing,
+			Sort:   "-" + entity.SortMember,
+			Page:   1,
+			Limit:  -1,
+		})
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+		return anime, http.StatusOK, nil
+	}
+
+	userAnime, _, code, err := s.userAnime.Get(ctx, userAnimeEntity.GetUserAnimeRequest{
+		Username: username,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(userAnime) == 0 {
+		// Queue to parse.
+		if _, err := s.publisher.PublishParseUserAnime(ctx, username, "", false, publisherEntity.PriorityHigh); err != nil {
+			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+		return nil, http.StatusAccepted, nil
+	}
+
+	var ids []int64
+	for _, ua := range userAnime {
+		if ua.Status == userAnimeEntity.StatusWatching {
+			ids = append(ids, ua.AnimeID)
+		}
+	}
+
+	res := []*entity.Anime{}
+	if len(ids) == 0 {
+		return res, http.StatusOK, nil
+	}
+
+	anime, code, err := s.anime.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	for _, a := range anime {
+		if a.Status == entity.StatusReleasing {
+			res = append(res, a)
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
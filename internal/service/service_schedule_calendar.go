@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const (
+	// Used when anime has no episode duration.
+	calendarDefaultDuration = 30 * time.Minute
+	// Max line length in octets.
+	calendarLineLimit  = 75
+	calendarTimeFormat = "20060102T150405Z"
+)
+
+// GetScheduleCalendarRequest is get schedule calendar request model.
+type GetScheduleCalendarRequest struct {
+	Username string `mod:"trim,lcase"`
+}
+
+// GetScheduleCalendar to get broadcast schedule of
+// releasing anime as iCalendar (RFC 5545).
+//
+// Each anime is a weekly recurring event starting from
+// its first broadcast on or after start date. The event
+// ends at end date or after the last episode, whichever
+// comes first. Anime without known start date start
+// from this week and only end at end date, or don't
+// repeat if end date is unknown. Anime without known
+// broadcast day and time are skipped. If username is set, only anime in
+// user's watching list are included.
+func (s *service) GetScheduleCalendar(ctx context.Context, data GetScheduleCalendarRequest) ([]byte, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	anime, code, err := s.getScheduleAnime(ctx, data.Username)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if anime == nil {
+		return nil, http.StatusAccepted, nil
+	}
+
+	name := "Anime Schedule"
+	if data.Username != "" {
+		name = data.Username + "'s Anime Schedule"
+	}
+
+	now := time.Now()
+
+	var b strings.Builder
+	s.writeCalendarLine(&b, "BEGIN:VCALENDAR")
+	s.writeCalendarLine(&b, "VERSION:2.0")
+	s.writeCalendarLine(&b, "PRODID:-//akatsuki//schedule//EN")
+	s.writeCalendarLine(&b, "CALSCALE:GREGORIAN")
+	s.writeCalendarLine(&b, "METHOD:PUBLISH")
+	s.writeCalendarLine(&b, "X-WR-CALNAME:"+s.escapeCalendarText(name))
+
+	for _, a := range anime {
+		start, ok := s.getFirstBroadcastTime(a, now)
+		if !ok {
+			continue
+		}
+
+		duration := time.Duration(a.Episode.Duration) * time.Second
+		if duration <= 0 {
+			duration = calendarDefaultDuration
+		}
+
+		s.writeCalendarLine(&b, "BEGIN:VEVENT")
+		s.writeCalendarLine(&b, fmt.Sprintf("UID:anime-%d@akatsuki", a.ID))
+		s.writeCalendarLine(&b, "DTSTAMP:"+now.UTC().Format(calendarTimeFormat))
+		s.writeCalendarLine(&b, "DTSTART:"+start.UTC().Format(calendarTimeFormat))
+		s.writeCalendarLine(&b, "DTEND:"+start.Add(duration).UTC().Format(calendarTimeFormat))
+		if rule := s.getCalendarRule(a, start); rule != "" {
+			s.writeCalendarLine(&b, "RRULE:"+rule)
+		}
+		s.writeCalendarLine(&b, "SUMMARY:"+s.escapeCalendarText(a.Title))
+		s.writeCalendarLine(&b, fmt.Sprintf("URL:https://myanimelist.net/anime/%d", a.ID))
+		s.writeCalendarLine(&b, "END:VEVENT")
+	}
+
+	s.writeCalendarLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String()), http.StatusOK, nil
+}
+
+// getFirstBroadcastTime to get the first broadcast on or
+// after anime start date. Unknown start date uses the
+// broadcast of this week.
+func (s *service) getFirstBroadcastTime(a *entity.Anime, now time.Time) (time.Time, bool) {
+	if a.StartDate.Year == 0 {
+		return a.Broadcast.SlotInWeek(now)
+	}
+
+	startDate := time.Date(a.StartDate.Year, time.Month(max(a.StartDate.Month, 1)), max(a.StartDate.Day, 1), 0, 0, 0, 0, entity.JST)
+
+	t, ok := a.Broadcast.SlotInWeek(startDate)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if t.Before(startDate) {
+		t = t.AddDate(0, 0, 7)
+	}
+
+	return t, true
+}
+
+// getCalendarRule to get weekly recurrence rule ended
+// by episode count or end date. Will return empty
+// string if the event should not repeat.
+func (s *service) getCalendarRule(a *entity.Anime, start time.Time) string {
+	rule := "FREQ=WEEKLY"
+
+	var until time.Time
+	if a.EndDate.Year > 0 && a.EndDate.Month > 0 && a.EndDate.Day > 0 {
+		until = time.Date(a.EndDate.Year, time.Month(a.EndDate.Month), a.EndDate.Day, 23, 59, 59, 0, entity.JST)
+	}
+
+	if a.StartDate.Year == 0 {
+		// Aired episode count is unknown, counting from
+		// this week will go past the last episode.
+		if until.IsZero() {
+			return ""
+		}
+		return rule + ";UNTIL=" + until.UTC().Format(calendarTimeFormat)
+	}
+
+	if a.Episode.Count > 0 {
+		last := start.AddDate(0, 0, 7*(a.Episode.Count-1))
+		if until.IsZero() || !last.After(until) {
+			return fmt.Sprintf("%s;COUNT=%d", rule, a.Episode.Count)
+		}
+	}
+
+	if !until.IsZero() {
+		return rule + ";UNTIL=" + until.UTC().Format(calendarTimeFormat)
+	}
+
+	return rule
+}
+
+func (s *service) escapeCalendarText(str string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(str)
+}
+
+// writeCalendarLine to write content line with CRLF.
+// Long line is folded without splitting a character.
+func (s *service) writeCalendarLine(b *strings.Builder, line string) {
+	limit := calendarLineLimit
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+
+		// Leading space is counted.
+		limit = calendarLineLimit - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	entityUserAnime "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	mockUserAnime "github.com/rl404/akatsuki/tests/mocks/domain/user_anime"
+	"github.com/stretchr/testify/suite"
+)
+
+type scheduleTestSuite struct {
+	suite.Suite
+	animeMock     *mockAnime.Repository
+	userAnimeMock *mockUserAnime.Repository
+	publisherMock *mockPublisher.Repository
+	service       service.Service
+}
+
+func TestSchedule(t *testing.T) {
+	suite.Run(t, new(scheduleTestSuite))
+}
+
+func (suite *scheduleTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.userAnimeMock = new(mockUserAnime.Repository)
+	suite.publisherMock = new(mockPublisher.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:     suite.animeMock,
+		UserAnime: suite.userAnimeMock,
+		Publisher: suite.publisherMock,
+	})
+}
+
+func (suite *scheduleTestSuite) TestGetSchedule() {
+	ctx := context.Background()
+
+	// Invalid timezone.
+	_, code, err := suite.service.GetSchedule(ctx, service.GetScheduleRequest{Timezone: "Mars/Olympus"})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	suite.animeMock.On("Get", ctx, entity.GetRequest{Status: entity.StatusReleasing, Sort: "-MEMBER", Page: 1, Limit: -1}).Return([]*entity.Anime{
+		{ID: 1, Member: 30, Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "01:30"}},
+		{ID: 2, Member: 20, Broadcast: entity.Broadcast{Day: entity.DaySunday, Time: "23:00"}},
+		{ID: 3, Member: 10, Broadcast: entity.Broadcast{Day: entity.DayMonday}},
+		{ID: 4, Member: 5, Broadcast: entity.Broadcast{Day: entity.DayOther}},
+	}, 4, http.StatusOK, nil).Twice()
+
+	// Default JST.
+	data, code, err := suite.service.GetSchedule(ctx, service.GetScheduleRequest{})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal("Asia/Tokyo", data.Timezone)
+	suite.Len(data.Days, 8)
+	suite.Equal(entity.DayMonday, data.Days[0].Day)
+	suite.Len(data.Days[0].Anime, 2)
+	suite.Equal(int64(1), data.Days[0].Anime[0].ID)
+	suite.Equal("01:30", data.Days[0].Anime[0].Time)
+	suite.Equal(int64(3), data.Days[0].Anime[1].ID)
+	suite.Equal("", data.Days[0].Anime[1].Time)
+	suite.Equal(entity.DaySunday, data.Days[6].Day)
+	suite.Equal(int64(2), data.Days[6].Anime[0].ID)
+	suite.Equal(entity.DayOther, data.Days[7].Day)
+	suite.Equal(int64(4), data.Days[7].Anime[0].ID)
+
+	// Day rollover.
+	data, code, err = suite.service.GetSchedule(ctx, service.GetScheduleRequest{Timezone: "UTC"})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal(entity.DayMonday, data.Days[0].Day)
+	suite.Len(data.Days[0].Anime, 1)
+	suite.Equal(int64(3), data.Days[0].Anime[0].ID)
+	suite.Equal(entity.DaySunday, data.Days[6].Day)
+	suite.Len(data.Days[6].Anime, 2)
+	suite.Equal(int64(2), data.Days[6].Anime[0].ID)
+	suite.Equal("14:00", data.Days[6].Anime[0].Time)
+	suite.Equal(int64(1), data.Days[6].Anime[1].ID)
+	suite.Equal("16:30", data.Days[6].Anime[1].Time)
+
+	// New user.
+	suite.userAnimeMock.On("Get", ctx, entityUserAnime.GetUserAnimeRequest{Username: "new", Page: 1, Limit: -1}).Return(nil, 0, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "new", "", false, entityPublisher.PriorityHigh).Return(true, nil).Once()
+
+	_, code, err = suite.service.GetSchedule(ctx, service.GetScheduleRequest{Username: "new"})
+	suite.Equal(http.StatusAccepted, code)
+	suite.Nil(err)
+
+	// Watching list.
+	suite.userAnimeMock.On("Get", ctx, entityUserAnime.GetUserAnimeRequest{Username: "user", Page: 1, Limit: -1}).Return([]*entityUserAnime.UserAnime{
+		{AnimeID: 1, Status: entityUserAnime.StatusWatching},
+		{AnimeID: 2, Status: entityUserAnime.StatusWatching},
+		{AnimeID: 5, Status: entityUserAnime.StatusPlanned},
+	}, 3, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1, 2}).Return([]*entity.Anime{
+		{ID: 1, Status: entity.StatusReleasing, Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "01:30"}},
+		{ID: 2, Status: entity.StatusFinished, Broadcast: entity.Broadcast{Day: entity.DaySunday, Time: "23:00"}},
+	}, http.StatusOK, nil).Once()
+
+	data, code, err = suite.service.GetSchedule(ctx, service.GetScheduleRequest{Username: "User"})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Len(data.Days, 7)
+	suite.Len(data.Days[0].Anime, 1)
+	suite.Equal(int64(1), data.Days[0].Anime[0].ID)
+	suite.Empty(data.Days[6].Anime)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
+
+func (suite *scheduleTestSuite) TestGetScheduleCalendar() {
+	ctx := context.Background()
+
+	suite.animeMock.On("Get", ctx, entity.GetRequest{Status: entity.StatusReleasing, Sort: "-MEMBER", Page: 1, Limit: -1}).Return([]*entity.Anime{
+		{
+			ID:        1,
+			Title:     "A",
+			StartDate: entity.Date{Year: 2024, Month: 1, Day: 1},
+			Episode:   entity.Episode{Count: 12, Duration: 1440},
+			Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "01:30"},
+		},
+		{
+			ID:        2,
+			Title:     "B, C; D",
+			StartDate: entity.Date{Year: 2024, Month: 1, Day: 3},
+			EndDate:   entity.Date{Year: 2024, Month: 2, Day: 1},
+			Episode:   entity.Episode{Count: 12},
+			Broadcast: entity.Broadcast{Day: entity.DaySunday, Time: "23:00"},
+		},
+		{
+			ID:        3,
+			Title:     "E",
+			Broadcast: entity.Broadcast{Day: entity.DayMonday},
+		},
+		{
+			ID:        4,
+			Title:     strings.Repeat("F", 100),
+			StartDate: entity.Date{Year: 2024, Month: 1},
+			Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "12:00"},
+		},
+		{
+			ID:        5,
+			Title:     "G",
+			EndDate:   entity.Date{Year: 2024, Month: 3, Day: 1},
+			Episode:   entity.Episode{Count: 12},
+			Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "12:00"},
+		},
+		{
+			ID:        6,
+			Title:     "H",
+			Episode:   entity.Episode{Count: 12},
+			Broadcast: entity.Broadcast{Day: entity.DayMonday, Time: "12:00"},
+		},
+	}, 6, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetScheduleCalendar(ctx, service.GetScheduleCalendarRequest{})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	calendar := string(data)
+	suite.True(strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	suite.True(strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+	suite.Equal(5, strings.Count(calendar, "BEGIN:VEVENT"))
+
+	suite.Contains(calendar, "UID:anime-1@akatsuki\r\n")
+	suite.Contains(calendar, "DTSTART:20231231T163000Z\r\nDTEND:20231231T165400Z\r\nRRULE:FREQ=WEEKLY;COUNT=12\r\n")
+
+	// Ended by end date, escaped title.
+	suite.Contains(calendar, "DTSTART:20240107T140000Z\r\nDTEND:20240107T143000Z\r\nRRULE:FREQ=WEEKLY;UNTIL=20240201T145959Z\r\nSUMMARY:B\\, C\\; D\r\n")
+
+	// No broadcast time.
+	suite.NotContains(calendar, "anime-3@")
+
+	// No end, folded title.
+	suite.Contains(calendar, "DTSTART:20240101T030000Z\r\nDTEND:20240101T033000Z\r\nRRULE:FREQ=WEEKLY\r\n")
+	suite.Contains(calendar, "SUMMARY:"+strings.Repeat("F", 67)+"\r\n "+strings.Repeat("F", 33)+"\r\n")
+
+	// No start, ended by end date.
+	suite.Contains(calendar, "RRULE:FREQ=WEEKLY;UNTIL=20240301T145959Z\r\nSUMMARY:G\r\n")
+
+	// No start and end, not repeated.
+	suite.Contains(calendar, "\r\nSUMMARY:H\r\n")
+	suite.Equal(4, strings.Count(calendar, "RRULE:"))
+
+	for _, line := range strings.Split(calendar, "\r\n") {
+		suite.LessOrEqual(len(line), 75)
+	}
+
+	suite.animeMock.AssertExpectations(suite.T())
+}
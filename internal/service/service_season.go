@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const seasonStudioLimit = 10
+
+// Order of seasons in a year.
+var seasons = []entity.Season{
+	entity.SeasonWinter,
+	entity.SeasonSpring,
+	entity.SeasonSummer,
+	entity.SeasonFall,
+}
+
+// SeasonCount is season anime count model.
+type SeasonCount struct {
+	Season entity.Season `json:"season" swaggertype:"string"`
+	Year   int           `json:"year"`
+	Count  int           `json:"count"`
+}
+
+// GetSeasons to get all seasons with their anime count.
+// Sorted from the latest season.
+func (s *service) GetSeasons(ctx context.Context) ([]SeasonCount, int, error) {
+	seasonCounts, code, err := s.anime.GetSeasons(ctx)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]SeasonCount, len(seasonCounts))
+	for i, sc := range seasonCounts {
+		res[i] = SeasonCount{
+			Season: sc.Season,
+			Year:   sc.Year,
+			Count:  sc.Count,
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Year != res[j].Year {
+			return res[i].Year > res[j].Year
+		}
+		return s.getSeasonIndex(res[i].Season) > s.getSeasonIndex(res[j].Season)
+	})
+
+	return res, http.StatusOK, nil
+}
+
+// SeasonDetail is season detail model.
+type SeasonDetail struct {
+	Season         entity.Season  `json:"season" swaggertype:"string"`
+	Year           int            `json:"year"`
+	Count          int            `json:"count"`
+	MeanScore      float64        `json:"mean_score"`
+	Types          []seasonCount  `json:"types"`
+	Sources        []seasonCount  `json:"sources"`
+	Studios        []seasonStudio `json:"studios"`
+	Anime          []seasonAnime  `json:"anime"`
+	CarryOvers     []seasonAnime  `json:"carry_overs"`
+	PreviousSeason Season         `json:"previous_season"`
+}
+
+type seasonCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type seasonStudio struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type seasonAnime struct {
+	ID           int64         `json:"id"`
+	Title        string        `json:"title"`
+	Picture      string        `json:"picture"`
+	Type         entity.Type   `json:"type" swaggertype:"string"`
+	Source       entity.Source `json:"source" swaggertype:"string"`
+	Status       entity.Status `json:"status" swaggertype:"string"`
+	Mean         float64       `json:"mean"`
+	Member       int           `json:"member"`
+	EpisodeCount int           `json:"episode_count"`
+	StartDate    Date          `json:"start_date"`
+}
+
+// GetSeasonRequest is get season request model.
+type GetSeasonRequest struct {
+	Year   int           `validate:"required,gte=1"`
+	Season entity.Season `validate:"required,oneof=WINTER SPRING SUMMER FALL" mod:"no_space,ucase"`
+}
+
+// GetSeason to get anime in a season and its summary.
+//
+// Anime are sorted by member. Mean score excludes
+// unscored anime. Carry-overs are anime from the
+// previous season still airing when this season
+// started.
+func (s *service) GetSeason(ctx context.Context, data GetSeasonRequest) (*SeasonDetail, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	anime, _, code, err := s.anime.Get(ctx, entity.GetRequest{
+		Season:     data.Season,
+		SeasonYear: data.Year,
+		Sort:       "-" + entity.SortMember,
+		Page:       1,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	prevSeason, prevYear := s.getPreviousSeason(data.Season, data.Year)
+
+	prevAnime, _, code, err := s.anime.Get(ctx, entity.GetRequest{
+		Season:     prevSeason,
+		SeasonYear: prevYear,
+		Sort:       "-" + entity.SortMember,
+		Page:       1,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := SeasonDetail{
+		Season:     data.Season,
+		Year:       data.Year,
+		Count:      len(anime),
+		Types:      []seasonCount{},
+		Sources:    []seasonCount{},
+		Studios:    []seasonStudio{},
+		Anime:      make([]seasonAnime, len(anime)),
+		CarryOvers: []seasonAnime{},
+		PreviousSeason: Season{
+			Season: prevSeason,
+			Year:   prevYear,
+		},
+	}
+
+	var meanSum float64
+	var meanCnt int
+	types := make(map[string]int)
+	sources := make(map[string]int)
+	animeIDs := make([]int64, len(anime))
+
+	for i, a := range anime {
+		res.Anime[i] = s.seasonAnimeFromEntity(a)
+		animeIDs[i] = a.ID
+		types[string(a.Type)]++
+		sources[string(a.Source)]++
+
+		if a.Mean > 0 {
+			meanSum += a.Mean
+			meanCnt++
+		}
+	}
+
+	if meanCnt > 0 {
+		res.MeanScore = s.round(meanSum / float64(meanCnt))
+	}
+
+	res.Types = s.getSeasonCounts(types)
+	res.Sources = s.getSeasonCounts(sources)
+
+	startMonth := s.getSeasonIndex(data.Season)*3 + 1
+	for _, a := range prevAnime {
+		if a.Status == entity.StatusReleasing ||
+			a.EndDate.Year > data.Year ||
+			(a.EndDate.Year == data.Year && a.EndDate.Month >= startMonth) {
+			res.CarryOvers = append(res.CarryOvers, s.seasonAnimeFromEntity(a))
+		}
+	}
+
+	if len(animeIDs) > 0 {
+		studios, code, err := s.getSeasonStudios(ctx, animeIDs)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+		res.Studios = studios
+	}
+
+	return &res, http.StatusOK, nil
+}
+
+func (s *service) seasonAnimeFromEntity(a *entity.Anime) seasonAnime {
+	return seasonAnime{
+		ID:           a.ID,
+		Title:        a.Title,
+		Picture:      a.Picture,
+		Type:         a.Type,
+		Source:       a.Source,
+		Status:       a.Status,
+		Mean:         a.Mean,
+		Member:       a.Member,
+		EpisodeCount: a.Episode.Count,
+		StartDate: Date{
+			Year:  a.StartDate.Year,
+			Month: a.StartDate.Month,
+			Day:   a.StartDate.Day,
+		},
+	}
+}
+
+func (s *service) getSeasonCounts(counts map[string]int) []seasonCount {
+	res := make([]seasonCount, 0, len(counts))
+	for name, cnt := range counts {
+		res = append(res, seasonCount{
+			Name:  name,
+			Count: cnt,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+func (s *service) getSeasonStudios(ctx context.Context, animeIDs []int64) ([]seasonStudio, int, error) {
+	animeStudios, code, err := s.anime.GetStudiosByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	counts := make(map[int64]int)
+	for _, as := range animeStudios {
+		counts[as.StudioID]++
+	}
+
+	res := make([]seasonStudio, 0, len(counts))
+	for id, cnt := range counts {
+		res = append(res, seasonStudio{
+			ID:    id,
+			Count: cnt,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].ID < res[j].ID
+	})
+
+	if len(res) > seasonStudioLimit {
+		res = res[:seasonStudioLimit]
+	}
+
+	if len(res) == 0 {
+		return res, http.StatusOK, nil
+	}
+
+	studioIDs := make([]int64, len(res))
+	for i, st := range res {
+		studioIDs[i] = st.ID
+	}
+
+	studios, code, err := s.studio.GetByIDs(ctx, studioIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	nameMap := make(map[int64]string)
+	for _, st := range studios {
+		nameMap[st.ID] = st.Name
+	}
+
+	for i := range res {
+		res[i].Name = nameMap[res[i].ID]
+	}
+
+	return res, http.StatusOK, nil
+}
+
+func (s *service) getSeasonIndex(season entity.Season) int {
+	for i, ss := range seasons {
+		if ss == season {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *service) getPreviousSeason(season entity.Season, year int) (entity.Season, int) {
+	i := s.getSeasonIndex(season)
+	if i == 0 {
+		return seasons[len(seasons)-1], year - 1
+	}
+	return seasons[i-1], year
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rl404/akatsuki/internal/domain/anime/entity"
+	entityStudio "github.com/rl404/akatsuki/internal/domain/studio/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockStudio "github.com/rl404/akatsuki/tests/mocks/domain/studio"
+	"github.com/stretchr/testify/suite"
+)
+
+type seasonTestSuite struct {
+	suite.Suite
+	animeMock  *mockAnime.Repository
+	studioMock *mockStudio.Repository
+	service    service.Service
+}
+
+func TestSeason(t *testing.T) {
+	suite.Run(t, new(seasonTestSuite))
+}
+
+func (suite *seasonTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.studioMock = new(mockStudio.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:  suite.animeMock,
+		Studio: suite.studioMock,
+	})
+}
+
+func (suite *seasonTestSuite) TestGetSeasons() {
+	ctx := context.Background()
+
+	suite.animeMock.On("GetSeasons", ctx).Return([]*entity.SeasonCount{
+		{Season: entity.SeasonSpring, Year: 2020, Count: 5},
+		{Season: entity.SeasonWinter, Year: 2021, Count: 3},
+		{Season: entity.SeasonFall, Year: 2020, Count: 4},
+	}, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetSeasons(ctx)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal([]service.SeasonCount{
+		{Season: entity.SeasonWinter, Year: 2021, Count: 3},
+		{Season: entity.SeasonFall, Year: 2020, Count: 4},
+		{Season: entity.SeasonSpring, Year: 2020, Count: 5},
+	}, data)
+
+	suite.animeMock.AssertExpectations(suite.T())
+}
+
+func (suite *seasonTestSuite) TestGetSeason() {
+	ctx := context.Background()
+
+	// Invalid season.
+	_, code, err := suite.service.GetSeason(ctx, service.GetSeasonRequest{Year: 2021, Season: "autumn"})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	suite.animeMock.On("Get", ctx, entity.GetRequest{Season: entity.SeasonWinter, SeasonYear: 2021, Sort: "-MEMBER", Page: 1, Limit: -1}).Return([]*entity.Anime{
+		{ID: 1, Type: entity.TypeTV, Source: entity.SourceManga, Mean: 8},
+		{ID: 2, Type: entity.TypeTV, Source: entity.SourceOriginal, Mean: 7},
+		{ID: 3, Type: entity.TypeMovie, Source: entity.SourceManga},
+	}, 3, http.StatusOK, nil).Once()
+	suite.animeMock.On("Get", ctx, entity.GetRequest{Season: entity.SeasonFall, SeasonYear: 2020, Sort: "-MEMBER", Page: 1, Limit: -1}).Return([]*entity.Anime{
+		{ID: 4, Status: entity.StatusReleasing},
+		{ID: 5, Status: entity.StatusFinished, EndDate: entity.Date{Year: 2021, Month: 3}},
+		{ID: 6, Status: entity.StatusFinished, EndDate: entity.Date{Year: 2020, Month: 12}},
+	}, 3, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetStudiosByIDs", ctx, []int64{1, 2, 3}).Return([]*entity.AnimeStudio{
+		{AnimeID: 1, StudioID: 10},
+		{AnimeID: 2, StudioID: 10},
+		{AnimeID: 3, StudioID: 11},
+	}, http.StatusOK, nil).Once()
+	suite.studioMock.On("GetByIDs", ctx, []int64{10, 11}).Return([]*entityStudio.Studio{
+		{ID: 10, Name: "A"},
+		{ID: 11, Name: "B"},
+	}, http.StatusOK, nil).Once()
+
+	data, code, err := suite.service.GetSeason(ctx, service.GetSeasonRequest{Year: 2021, Season: "winter"})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal(3, data.Count)
+	suite.Equal(7.5, data.MeanScore)
+	suite.Equal(service.Season{Season: entity.SeasonFall, Year: 2020}, data.PreviousSeason)
+	suite.Equal("TV", data.Types[0].Name)
+	suite.Equal(2, data.Types[0].Count)
+	suite.Equal("MANGA", data.Sources[0].Name)
+	suite.Equal("A", data.Studios[0].Name)
+	suite.Equal(2, data.Studios[0].Count)
+	suite.Len(data.CarryOvers, 2)
+	suite.Equal(int64(4), data.CarryOvers[0].ID)
+	suite.Equal(int64(5), data.CarryOvers[1].ID)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.studioMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// SyncStatus is data sync status model.
+type SyncStatus struct {
+	Attempt       int        `json:"attempt"`
+	LastAttemptAt time.Time  `json:"last_attempt_at"`
+	LastSuccessAt *time.Time `json:"last_success_at"`
+	LastError     string     `json:"last_error"`
+}
+
+// GetAnimeSyncStatus to get anime sync status.
+func (s *service) GetAnimeSyncStatus(ctx context.Context, id int64) (*SyncStatus, int, error) {
+	status, code, err := s.syncStatus.GetByKey(ctx, entity.TypeAnime, strconv.FormatInt(id, 10))
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	return &SyncStatus{
+		Attempt:       status.Attempt,
+		LastAttemptAt: status.LastAttemptAt,
+		LastSuccessAt: status.LastSuccessAt,
+		LastError:     status.LastError,
+	}, http.StatusOK, nil
+}
+
+// recordSync to save sync attempt result.
+// Failing to save is only logged so the message is not retried.
+func (s *service) recordSync(ctx context.Context, _type entity.Type, key string, syncErr error) {
+	if _, err := s.syncStatus.Record(ctx, _type, key, syncErr); err != nil {
+		stack.Wrap(ctx, err)
+	}
+}
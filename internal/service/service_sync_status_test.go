@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/service"
+	mockSyncStatus "github.com/rl404/akatsuki/tests/mocks/domain/sync_status"
+	"github.com/stretchr/testify/suite"
+)
+
+type syncStatusTestSuite struct {
+	suite.Suite
+	syncStatusMock *mockSyncStatus.Repository
+	service        service.Service
+}
+
+func TestSyncStatus(t *testing.T) {
+	suite.Run(t, new(syncStatusTestSuite))
+}
+
+func (suite *syncStatusTestSuite) SetupTest() {
+	suite.syncStatusMock = new(mockSyncStatus.Repository)
+	suite.service = service.New(service.Deps{
+		SyncStatus: suite.syncStatusMock,
+	})
+}
+
+func (suite *syncStatusTestSuite) TestGetAnimeSyncStatus() {
+	ctx := context.Background()
+	now := time.Now()
+
+	suite.syncStatusMock.On("GetByKey", ctx, entity.TypeAnime, "1").Return(&entity.SyncStatus{
+		Type:          entity.TypeAnime,
+		Key:           "1",
+		Attempt:       2,
+		LastAttemptAt: now,
+		LastError:     "dummy error",
+	}, http.StatusOK, nil).Once()
+
+	status, code, err := suite.service.GetAnimeSyncStatus(ctx, 1)
+	suite.Equal(&service.SyncStatus{
+		Attempt:       2,
+		LastAttemptAt: now,
+		LastError:     "dummy error",
+	}, status)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.syncStatusMock.On("GetByKey", ctx, entity.TypeAnime, "2").Return(nil, http.StatusNotFound, errors.ErrSyncStatusNotFound).Once()
+
+	status, code, err = suite.service.GetAnimeSyncStatus(ctx, 2)
+	suite.Nil(status)
+	suite.Equal(http.StatusNotFound, code)
+	suite.NotNil(err)
+	suite.syncStatusMock.AssertExpectations(suite.T())
+}
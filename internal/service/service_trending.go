@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/trending/entity"
+	"github.com/rl404/akatsuki/internal/utils"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+// TrendingAnime is trending anime model.
+type TrendingAnime struct {
+	AnimeID    int64   `json:"anime_id"`
+	Title      string  `json:"title"`
+	Picture    string  `json:"picture"`
+	Member     int     `json:"member"`
+	Rank       int     `json:"rank"`
+	Mean       float64 `json:"mean"`
+	MemberGain int     `json:"member_gain"`
+	RankChange int     `json:"rank_change"`
+	MeanChange float64 `json:"mean_change"`
+}
+
+// GetTrendingAnimeRequest is get trending anime request model.
+type GetTrendingAnimeRequest struct {
+	Window entity.Window `validate:"oneof=7d 30d" mod:"no_space,lcase,default=7d"`
+	Sort   entity.Sort   `validate:"oneof=MEMBER RANK MEAN" mod:"no_space,ucase,default=MEMBER"`
+	Page   int           `validate:"required,gte=1" mod:"default=1"`
+	Limit  int           `validate:"required,gte=1,lte=100" mod:"default=20"`
+}
+
+// GetTrendingAnime to get trending anime in the window.
+//
+// Sorted by member gain, rank climb or mean score
+// increase. Only anime moving in that direction are
+// returned.
+func (s *service) GetTrendingAnime(ctx context.Context, data GetTrendingAnimeRequest) ([]TrendingAnime, *Pagination, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	trending, total, code, err := s.trending.Get(ctx, entity.GetRequest{
+		Window: data.Window,
+		Sort:   data.Sort,
+		Page:   data.Page,
+		Limit:  data.Limit,
+	})
+	if err != nil {
+		return nil, nil, code, stack.Wrap(ctx, err)
+	}
+
+	res := make([]TrendingAnime, len(trending))
+	animeIDs := make([]int64, len(trending))
+	for i, t := range trending {
+		animeIDs[i] = t.AnimeID
+		res[i] = TrendingAnime{
+			AnimeID:    t.AnimeID,
+			MemberGain: t.MemberGain,
+			RankChange: t.RankChange,
+			MeanChange: t.MeanChange,
+		}
+	}
+
+	if len(animeIDs) > 0 {
+		anime, code, err := s.anime.GetByIDs(ctx, animeIDs)
+		if err != nil {
+			return nil, nil, code, stack.Wrap(ctx, err)
+		}
+
+		animeMap := make(map[int64]*animeEntity.Anime)
+		for _, a := range anime {
+			animeMap[a.ID] = a
+		}
+
+		for i := range res {
+			if a := animeMap[res[i].AnimeID]; a != nil {
+				res[i].Title = a.Title
+				res[i].Picture = a.Picture
+				res[i].Member = a.Member
+				res[i].Rank = a.Rank
+				res[i].Mean = a.Mean
+			}
+		}
+	}
+
+	return res, &Pagination{
+		Page:  data.Page,
+		Limit: data.Limit,
+		Total: total,
+	}, http.StatusOK, nil
+}
+
+// UpdateTrending to recompute trending anime of all
+// windows from anime stats history.
+// Will return total saved trending anime.
+func (s *service) UpdateTrending(ctx context.Context) (int, int, error) {
+	var cnt int
+	for _, window := range entity.Windows {
+		changes, code, err := s.anime.GetStatsChanges(ctx, time.Now().Add(-window.Duration()))
+		if err != nil {
+			return cnt, code, stack.Wrap(ctx, err)
+		}
+
+		var trending []entity.Trending
+		for _, c := range changes {
+			// Baseline may be older than the window start.
+			// Scale the change down to the window length.
+			scale := 1.0
+			if span := c.EndAt.Sub(c.StartAt); span > window.Duration() {
+				scale = float64(window.Duration()) / float64(span)
+			}
+
+			t := entity.Trending{
+				AnimeID:    c.AnimeID,
+				Window:     window,
+				MemberGain: int(math.Round(float64(c.EndMember-c.StartMember) * scale)),
+			}
+
+			// Unranked and unscored anime have 0.
+			if c.StartRank > 0 && c.EndRank > 0 {
+				t.RankChange = int(math.Round(float64(c.StartRank-c.EndRank) * scale))
+			}
+
+			if c.StartMean > 0 && c.EndMean > 0 {
+				t.MeanChange = s.round((c.EndMean - c.StartMean) * scale)
+			}
+
+			if t.MemberGain == 0 && t.RankChange == 0 && t.MeanChange == 0 {
+				continue
+			}
+
+			trending = append(trending, t)
+		}
+
+		if code, err := s.trending.Replace(ctx, window, trending); err != nil {
+			return cnt, code, stack.Wrap(ctx, err)
+		}
+
+		cnt += len(trending)
+	}
+
+	return cnt, http.StatusOK, nil
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	entityAnime "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	"github.com/rl404/akatsuki/internal/domain/trending/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockTrending "github.com/rl404/akatsuki/tests/mocks/domain/trending"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type trendingTestSuite struct {
+	suite.Suite
+	animeMock    *mockAnime.Repository
+	trendingMock *mockTrending.Repository
+	service      service.Service
+}
+
+func TestTrending(t *testing.T) {
+	suite.Run(t, new(trendingTestSuite))
+}
+
+func (suite *trendingTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.trendingMock = new(mockTrending.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:    suite.animeMock,
+		Trending: suite.trendingMock,
+	})
+}
+
+func (suite *trendingTestSuite) TestGetTrendingAnime() {
+	ctx := context.Background()
+
+	// Invalid window.
+	_, _, code, err := suite.service.GetTrendingAnime(ctx, service.GetTrendingAnimeRequest{Window: "1y"})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	suite.trendingMock.On("Get", ctx, entity.GetRequest{Window: entity.Window7Day, Sort: entity.SortMember, Page: 1, Limit: 20}).Return([]*entity.Trending{
+		{AnimeID: 1, Window: entity.Window7Day, MemberGain: 100, RankChange: 2},
+	}, 1, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1}).Return([]*entityAnime.Anime{
+		{ID: 1, Title: "A", Member: 1000, Rank: 10, Mean: 8.5},
+	}, http.StatusOK, nil).Once()
+
+	data, pagination, code, err := suite.service.GetTrendingAnime(ctx, service.GetTrendingAnimeRequest{})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal(1, pagination.Total)
+	suite.Equal([]service.TrendingAnime{{AnimeID: 1, Title: "A", Member: 1000, Rank: 10, Mean: 8.5, MemberGain: 100, RankChange: 2}}, data)
+
+	suite.trendingMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+}
+
+func (suite *trendingTestSuite) TestUpdateTrending() {
+	ctx := context.Background()
+
+	now := time.Now()
+	suite.animeMock.On("GetStatsChanges", ctx, mock.Anything).Return([]*entityAnime.StatsChange{
+		{AnimeID: 1, StartMember: 100, EndMember: 150, StartRank: 20, EndRank: 15, StartMean: 8.1, EndMean: 8.3, StartAt: now.Add(-7 * 24 * time.Hour), EndAt: now},
+		{AnimeID: 2, StartMember: 100, EndMember: 100, EndRank: 50, EndMean: 7, StartAt: now.Add(-24 * time.Hour), EndAt: now},
+		{AnimeID: 3, StartMember: 100, EndMember: 300, StartAt: now.Add(-14 * 24 * time.Hour), EndAt: now},
+	}, http.StatusOK, nil).Twice()
+	suite.trendingMock.On("Replace", ctx, entity.Window7Day, []entity.Trending{
+		{AnimeID: 1, Window: entity.Window7Day, MemberGain: 50, RankChange: 5, MeanChange: 0.2},
+		{AnimeID: 3, Window: entity.Window7Day, MemberGain: 100},
+	}).Return(http.StatusOK, nil).Once()
+	suite.trendingMock.On("Replace", ctx, entity.Window30Day, []entity.Trending{
+		{AnimeID: 1, Window: entity.Window30Day, MemberGain: 50, RankChange: 5, MeanChange: 0.2},
+		{AnimeID: 3, Window: entity.Window30Day, MemberGain: 200},
+	}).Return(http.StatusOK, nil).Once()
+
+	cnt, code, err := suite.service.UpdateTrending(ctx)
+	suite.Equal(4, cnt)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.trendingMock.AssertExpectations(suite.T())
+}
@@ -3,19 +3,84 @@ package service
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
 	genreEntity "github.com/rl404/akatsuki/internal/domain/genre/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	studioEntity "github.com/rl404/akatsuki/internal/domain/studio/entity"
+	syncEntity "github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	"github.com/rl404/akatsuki/internal/errors"
+	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
 )
 
 // UpdateAnimeByID to update anime by id.
-func (s *service) UpdateAnimeByID(ctx context.Context, id int64) (int, error) {
-	if err := s.publisher.PublishParseAnime(ctx, id, true); err != nil {
-		return http.StatusInternalServerError, stack.Wrap(ctx, err)
+// Will return cooldown if the anime was just forced to update.
+func (s *service) UpdateAnimeByID(ctx context.Context, id int64) (*Cooldown, int, error) {
+	if cooldown, code, err := s.takeCooldown(ctx, syncEntity.TypeAnime, strconv.FormatInt(id, 10)); err != nil {
+		return cooldown, code, stack.Wrap(ctx, err)
 	}
-	return http.StatusAccepted, nil
+
+	if _, err := s.publisher.PublishParseAnime(ctx, id, true, publisherEntity.PriorityHigh); err != nil {
+		// Not queued so it can be retried right away.
+		s.releaseCooldown(ctx, syncEntity.TypeAnime, strconv.FormatInt(id, 10))
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+	}
+
+	return nil, http.StatusAccepted, nil
+}
+
+const maxRefreshRange = 1000
+
+// RefreshAnimeRequest is bulk force update anime request model.
+// Use id range or filter.
+type RefreshAnimeRequest struct {
+	StartID int64 `validate:"gte=0"`
+	EndID   int64 `validate:"gte=0"`
+	Filter  GetAnimeRequest
+}
+
+// RefreshAnime to queue anime in id range or
+// anime list filter result to be updated.
+// Ids in range do not need to exist in database.
+// Will return queued count.
+func (s *service) RefreshAnime(ctx context.Context, data RefreshAnimeRequest) (int, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return 0, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
+
+	var ids []int64
+	if data.StartID > 0 || data.EndID > 0 {
+		if data.EndID < data.StartID {
+			return 0, http.StatusBadRequest, stack.Wrap(ctx, errors.ErrGTEField("end_id", "start_id"))
+		}
+
+		if data.EndID-data.StartID >= maxRefreshRange {
+			return 0, http.StatusBadRequest, stack.Wrap(ctx, errors.ErrRefreshRangeTooLarge)
+		}
+
+		for id := data.StartID; id <= data.EndID; id++ {
+			ids = append(ids, id)
+		}
+	} else {
+		anime, _, code, err := s.anime.Get(ctx, s.animeGetRequest(data.Filter))
+		if err != nil {
+			return 0, code, stack.Wrap(ctx, err)
+		}
+
+		for _, a := range anime {
+			ids = append(ids, a.ID)
+		}
+	}
+
+	for i, id := range ids {
+		if _, err := s.publisher.PublishParseAnime(ctx, id, true, publisherEntity.PriorityLow); err != nil {
+			return i, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+	}
+
+	return len(ids), http.StatusAccepted, nil
 }
 
 func (s *service) updateAnime(ctx context.Context, id int64) (int, error) {
@@ -36,6 +101,9 @@ func (s *service) updateAnime(ctx context.Context, id int64) (int, error) {
 			if code, err := s.userAnime.DeleteByAnimeID(ctx, id); err != nil {
 				return code, stack.Wrap(ctx, err)
 			}
+
+			// Not an error so the message is not retried.
+			return http.StatusOK, nil
 		}
 		return code, stack.Wrap(ctx, err)
 	}
@@ -71,16 +139,10 @@ func (s *service) updateAnime(ctx context.Context, id int64) (int, error) {
 	}
 
 	// Update anime data.
+	// Related anime will be queued through outbox.
 	if code, err := s.anime.Update(ctx, animeEntity.AnimeFromMal(ctx, anime)); err != nil {
 		return code, stack.Wrap(ctx, err)
 	}
 
-	// Queue related anime.
-	for _, r := range anime.RelatedAnime {
-		if err := s.publisher.PublishParseAnime(ctx, int64(r.Anime.ID), false); err != nil {
-			return http.StatusInternalServerError, stack.Wrap(ctx, err)
-		}
-	}
-
 	return http.StatusOK, nil
 }
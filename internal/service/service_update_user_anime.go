@@ -6,16 +6,28 @@ import (
 	"strings"
 
 	"github.com/rl404/akatsuki/internal/domain/mal/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	syncEntity "github.com/rl404/akatsuki/internal/domain/sync_status/entity"
 	userEntity "github.com/rl404/akatsuki/internal/domain/user_anime/entity"
 	"github.com/rl404/fairy/errors/stack"
 )
 
 // UpdateUserAnime to update user anime.
-func (s *service) UpdateUserAnime(ctx context.Context, username string) (int, error) {
-	if err := s.publisher.PublishParseUserAnime(ctx, strings.ToLower(username), "", true); err != nil {
-		return http.StatusInternalServerError, stack.Wrap(ctx, err)
+// Will return cooldown if the user was just forced to update.
+func (s *service) UpdateUserAnime(ctx context.Context, username string) (*Cooldown, int, error) {
+	username = strings.ToLower(username)
+
+	if cooldown, code, err := s.takeCooldown(ctx, syncEntity.TypeUserAnime, username); err != nil {
+		return cooldown, code, stack.Wrap(ctx, err)
+	}
+
+	if _, err := s.publisher.PublishParseUserAnime(ctx, username, "", true, publisherEntity.PriorityHigh); err != nil {
+		// Not queued so it can be retried right away.
+		s.releaseCooldown(ctx, syncEntity.TypeUserAnime, username)
+		return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
 	}
-	return http.StatusAccepted, nil
+
+	return nil, http.StatusAccepted, nil
 }
 
 func (s *service) updateUserAnime(ctx context.Context, username, status string) (int, error) {
@@ -37,6 +49,9 @@ func (s *service) updateUserAnime(ctx context.Context, username, status string)
 				if code, err := s.userAnime.DeleteByUsername(ctx, username); err != nil {
 					return code, stack.Wrap(ctx, err)
 				}
+				if code, err := s.relationGraph.DeleteByUsername(ctx, username); err != nil {
+					return code, stack.Wrap(ctx, err)
+				}
 				return http.StatusOK, nil
 			}
 			return code, stack.Wrap(ctx, err)
@@ -46,14 +61,10 @@ func (s *service) updateUserAnime(ctx context.Context, username, status string)
 			ids = append(ids, int64(a.Anime.ID))
 
 			// Update user anime data.
+			// The anime will be queued through outbox.
 			if code, err := s.userAnime.Update(ctx, userEntity.UserAnimeFromMal(ctx, username, a)); err != nil {
 				return code, stack.Wrap(ctx, err)
 			}
-
-			// Queue related anime.
-			if err := s.publisher.PublishParseAnime(ctx, int64(a.Anime.ID), false); err != nil {
-				return http.StatusInternalServerError, stack.Wrap(ctx, err)
-			}
 		}
 
 		if len(anime) <= limit || len(anime) == 0 {
@@ -68,5 +79,10 @@ func (s *service) updateUserAnime(ctx context.Context, username, status string)
 		return code, stack.Wrap(ctx, err)
 	}
 
+	// Invalidate cached relation graph.
+	if code, err := s.relationGraph.DeleteByUsername(ctx, username); err != nil {
+		return code, stack.Wrap(ctx, err)
+	}
+
 	return http.StatusOK, nil
 }
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	relationGraphEntity "github.com/rl404/akatsuki/internal/domain/relation_graph/entity"
 	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
 	"github.com/rl404/akatsuki/internal/utils"
 	"github.com/rl404/fairy/errors/stack"
@@ -25,9 +29,25 @@ type UserAnime struct {
 
 // GetUserAnimeRequest is get user anime request model.
 type GetUserAnimeRequest struct {
-	Username string `validate:"required" mod:"trim,lcase"`
-	Page     int    `validate:"required,gte=1" mod:"default=1"`
-	Limit    int    `validate:"required,gte=-1" mod:"default=20"`
+	Username     string        `validate:"required" mod:"trim,lcase"`
+	Status       entity.Status `validate:"omitempty,oneof=WATCHING COMPLETED ON_HOLD DROPPED PLANNED" mod:"ucase,no_space"`
+	StartScore   int           `validate:"gte=0,lte=10"`
+	EndScore     int           `validate:"gte=0,lte=10"`
+	Tags         []string      `mod:"dive,trim"`
+	IsRewatching *bool
+	Title        string             `mod:"trim"`
+	AnimeType    animeEntity.Type   `validate:"omitempty,oneof=TV OVA ONA MOVIE SPECIAL MUSIC CM PV TV_SPECIAL" mod:"ucase,no_space"`
+	Season       animeEntity.Season `validate:"omitempty,oneof=WINTER SPRING SUMMER FALL" mod:"ucase,no_space"`
+	SeasonYear   int                `validate:"gte=0"`
+	GenreID      int64              `validate:"gte=0"`
+	Sort         entity.Sort        `validate:"oneof=SCORE -SCORE UPDATED_AT -UPDATED_AT PROGRESS -PROGRESS TITLE -TITLE" mod:"no_space,ucase,default=-UPDATED_AT"`
+	Page         int                `validate:"required,gte=1" mod:"default=1"`
+	Limit        int                `validate:"required,gte=-1" mod:"default=20"`
+}
+
+func (r GetUserAnimeRequest) isFiltered() bool {
+	return r.Status != "" || r.StartScore > 0 || r.EndScore > 0 || len(r.Tags) > 0 || r.IsRewatching != nil ||
+		r.Title != "" || r.AnimeType != "" || r.Season != "" || r.SeasonYear > 0 || r.GenreID > 0
 }
 
 // GetUserAnime to get user anime.
@@ -37,17 +57,47 @@ func (s *service) GetUserAnime(ctx context.Context, data GetUserAnimeRequest) ([
 	}
 
 	userAnime, cnt, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
-		Username: data.Username,
-		Page:     data.Page,
-		Limit:    data.Limit,
+		Username:     data.Username,
+		Status:       data.Status,
+		StartScore:   data.StartScore,
+		EndScore:     data.EndScore,
+		Tags:         data.Tags,
+		IsRewatching: data.IsRewatching,
+		Title:        data.Title,
+		AnimeType:    data.AnimeType,
+		Season:       data.Season,
+		SeasonYear:   data.SeasonYear,
+		GenreID:      data.GenreID,
+		Sort:         data.Sort,
+		Page:         data.Page,
+		Limit:        data.Limit,
 	})
 	if err != nil {
 		return nil, nil, code, stack.Wrap(ctx, err)
 	}
 
+	if cnt == 0 && data.isFiltered() {
+		// Empty filter result doesn't mean the user is not parsed yet.
+		if _, cnt, code, err = s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
+			Username: data.Username,
+			Page:     1,
+			Limit:    1,
+		}); err != nil {
+			return nil, nil, code, stack.Wrap(ctx, err)
+		}
+
+		if cnt > 0 {
+			return []UserAnime{}, &Pagination{
+				Page:  data.Page,
+				Limit: data.Limit,
+				Total: 0,
+			}, http.StatusOK, nil
+		}
+	}
+
 	if cnt == 0 {
 		// Queue to parse.
-		if err := s.publisher.PublishParseUserAnime(ctx, data.Username, "", false); err != nil {
+		if _, err := s.publisher.PublishParseUserAnime(ctx, data.Username, "", false, publisherEntity.PriorityHigh); err != nil {
 			return nil, nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
 		return nil, nil, http.StatusAccepted, nil
@@ -75,8 +125,9 @@ func (s *service) GetUserAnime(ctx context.Context, data GetUserAnimeRequest) ([
 
 // UserAnimeRelation is user anime relation model.
 type UserAnimeRelation struct {
-	Nodes []userAnimeRelationNode `json:"nodes"`
-	Links []userAnimeRelationLink `json:"links"`
+	Nodes     []userAnimeRelationNode `json:"nodes"`
+	Links     []userAnimeRelationLink `json:"links"`
+	Truncated bool                    `json:"truncated"`
 }
 
 type userAnimeRelationNode struct {
@@ -102,12 +153,31 @@ type userAnimeRelationLink struct {
 	Relation animeEntity.Relation `json:"relation" swaggertype:"string"`
 }
 
-// GetUserAnimeRelations to get user anime relation.
-func (s *service) GetUserAnimeRelations(ctx context.Context, username string) (*UserAnimeRelation, int, error) {
-	username = strings.ToLower(username)
+// Max anime ids in one query.
+const userAnimeRelationChunk = 1000
+
+// GetUserAnimeRelationsRequest is get user anime relations request model.
+type GetUserAnimeRelationsRequest struct {
+	Username  string                 `validate:"required" mod:"trim,lcase"`
+	Relations []animeEntity.Relation `validate:"dive,oneof=SEQUEL PREQUEL ALTERNATIVE_SETTING ALTERNATIVE_VERSION SIDE_STORY PARENT_STORY SUMMARY FULL_STORY SPIN_OFF ADAPTATION CHARACTER OTHER" mod:"dive,ucase,no_space"`
+	Depth     int                    `validate:"required,gte=1,lte=20" mod:"default=10"`
+	Limit     int                    `validate:"required,gte=1,lte=10000" mod:"default=5000"`
+}
+
+// GetUserAnimeRelations to get user anime relation graph.
+//
+// The graph starts from anime in user list and follows
+// anime relations up to depth steps away. Anime in user
+// list are always included, related anime stop being
+// added once there are limit nodes. Graph is truncated
+// only if a relation is left out because of the caps.
+func (s *service) GetUserAnimeRelations(ctx context.Context, data GetUserAnimeRelationsRequest) (*UserAnimeRelation, int, error) {
+	if err := utils.Validate(&data); err != nil {
+		return nil, http.StatusBadRequest, stack.Wrap(ctx, err)
+	}
 
 	userAnime, _, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
-		Username: username,
+		Username: data.Username,
 		Page:     1,
 		Limit:    -1,
 	})
@@ -117,95 +187,175 @@ func (s *service) GetUserAnimeRelations(ctx context.Context, username string) (*
 
 	if len(userAnime) == 0 {
 		// Queue to parse.
-		if err := s.publisher.PublishParseUserAnime(ctx, username, "", false); err != nil {
+		if _, err := s.publisher.PublishParseUserAnime(ctx, data.Username, "", false, publisherEntity.PriorityHigh); err != nil {
 			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
 		}
 		return nil, http.StatusAccepted, nil
 	}
 
-	var animeIDs []int64
-	nodes := []userAnimeRelationNode{}
-	links := []userAnimeRelationLink{}
-	nodeMap := make(map[int64]bool)
 	userAnimeMap := make(map[int64]*entity.UserAnime)
-
 	for _, ua := range userAnime {
-		animeIDs = append(animeIDs, ua.AnimeID)
 		userAnimeMap[ua.AnimeID] = ua
 	}
 
-	if code, err := s.getUserAnimeRelations(ctx, &animeIDs, nodeMap, &links); err != nil {
-		return nil, code, stack.Wrap(ctx, err)
-	}
-
-	anime, code, err := s.anime.GetByIDs(ctx, animeIDs)
+	graph, code, err := s.getUserAnimeRelationGraph(ctx, userAnime, data)
 	if err != nil {
 		return nil, code, stack.Wrap(ctx, err)
 	}
 
-	for _, a := range anime {
-		var status entity.Status
-		var score int
-		var userEpisode int
-		if userAnimeMap[a.ID] != nil {
-			status = userAnimeMap[a.ID].Status
-			score = userAnimeMap[a.ID].Score
-			userEpisode = userAnimeMap[a.ID].Episode
+	nodes := []userAnimeRelationNode{}
+	links := []userAnimeRelationLink{}
+
+	for i := 0; i < len(graph.AnimeIDs); i += userAnimeRelationChunk {
+		anime, code, err := s.anime.GetByIDs(ctx, graph.AnimeIDs[i:min(i+userAnimeRelationChunk, len(graph.AnimeIDs))])
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+
+		for _, a := range anime {
+			var status entity.Status
+			var score int
+			var userEpisode int
+			if userAnimeMap[a.ID] != nil {
+				status = userAnimeMap[a.ID].Status
+				score = userAnimeMap[a.ID].Score
+				userEpisode = userAnimeMap[a.ID].Episode
+			}
+			nodes = append(nodes, userAnimeRelationNode{
+				AnimeID:          a.ID,
+				Title:            a.Title,
+				Status:           a.Status,
+				Score:            a.Mean,
+				Type:             a.Type,
+				Source:           a.Source,
+				StartYear:        a.StartDate.Year,
+				EpisodeCount:     a.Episode.Count,
+				EpisodeDuration:  a.Episode.Duration,
+				Season:           a.Season.Season,
+				SeasonYear:       a.Season.Year,
+				UserAnimeStatus:  status,
+				UserAnimeScore:   score,
+				UserEpisodeCount: userEpisode,
+			})
 		}
-		nodes = append(nodes, userAnimeRelationNode{
-			AnimeID:          a.ID,
-			Title:            a.Title,
-			Status:           a.Status,
-			Score:            a.Mean,
-			Type:             a.Type,
-			Source:           a.Source,
-			StartYear:        a.StartDate.Year,
-			EpisodeCount:     a.Episode.Count,
-			EpisodeDuration:  a.Episode.Duration,
-			Season:           a.Season.Season,
-			SeasonYear:       a.Season.Year,
-			UserAnimeStatus:  status,
-			UserAnimeScore:   score,
-			UserEpisodeCount: userEpisode,
+	}
+
+	for _, l := range graph.Links {
+		links = append(links, userAnimeRelationLink{
+			AnimeID1: l.AnimeID1,
+			AnimeID2: l.AnimeID2,
+			Relation: l.Relation,
 		})
 	}
 
 	return &UserAnimeRelation{
-		Nodes: nodes,
-		Links: links,
+		Nodes:     nodes,
+		Links:     links,
+		Truncated: graph.Truncated,
 	}, http.StatusOK, nil
 }
 
-func (s *service) getUserAnimeRelations(ctx context.Context, animeIDs *[]int64, nodeMap map[int64]bool, links *[]userAnimeRelationLink) (int, error) {
-	var ids []int64
+// getUserAnimeRelationGraph to get cached relation graph
+// or traverse anime relations breadth first, one query
+// per depth level.
+func (s *service) getUserAnimeRelationGraph(ctx context.Context, userAnime []*entity.UserAnime, data GetUserAnimeRelationsRequest) (*relationGraphEntity.Graph, int, error) {
+	relations := make([]string, len(data.Relations))
+	relationMap := make(map[animeEntity.Relation]bool)
+	for i, r := range data.Relations {
+		relations[i] = string(r)
+		relationMap[r] = true
+	}
+	sort.Strings(relations)
 
-	for _, id := range *animeIDs {
-		if !nodeMap[id] {
-			ids = append(ids, id)
-			nodeMap[id] = true
-		}
+	cacheKey := fmt.Sprintf("%d:%d:%s", data.Depth, data.Limit, strings.Join(relations, ","))
+
+	cached, code, err := s.relationGraph.Get(ctx, data.Username, cacheKey)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
 	}
 
-	if len(ids) == 0 {
-		return http.StatusOK, nil
+	if cached != nil {
+		return cached, http.StatusOK, nil
 	}
 
-	related, code, err := s.anime.GetRelatedByIDs(ctx, ids)
-	if err != nil {
-		return code, stack.Wrap(ctx, err)
+	var graph relationGraphEntity.Graph
+	var queue []int64
+	visited := make(map[int64]bool)
+
+	for _, ua := range userAnime {
+		if !visited[ua.AnimeID] {
+			visited[ua.AnimeID] = true
+			graph.AnimeIDs = append(graph.AnimeIDs, ua.AnimeID)
+			queue = append(queue, ua.AnimeID)
+		}
 	}
 
-	for _, r := range related {
-		if !nodeMap[r.AnimeID2] {
-			*animeIDs = append(*animeIDs, r.AnimeID2)
+	for depth := 0; depth < data.Depth && len(queue) > 0; depth++ {
+		var next []int64
+
+		for i := 0; i < len(queue); i += userAnimeRelationChunk {
+			related, code, err := s.anime.GetRelatedByIDs(ctx, queue[i:min(i+userAnimeRelationChunk, len(queue))])
+			if err != nil {
+				return nil, code, stack.Wrap(ctx, err)
+			}
+
+			for _, r := range related {
+				if len(relationMap) > 0 && !relationMap[r.Relation] {
+					continue
+				}
+
+				if !visited[r.AnimeID2] {
+					if len(graph.AnimeIDs) >= data.Limit {
+						graph.Truncated = true
+						continue
+					}
+
+					visited[r.AnimeID2] = true
+					graph.AnimeIDs = append(graph.AnimeIDs, r.AnimeID2)
+					next = append(next, r.AnimeID2)
+				}
+
+				graph.Links = append(graph.Links, relationGraphEntity.Link{
+					AnimeID1: r.AnimeID1,
+					AnimeID2: r.AnimeID2,
+					Relation: r.Relation,
+				})
+			}
 		}
 
-		*links = append(*links, userAnimeRelationLink{
-			AnimeID1: r.AnimeID1,
-			AnimeID2: r.AnimeID2,
-			Relation: r.Relation,
-		})
+		queue = next
+	}
+
+	// Max depth reached but unexpanded anime may still have relations.
+	if !graph.Truncated && len(queue) > 0 {
+		hasRelation, code, err := s.hasAnimeRelation(ctx, queue, relationMap)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+		graph.Truncated = hasRelation
+	}
+
+	if code, err := s.relationGraph.Set(ctx, data.Username, cacheKey, graph); err != nil {
+		return nil, code, stack.Wrap(ctx, err)
 	}
 
-	return s.getUserAnimeRelations(ctx, animeIDs, nodeMap, links)
+	return &graph, http.StatusOK, nil
+}
+
+// hasAnimeRelation to check if any of the anime has relation
+// matching the relation filter.
+func (s *service) hasAnimeRelation(ctx context.Context, ids []int64, relationMap map[animeEntity.Relation]bool) (bool, int, error) {
+	for i := 0; i < len(ids); i += userAnimeRelationChunk {
+		related, code, err := s.anime.GetRelatedByIDs(ctx, ids[i:min(i+userAnimeRelationChunk, len(ids))])
+		if err != nil {
+			return false, code, stack.Wrap(ctx, err)
+		}
+
+		for _, r := range related {
+			if len(relationMap) == 0 || relationMap[r.Relation] {
+				return true, http.StatusOK, nil
+			}
+		}
+	}
+	return false, http.StatusOK, nil
 }
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const userAnimeCompareGenreLimit = 10
+
+// UserAnimeCompare is user anime comparison model.
+type UserAnimeCompare struct {
+	Username1           string                   `json:"username1"`
+	Username2           string                   `json:"username2"`
+	Affinity            float64                  `json:"affinity"`
+	ScoreCorrelation    float64                  `json:"score_correlation"`
+	ScoreMeanDifference float64                  `json:"score_mean_difference"`
+	Shared              []userAnimeCompareShared `json:"shared"`
+	OnlyUser1           []int64                  `json:"only_user1"`
+	OnlyUser2           []int64                  `json:"only_user2"`
+	GenreDifferences    []userAnimeCompareGenre  `json:"genre_differences"`
+}
+
+type userAnimeCompareShared struct {
+	AnimeID int64 `json:"anime_id"`
+	Score1  int   `json:"score1"`
+	Score2  int   `json:"score2"`
+}
+
+type userAnimeCompareGenre struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Count1     int     `json:"count1"`
+	Count2     int     `json:"count2"`
+	Ratio1     float64 `json:"ratio1"`
+	Ratio2     float64 `json:"ratio2"`
+	Difference float64 `json:"difference"`
+}
+
+// CompareUserAnime to compare 2 users' anime list.
+//
+// Score correlation, mean difference and affinity only
+// use shared anime scored by both users. Affinity is
+// score correlation in percentage. Genre ratio is the
+// genre share in each list excluding planned anime.
+func (s *service) CompareUserAnime(ctx context.Context, username1, username2 string) (*UserAnimeCompare, int, error) {
+	username1, username2 = strings.ToLower(username1), strings.ToLower(username2)
+
+	userAnime1, _, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
+		Username: username1,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	userAnime2, _, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
+		Username: username2,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(userAnime1) == 0 || len(userAnime2) == 0 {
+		// Queue to parse.
+		for _, username := range []string{username1, username2} {
+			if _, err := s.publisher.PublishParseUserAnime(ctx, username, "", false, publisherEntity.PriorityHigh); err != nil {
+				return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+			}
+		}
+		return nil, http.StatusAccepted, nil
+	}
+
+	res := UserAnimeCompare{
+		Username1: username1,
+		Username2: username2,
+		Shared:    []userAnimeCompareShared{},
+		OnlyUser1: []int64{},
+		OnlyUser2: []int64{},
+	}
+
+	userAnimeMap2 := make(map[int64]*entity.UserAnime)
+	for _, ua := range userAnime2 {
+		userAnimeMap2[ua.AnimeID] = ua
+	}
+
+	var animeIDs []int64
+	var scores1, scores2 []float64
+	sharedMap := make(map[int64]bool)
+
+	for _, ua1 := range userAnime1 {
+		animeIDs = append(animeIDs, ua1.AnimeID)
+
+		ua2 := userAnimeMap2[ua1.AnimeID]
+		if ua2 == nil {
+			res.OnlyUser1 = append(res.OnlyUser1, ua1.AnimeID)
+			continue
+		}
+
+		sharedMap[ua1.AnimeID] = true
+		res.Shared = append(res.Shared, userAnimeCompareShared{
+			AnimeID: ua1.AnimeID,
+			Score1:  ua1.Score,
+			Score2:  ua2.Score,
+		})
+
+		if ua1.Score > 0 && ua2.Score > 0 {
+			scores1 = append(scores1, float64(ua1.Score))
+			scores2 = append(scores2, float64(ua2.Score))
+		}
+	}
+
+	for _, ua2 := range userAnime2 {
+		if !sharedMap[ua2.AnimeID] {
+			animeIDs = append(animeIDs, ua2.AnimeID)
+			res.OnlyUser2 = append(res.OnlyUser2, ua2.AnimeID)
+		}
+	}
+
+	if len(scores1) > 0 {
+		var diff float64
+		for i := range scores1 {
+			diff += math.Abs(scores1[i] - scores2[i])
+		}
+		res.ScoreMeanDifference = s.round(diff / float64(len(scores1)))
+	}
+
+	correlation := s.pearson(scores1, scores2)
+	res.ScoreCorrelation = s.round(correlation)
+	res.Affinity = s.round(correlation * 100)
+
+	genreDiffs, code, err := s.getUserAnimeGenreDifferences(ctx, animeIDs, userAnime1, userAnime2)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	res.GenreDifferences = genreDiffs
+
+	return &res, http.StatusOK, nil
+}
+
+func (s *service) getUserAnimeGenreDifferences(ctx context.Context, animeIDs []int64, userAnime1, userAnime2 []*entity.UserAnime) ([]userAnimeCompareGenre, int, error) {
+	animeGenres, code, err := s.anime.GetGenresByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	genreMap := make(map[int64][]int64)
+	for _, ag := range animeGenres {
+		genreMap[ag.AnimeID] = append(genreMap[ag.AnimeID], ag.GenreID)
+	}
+
+	genres := make(map[int64]*userAnimeCompareGenre)
+	var total1, total2 int
+
+	for _, ua := range userAnime1 {
+		if ua.Status == entity.StatusPlanned {
+			continue
+		}
+
+		total1++
+		for _, id := range genreMap[ua.AnimeID] {
+			if genres[id] == nil {
+				genres[id] = &userAnimeCompareGenre{ID: id}
+			}
+			genres[id].Count1++
+		}
+	}
+
+	for _, ua := range userAnime2 {
+		if ua.Status == entity.StatusPlanned {
+			continue
+		}
+
+		total2++
+		for _, id := range genreMap[ua.AnimeID] {
+			if genres[id] == nil {
+				genres[id] = &userAnimeCompareGenre{ID: id}
+			}
+			genres[id].Count2++
+		}
+	}
+
+	res := make([]userAnimeCompareGenre, 0, len(genres))
+	if len(genres) == 0 {
+		return res, http.StatusOK, nil
+	}
+
+	genreIDs := make([]int64, 0, len(genres))
+	for id, g := range genres {
+		if total1 > 0 {
+			g.Ratio1 = s.round(float64(g.Count1) / float64(total1))
+		}
+		if total2 > 0 {
+			g.Ratio2 = s.round(float64(g.Count2) / float64(total2))
+		}
+		g.Difference = s.round(g.Ratio1 - g.Ratio2)
+		genreIDs = append(genreIDs, id)
+	}
+
+	genreNames, code, err := s.genre.GetByIDs(ctx, genreIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	for _, g := range genreNames {
+		genres[g.ID].Name = g.Name
+	}
+
+	for _, g := range genres {
+		res = append(res, *g)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if d1, d2 := math.Abs(res[i].Difference), math.Abs(res[j].Difference); d1 != d2 {
+			return d1 > d2
+		}
+		return res[i].ID < res[j].ID
+	})
+
+	if len(res) > userAnimeCompareGenreLimit {
+		res = res[:userAnimeCompareGenreLimit]
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// pearson to calculate pearson correlation coefficient.
+// Will return 0 if there is not enough data to compare.
+func (s *service) pearson(x, y []float64) float64 {
+	if len(x) < 2 || len(x) != len(y) {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	animeEntity "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	publisherEntity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/fairy/errors/stack"
+)
+
+const userAnimeStatsTopLimit = 10
+
+// UserAnimeStats is user anime stats model.
+type UserAnimeStats struct {
+	Status            userAnimeStatsStatus  `json:"status"`
+	MeanScore         float64               `json:"mean_score"`
+	ScoreDistribution []userAnimeStatsScore `json:"score_distribution"`
+	TotalEpisode      int                   `json:"total_episode"`
+	TotalDuration     int                   `json:"total_duration"`
+	CompletionRate    float64               `json:"completion_rate"`
+	GenresByCount     []userAnimeStatsItem  `json:"genres_by_count"`
+	GenresByScore     []userAnimeStatsItem  `json:"genres_by_score"`
+	StudiosByCount    []userAnimeStatsItem  `json:"studios_by_count"`
+	StudiosByScore    []userAnimeStatsItem  `json:"studios_by_score"`
+	Years             []userAnimeStatsYear  `json:"years"`
+}
+
+type userAnimeStatsStatus struct {
+	Watching  int `json:"watching"`
+	Completed int `json:"completed"`
+	OnHold    int `json:"on_hold"`
+	Dropped   int `json:"dropped"`
+	Planned   int `json:"planned"`
+	Total     int `json:"total"`
+}
+
+type userAnimeStatsScore struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+type userAnimeStatsItem struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Count     int     `json:"count"`
+	MeanScore float64 `json:"mean_score"`
+
+	scoreSum   int
+	scoreCount int
+}
+
+type userAnimeStatsYear struct {
+	Year     int `json:"year"`
+	Started  int `json:"started"`
+	Finished int `json:"finished"`
+}
+
+// GetUserAnimeStats to get user anime stats.
+//
+// Total duration is in seconds. Completion rate
+// excludes planned anime.
+func (s *service) GetUserAnimeStats(ctx context.Context, username string) (*UserAnimeStats, int, error) {
+	username = strings.ToLower(username)
+
+	userAnime, _, code, err := s.userAnime.Get(ctx, entity.GetUserAnimeRequest{
+		Username: username,
+		Page:     1,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	if len(userAnime) == 0 {
+		// Queue to parse.
+		if _, err := s.publisher.PublishParseUserAnime(ctx, username, "", false, publisherEntity.PriorityHigh); err != nil {
+			return nil, http.StatusInternalServerError, stack.Wrap(ctx, err)
+		}
+		return nil, http.StatusAccepted, nil
+	}
+
+	animeIDs := make([]int64, len(userAnime))
+	for i, ua := range userAnime {
+		animeIDs[i] = ua.AnimeID
+	}
+
+	anime, code, err := s.anime.GetByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	animeMap := make(map[int64]*animeEntity.Anime)
+	for _, a := range anime {
+		animeMap[a.ID] = a
+	}
+
+	animeGenres, code, err := s.anime.GetGenresByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	animeStudios, code, err := s.anime.GetStudiosByIDs(ctx, animeIDs)
+	if err != nil {
+		return nil, code, stack.Wrap(ctx, err)
+	}
+
+	genreMap := make(map[int64][]int64)
+	for _, ag := range animeGenres {
+		genreMap[ag.AnimeID] = append(genreMap[ag.AnimeID], ag.GenreID)
+	}
+
+	studioMap := make(map[int64][]int64)
+	for _, as := range animeStudios {
+		studioMap[as.AnimeID] = append(studioMap[as.AnimeID], as.StudioID)
+	}
+
+	var res UserAnimeStats
+	var scoreSum, scoreCount int
+	scores := make([]int, 11)
+	genres := make(map[int64]*userAnimeStatsItem)
+	studios := make(map[int64]*userAnimeStatsItem)
+	years := make(map[int]*userAnimeStatsYear)
+
+	for _, ua := range userAnime {
+		res.Status.Total++
+		switch ua.Status {
+		case entity.StatusWatching:
+			res.Status.Watching++
+		case entity.StatusCompleted:
+			res.Status.Completed++
+		case entity.StatusOnHold:
+			res.Status.OnHold++
+		case entity.StatusDropped:
+			res.Status.Dropped++
+		case entity.StatusPlanned:
+			res.Status.Planned++
+		}
+
+		if ua.Score > 0 && ua.Score <= 10 {
+			scoreSum += ua.Score
+			scoreCount++
+			scores[ua.Score]++
+		}
+
+		res.TotalEpisode += ua.Episode
+		if a := animeMap[ua.AnimeID]; a != nil {
+			res.TotalDuration += ua.Episode * a.Episode.Duration
+		}
+
+		for _, id := range genreMap[ua.AnimeID] {
+			s.addUserAnimeStatsItem(genres, id, ua.Score)
+		}
+
+		for _, id := range studioMap[ua.AnimeID] {
+			s.addUserAnimeStatsItem(studios, id, ua.Score)
+		}
+
+		if ua.StartYear > 0 {
+			s.getUserAnimeStatsYear(years, ua.StartYear).Started++
+		}
+
+		if ua.EndYear > 0 {
+			s.getUserAnimeStatsYear(years, ua.EndYear).Finished++
+		}
+	}
+
+	if scoreCount > 0 {
+		res.MeanScore = s.round(float64(scoreSum) / float64(scoreCount))
+	}
+
+	res.ScoreDistribution = make([]userAnimeStatsScore, 10)
+	for i := range res.ScoreDistribution {
+		res.ScoreDistribution[i] = userAnimeStatsScore{
+			Score: i + 1,
+			Count: scores[i+1],
+		}
+	}
+
+	if started := res.Status.Total - res.Status.Planned; started > 0 {
+		res.CompletionRate = s.round(float64(res.Status.Completed) / float64(started))
+	}
+
+	// Fill genre and studio names.
+	genreIDs := make([]int64, 0, len(genres))
+	for id := range genres {
+		genreIDs = append(genreIDs, id)
+	}
+
+	if len(genreIDs) > 0 {
+		genreNames, code, err := s.genre.GetByIDs(ctx, genreIDs)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+
+		for _, g := range genreNames {
+			genres[g.ID].Name = g.Name
+		}
+	}
+
+	studioIDs := make([]int64, 0, len(studios))
+	for id := range studios {
+		studioIDs = append(studioIDs, id)
+	}
+
+	if len(studioIDs) > 0 {
+		studioNames, code, err := s.studio.GetByIDs(ctx, studioIDs)
+		if err != nil {
+			return nil, code, stack.Wrap(ctx, err)
+		}
+
+		for _, st := range studioNames {
+			studios[st.ID].Name = st.Name
+		}
+	}
+
+	res.GenresByCount, res.GenresByScore = s.getUserAnimeStatsTop(genres)
+	res.StudiosByCount, res.StudiosByScore = s.getUserAnimeStatsTop(studios)
+
+	res.Years = make([]userAnimeStatsYear, 0, len(years))
+	for _, y := range years {
+		res.Years = append(res.Years, *y)
+	}
+
+	sort.Slice(res.Years, func(i, j int) bool {
+		return res.Years[i].Year < res.Years[j].Year
+	})
+
+	return &res, http.StatusOK, nil
+}
+
+func (s *service) addUserAnimeStatsItem(items map[int64]*userAnimeStatsItem, id int64, score int) {
+	if items[id] == nil {
+		items[id] = &userAnimeStatsItem{ID: id}
+	}
+
+	items[id].Count++
+	if score > 0 {
+		items[id].scoreSum += score
+		items[id].scoreCount++
+	}
+}
+
+func (s *service) getUserAnimeStatsYear(years map[int]*userAnimeStatsYear, year int) *userAnimeStatsYear {
+	if years[year] == nil {
+		years[year] = &userAnimeStatsYear{Year: year}
+	}
+	return years[year]
+}
+
+func (s *service) getUserAnimeStatsTop(items map[int64]*userAnimeStatsItem) (byCount, byScore []userAnimeStatsItem) {
+	byCount = make([]userAnimeStatsItem, 0, len(items))
+	byScore = make([]userAnimeStatsItem, 0, len(items))
+
+	for _, item := range items {
+		if item.scoreCount > 0 {
+			item.MeanScore = s.round(float64(item.scoreSum) / float64(item.scoreCount))
+			byScore = append(byScore, *item)
+		}
+		byCount = append(byCount, *item)
+	}
+
+	sort.Slice(byCount, func(i, j int) bool {
+		if byCount[i].Count != byCount[j].Count {
+			return byCount[i].Count > byCount[j].Count
+		}
+		return byCount[i].ID < byCount[j].ID
+	})
+
+	sort.Slice(byScore, func(i, j int) bool {
+		if byScore[i].MeanScore != byScore[j].MeanScore {
+			return byScore[i].MeanScore > byScore[j].MeanScore
+		}
+		if byScore[i].scoreCount != byScore[j].scoreCount {
+			return byScore[i].scoreCount > byScore[j].scoreCount
+		}
+		return byScore[i].ID < byScore[j].ID
+	})
+
+	if len(byCount) > userAnimeStatsTopLimit {
+		byCount = byCount[:userAnimeStatsTopLimit]
+	}
+
+	if len(byScore) > userAnimeStatsTopLimit {
+		byScore = byScore[:userAnimeStatsTopLimit]
+	}
+
+	return byCount, byScore
+}
+
+func (s *service) round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
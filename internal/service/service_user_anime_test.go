@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	entityAnime "github.com/rl404/akatsuki/internal/domain/anime/entity"
+	entityGenre "github.com/rl404/akatsuki/internal/domain/genre/entity"
+	entityPublisher "github.com/rl404/akatsuki/internal/domain/publisher/entity"
+	entityRelationGraph "github.com/rl404/akatsuki/internal/domain/relation_graph/entity"
+	entityStudio "github.com/rl404/akatsuki/internal/domain/studio/entity"
+	"github.com/rl404/akatsuki/internal/domain/user_anime/entity"
+	"github.com/rl404/akatsuki/internal/service"
+	mockAnime "github.com/rl404/akatsuki/tests/mocks/domain/anime"
+	mockGenre "github.com/rl404/akatsuki/tests/mocks/domain/genre"
+	mockPublisher "github.com/rl404/akatsuki/tests/mocks/domain/publisher"
+	mockRelationGraph "github.com/rl404/akatsuki/tests/mocks/domain/relation_graph"
+	mockStudio "github.com/rl404/akatsuki/tests/mocks/domain/studio"
+	mockUserAnime "github.com/rl404/akatsuki/tests/mocks/domain/user_anime"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type userAnimeTestSuite struct {
+	suite.Suite
+	animeMock         *mockAnime.Repository
+	genreMock         *mockGenre.Repository
+	studioMock        *mockStudio.Repository
+	userAnimeMock     *mockUserAnime.Repository
+	publisherMock     *mockPublisher.Repository
+	relationGraphMock *mockRelationGraph.Repository
+	service           service.Service
+}
+
+func TestUserAnime(t *testing.T) {
+	suite.Run(t, new(userAnimeTestSuite))
+}
+
+func (suite *userAnimeTestSuite) SetupTest() {
+	suite.animeMock = new(mockAnime.Repository)
+	suite.genreMock = new(mockGenre.Repository)
+	suite.studioMock = new(mockStudio.Repository)
+	suite.userAnimeMock = new(mockUserAnime.Repository)
+	suite.publisherMock = new(mockPublisher.Repository)
+	suite.relationGraphMock = new(mockRelationGraph.Repository)
+	suite.service = service.New(service.Deps{
+		Anime:         suite.animeMock,
+		Genre:         suite.genreMock,
+		Studio:        suite.studioMock,
+		UserAnime:     suite.userAnimeMock,
+		Publisher:     suite.publisherMock,
+		RelationGraph: suite.relationGraphMock,
+	})
+}
+
+func (suite *userAnimeTestSuite) TestGetUserAnime() {
+	ctx := context.Background()
+
+	// Invalid sort.
+	_, _, code, err := suite.service.GetUserAnime(ctx, service.GetUserAnimeRequest{Username: "rl404", Sort: "MEAN"})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	// Filtered and sorted.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{
+		Username: "rl404",
+		Status:   entity.StatusCompleted,
+		Tags:     []string{"rewatch"},
+		Sort:     "-SCORE",
+		Page:     1,
+		Limit:    20,
+	}).Return([]*entity.UserAnime{{AnimeID: 1, Status: entity.StatusCompleted, Score: 9}}, 1, http.StatusOK, nil).Once()
+
+	data, pagination, code, err := suite.service.GetUserAnime(ctx, service.GetUserAnimeRequest{
+		Username: "RL404",
+		Status:   "completed",
+		Tags:     []string{" rewatch "},
+		Sort:     "-score",
+	})
+	suite.Equal([]service.UserAnime{{AnimeID: 1, Status: entity.StatusCompleted, Score: 9}}, data)
+	suite.Equal(&service.Pagination{Page: 1, Limit: 20, Total: 1}, pagination)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	// Empty filter result of parsed user.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", StartScore: 10, Sort: "-UPDATED_AT", Page: 1, Limit: 20}).Return(nil, 0, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: 1}).Return([]*entity.UserAnime{{AnimeID: 1}}, 1, http.StatusOK, nil).Once()
+
+	data, pagination, code, err = suite.service.GetUserAnime(ctx, service.GetUserAnimeRequest{Username: "rl404", StartScore: 10})
+	suite.Empty(data)
+	suite.Equal(&service.Pagination{Page: 1, Limit: 20, Total: 0}, pagination)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+
+	// Not parsed user.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "new", Sort: "-UPDATED_AT", Page: 1, Limit: 20}).Return(nil, 0, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "new", "", false, entityPublisher.PriorityHigh).Return(true, nil).Once()
+
+	_, _, code, err = suite.service.GetUserAnime(ctx, service.GetUserAnimeRequest{Username: "new"})
+	suite.Equal(http.StatusAccepted, code)
+	suite.Nil(err)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
+
+func (suite *userAnimeTestSuite) TestGetUserAnimeStats() {
+	ctx := context.Background()
+	ids := []int64{1, 2, 3}
+
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: -1}).Return([]*entity.UserAnime{
+		{AnimeID: 1, Status: entity.StatusCompleted, Score: 9, Episode: 12, StartYear: 2020, EndYear: 2021},
+		{AnimeID: 2, Status: entity.StatusDropped, Score: 4, Episode: 2, StartYear: 2021},
+		{AnimeID: 3, Status: entity.StatusPlanned},
+	}, 3, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, ids).Return([]*entityAnime.Anime{
+		{ID: 1, Episode: entityAnime.Episode{Count: 12, Duration: 1440}},
+		{ID: 2, Episode: entityAnime.Episode{Count: 24, Duration: 1200}},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetGenresByIDs", ctx, ids).Return([]*entityAnime.AnimeGenre{
+		{AnimeID: 1, GenreID: 10},
+		{AnimeID: 2, GenreID: 10},
+		{AnimeID: 2, GenreID: 20},
+		{AnimeID: 3, GenreID: 20},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetStudiosByIDs", ctx, ids).Return([]*entityAnime.AnimeStudio{{AnimeID: 1, StudioID: 5}}, http.StatusOK, nil).Once()
+	suite.genreMock.On("GetByIDs", ctx, mock.Anything).Return([]*entityGenre.Genre{{ID: 10, Name: "Action"}, {ID: 20, Name: "Drama"}}, http.StatusOK, nil).Once()
+	suite.studioMock.On("GetByIDs", ctx, []int64{5}).Return([]*entityStudio.Studio{{ID: 5, Name: "Madhouse"}}, http.StatusOK, nil).Once()
+
+	stats, code, err := suite.service.GetUserAnimeStats(ctx, "RL404")
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Equal(3, stats.Status.Total)
+	suite.Equal(1, stats.Status.Completed)
+	suite.Equal(6.5, stats.MeanScore)
+	suite.Equal(1, stats.ScoreDistribution[8].Count)
+	suite.Equal(14, stats.TotalEpisode)
+	suite.Equal(12*1440+2*1200, stats.TotalDuration)
+	suite.Equal(0.5, stats.CompletionRate)
+	suite.Equal("Action", stats.GenresByCount[0].Name)
+	suite.Equal(2, stats.GenresByCount[0].Count)
+	suite.Equal("Action", stats.GenresByScore[0].Name)
+	suite.Equal(6.5, stats.GenresByScore[0].MeanScore)
+	suite.Equal("Drama", stats.GenresByScore[1].Name)
+	suite.Equal(4.0, stats.GenresByScore[1].MeanScore)
+	suite.Equal("Madhouse", stats.StudiosByScore[0].Name)
+	suite.Len(stats.Years, 2)
+	suite.Equal(2021, stats.Years[1].Year)
+	suite.Equal(1, stats.Years[1].Started)
+	suite.Equal(1, stats.Years[1].Finished)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.genreMock.AssertExpectations(suite.T())
+	suite.studioMock.AssertExpectations(suite.T())
+}
+
+func (suite *userAnimeTestSuite) TestCompareUserAnime() {
+	ctx := context.Background()
+
+	// One of the list is missing.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "a", Page: 1, Limit: -1}).Return([]*entity.UserAnime{{AnimeID: 1}}, 1, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "b", Page: 1, Limit: -1}).Return(nil, 0, http.StatusOK, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "a", "", false, entityPublisher.PriorityHigh).Return(true, nil).Once()
+	suite.publisherMock.On("PublishParseUserAnime", ctx, "b", "", false, entityPublisher.PriorityHigh).Return(true, nil).Once()
+
+	_, code, err := suite.service.CompareUserAnime(ctx, "A", "B")
+	suite.Equal(http.StatusAccepted, code)
+	suite.Nil(err)
+
+	// Both lists exist.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "a", Page: 1, Limit: -1}).Return([]*entity.UserAnime{
+		{AnimeID: 1, Status: entity.StatusCompleted, Score: 8},
+		{AnimeID: 2, Status: entity.StatusCompleted, Score: 6},
+		{AnimeID: 3, Status: entity.StatusCompleted, Score: 4},
+		{AnimeID: 4, Status: entity.StatusCompleted, Score: 7},
+	}, 4, http.StatusOK, nil).Once()
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "b", Page: 1, Limit: -1}).Return([]*entity.UserAnime{
+		{AnimeID: 1, Status: entity.StatusCompleted, Score: 9},
+		{AnimeID: 2, Status: entity.StatusCompleted, Score: 7},
+		{AnimeID: 3, Status: entity.StatusCompleted, Score: 5},
+		{AnimeID: 5, Status: entity.StatusPlanned},
+	}, 4, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetGenresByIDs", ctx, []int64{1, 2, 3, 4, 5}).Return([]*entityAnime.AnimeGenre{
+		{AnimeID: 4, GenreID: 10},
+		{AnimeID: 5, GenreID: 10},
+	}, http.StatusOK, nil).Once()
+	suite.genreMock.On("GetByIDs", ctx, []int64{10}).Return([]*entityGenre.Genre{{ID: 10, Name: "Action"}}, http.StatusOK, nil).Once()
+
+	compare, code, err := suite.service.CompareUserAnime(ctx, "a", "b")
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Len(compare.Shared, 3)
+	suite.Equal([]int64{4}, compare.OnlyUser1)
+	suite.Equal([]int64{5}, compare.OnlyUser2)
+	suite.Equal(1.0, compare.ScoreCorrelation)
+	suite.Equal(1.0, compare.ScoreMeanDifference)
+	suite.Equal(100.0, compare.Affinity)
+	suite.Len(compare.GenreDifferences, 1)
+	suite.Equal("Action", compare.GenreDifferences[0].Name)
+	suite.Equal(1, compare.GenreDifferences[0].Count1)
+	suite.Equal(0, compare.GenreDifferences[0].Count2)
+	suite.Equal(0.25, compare.GenreDifferences[0].Difference)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+	suite.genreMock.AssertExpectations(suite.T())
+	suite.publisherMock.AssertExpectations(suite.T())
+}
+
+func (suite *userAnimeTestSuite) TestGetUserAnimeRelations() {
+	ctx := context.Background()
+
+	// Invalid relation type.
+	_, code, err := suite.service.GetUserAnimeRelations(ctx, service.GetUserAnimeRelationsRequest{Username: "rl404", Relations: []entityAnime.Relation{"x"}})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.NotNil(err)
+
+	userAnime := []*entity.UserAnime{{AnimeID: 1, Status: entity.StatusCompleted, Score: 8}}
+
+	// Traverse only sequel relation, 2 steps away.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: -1}).Return(userAnime, 1, http.StatusOK, nil).Twice()
+	suite.relationGraphMock.On("Get", ctx, "rl404", "2:5000:SEQUEL").Return(nil, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{1}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel},
+		{AnimeID1: 1, AnimeID2: 5, Relation: entityAnime.RelationCharacter},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{2}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 2, AnimeID2: 1, Relation: entityAnime.RelationPrequel},
+		{AnimeID1: 2, AnimeID2: 3, Relation: entityAnime.RelationSequel},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{3}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 3, AnimeID2: 2, Relation: entityAnime.RelationPrequel},
+	}, http.StatusOK, nil).Once()
+
+	// Anime 3 is not expanded but has no sequel.
+	graph := entityRelationGraph.Graph{
+		AnimeIDs: []int64{1, 2, 3},
+		Links: []entityRelationGraph.Link{
+			{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel},
+			{AnimeID1: 2, AnimeID2: 3, Relation: entityAnime.RelationSequel},
+		},
+	}
+
+	suite.relationGraphMock.On("Set", ctx, "rl404", "2:5000:SEQUEL", graph).Return(http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1, 2, 3}).Return([]*entityAnime.Anime{{ID: 1}, {ID: 2}, {ID: 3}}, http.StatusOK, nil).Twice()
+
+	data, code, err := suite.service.GetUserAnimeRelations(ctx, service.GetUserAnimeRelationsRequest{Username: "RL404", Relations: []entityAnime.Relation{"sequel"}, Depth: 2})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Len(data.Nodes, 3)
+	suite.Len(data.Links, 2)
+	suite.False(data.Truncated)
+	suite.Equal(entity.StatusCompleted, data.Nodes[0].UserAnimeStatus)
+
+	// Cached graph.
+	suite.relationGraphMock.On("Get", ctx, "rl404", "2:5000:SEQUEL").Return(&graph, http.StatusOK, nil).Once()
+
+	data, code, err = suite.service.GetUserAnimeRelations(ctx, service.GetUserAnimeRelationsRequest{Username: "rl404", Relations: []entityAnime.Relation{"SEQUEL"}, Depth: 2})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.Len(data.Nodes, 3)
+
+	// Anime 2 is not expanded and has sequel.
+	suite.userAnimeMock.On("Get", ctx, entity.GetUserAnimeRequest{Username: "rl404", Page: 1, Limit: -1}).Return(userAnime, 1, http.StatusOK, nil).Once()
+	suite.relationGraphMock.On("Get", ctx, "rl404", "1:5000:SEQUEL").Return(nil, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{1}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel},
+	}, http.StatusOK, nil).Once()
+	suite.animeMock.On("GetRelatedByIDs", ctx, []int64{2}).Return([]*entityAnime.AnimeRelated{
+		{AnimeID1: 2, AnimeID2: 3, Relation: entityAnime.RelationSequel},
+	}, http.StatusOK, nil).Once()
+	suite.relationGraphMock.On("Set", ctx, "rl404", "1:5000:SEQUEL", entityRelationGraph.Graph{
+		AnimeIDs:  []int64{1, 2},
+		Truncated: true,
+		Links:     []entityRelationGraph.Link{{AnimeID1: 1, AnimeID2: 2, Relation: entityAnime.RelationSequel}},
+	}).Return(http.StatusOK, nil).Once()
+	suite.animeMock.On("GetByIDs", ctx, []int64{1, 2}).Return([]*entityAnime.Anime{{ID: 1}, {ID: 2}}, http.StatusOK, nil).Once()
+
+	data, code, err = suite.service.GetUserAnimeRelations(ctx, service.GetUserAnimeRelationsRequest{Username: "rl404", Relations: []entityAnime.Relation{"SEQUEL"}, Depth: 1})
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(err)
+	suite.True(data.Truncated)
+
+	suite.userAnimeMock.AssertExpectations(suite.T())
+	suite.relationGraphMock.AssertExpectations(suite.T())
+	suite.animeMock.AssertExpectations(suite.T())
+}
@@ -25,6 +25,10 @@ func ResponseWithGRPC(code int, err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case http.StatusUnauthorized:
 		return status.Error(codes.Unauthenticated, err.Error())
+	case http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case http.StatusTooManyRequests:
+		return status.Error(codes.ResourceExhausted, err.Error())
 	case http.StatusInternalServerError:
 		return status.Error(codes.Internal, err.Error())
 	default:
@@ -0,0 +1,51 @@
+// Package atomic contains caches with atomic operations
+// for data that can't be safely updated with get then set.
+package atomic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rl404/akatsuki/pkg/cache/atomic/inmemory"
+	"github.com/rl404/akatsuki/pkg/cache/atomic/redis"
+)
+
+// Cacher is cache with atomic operations.
+type Cacher interface {
+	// SetNX to set key with ttl only if it does not exist.
+	// Will return remaining ttl of the existing key otherwise.
+	SetNX(ctx context.Context, key string, ttl time.Duration) (bool, time.Duration, error)
+	// Del to delete key set by SetNX.
+	Del(ctx context.Context, key string) error
+	// HIncr to increment field of hash key by 1.
+	HIncr(ctx context.Context, key, field string) error
+	// HGetAll to get all fields of hash key.
+	HGetAll(ctx context.Context, key string) (map[string]int, error)
+	Close() error
+}
+
+// CacheType is type for atomic cache.
+type CacheType int8
+
+// Available types for atomic cache.
+const (
+	InMemory CacheType = iota
+	Redis
+)
+
+// ErrInvalidCacheType is error for invalid cache type.
+var ErrInvalidCacheType = errors.New("invalid atomic cache type")
+
+// New to create new atomic cache client depends on the type.
+// In-memory cache is only atomic in the same process.
+func New(cacheType CacheType, address, password string) (Cacher, error) {
+	switch cacheType {
+	case InMemory:
+		return inmemory.New(), nil
+	case Redis:
+		return redis.New(address, password)
+	default:
+		return nil, ErrInvalidCacheType
+	}
+}
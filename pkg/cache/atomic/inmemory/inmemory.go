@@ -0,0 +1,100 @@
+// Package inmemory is atomic cache for single process.
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// How often expired keys are removed.
+const sweepInterval = time.Minute
+
+// InMemory is in-memory atomic cache.
+type InMemory struct {
+	sync.Mutex
+	keys    map[string]time.Time
+	hashes  map[string]map[string]int
+	sweptAt time.Time
+}
+
+// New to create new in-memory atomic cache.
+func New() *InMemory {
+	return &InMemory{
+		keys:    make(map[string]time.Time),
+		hashes:  make(map[string]map[string]int),
+		sweptAt: time.Now(),
+	}
+}
+
+// SetNX to set key with ttl only if it does not exist.
+// Will return remaining ttl of the existing key otherwise.
+func (c *InMemory) SetNX(_ context.Context, key string, ttl time.Duration) (bool, time.Duration, error) {
+	now := time.Now()
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.sweep(now)
+
+	if expiredAt, ok := c.keys[key]; ok && now.Before(expiredAt) {
+		return false, expiredAt.Sub(now), nil
+	}
+
+	c.keys[key] = now.Add(ttl)
+	return true, 0, nil
+}
+
+// Del to delete key set by SetNX.
+func (c *InMemory) Del(_ context.Context, key string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	delete(c.keys, key)
+	return nil
+}
+
+// HIncr to increment field of hash key by 1.
+func (c *InMemory) HIncr(_ context.Context, key, field string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.hashes[key] == nil {
+		c.hashes[key] = make(map[string]int)
+	}
+	c.hashes[key][field]++
+
+	return nil
+}
+
+// HGetAll to get all fields of hash key.
+func (c *InMemory) HGetAll(_ context.Context, key string) (map[string]int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	res := make(map[string]int, len(c.hashes[key]))
+	for k, v := range c.hashes[key] {
+		res[k] = v
+	}
+
+	return res, nil
+}
+
+// Close to close cache.
+func (c *InMemory) Close() error {
+	return nil
+}
+
+func (c *InMemory) sweep(now time.Time) {
+	if now.Sub(c.sweptAt) < sweepInterval {
+		return
+	}
+
+	for key, expiredAt := range c.keys {
+		if !now.Before(expiredAt) {
+			delete(c.keys, key)
+		}
+	}
+
+	c.sweptAt = now
+}
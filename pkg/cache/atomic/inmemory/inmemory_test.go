@@ -0,0 +1,57 @@
+package inmemory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/pkg/cache/atomic/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNX(t *testing.T) {
+	ctx := context.Background()
+	c := inmemory.New()
+
+	ok, _, err := c.SetNX(ctx, "a", time.Minute)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, ttl, err := c.SetNX(ctx, "a", time.Minute)
+	assert.False(t, ok)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, time.Minute)
+	assert.Nil(t, err)
+
+	// Expired key can be set again.
+	ok, _, _ = c.SetNX(ctx, "b", time.Nanosecond)
+	assert.True(t, ok)
+	time.Sleep(time.Millisecond)
+	ok, _, _ = c.SetNX(ctx, "b", time.Minute)
+	assert.True(t, ok)
+
+	// Deleted key can be set again.
+	assert.Nil(t, c.Del(ctx, "a"))
+	ok, _, _ = c.SetNX(ctx, "a", time.Minute)
+	assert.True(t, ok)
+}
+
+func TestHIncr(t *testing.T) {
+	ctx := context.Background()
+	c := inmemory.New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.HIncr(ctx, "key", "field")
+		}()
+	}
+	wg.Wait()
+
+	data, err := c.HGetAll(ctx, "key")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"field": 100}, data)
+}
@@ -0,0 +1,88 @@
+// Package redis is atomic cache shared between processes
+// through redis.
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Set the key if not exist, or return remaining ttl
+// (in milliseconds) of the existing key. Returns -1
+// if the key is set.
+var setNXScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], '1', 'NX', 'PX', ARGV[1]) then
+	return -1
+end
+return redis.call('PTTL', KEYS[1])
+`)
+
+// Redis is redis atomic cache.
+type Redis struct {
+	client *redis.Client
+}
+
+// New to create new redis atomic cache.
+func New(address, password string) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: password,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return &Redis{
+		client: client,
+	}, nil
+}
+
+// SetNX to set key with ttl only if it does not exist.
+// Will return remaining ttl of the existing key otherwise.
+func (c *Redis) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, time.Duration, error) {
+	ms, err := setNXScript.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if ms == -1 {
+		return true, 0, nil
+	}
+
+	// Key may expire right after failed SET.
+	return false, max(0, time.Duration(ms)*time.Millisecond), nil
+}
+
+// Del to delete key set by SetNX.
+func (c *Redis) Del(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
+
+// HIncr to increment field of hash key by 1.
+func (c *Redis) HIncr(ctx context.Context, key, field string) error {
+	return c.client.HIncrBy(ctx, key, field, 1).Err()
+}
+
+// HGetAll to get all fields of hash key.
+func (c *Redis) HGetAll(ctx context.Context, key string) (map[string]int, error) {
+	data, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]int, len(data))
+	for k, v := range data {
+		res[k], _ = strconv.Atoi(v)
+	}
+
+	return res, nil
+}
+
+// Close to close redis connection.
+func (c *Redis) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthFunc to check request token for the called method.
+// Token is empty if request has no authorization metadata.
+type AuthFunc func(ctx context.Context, token, fullMethod string) error
+
+// UnaryAuthInterceptor to check bearer token in
+// authorization metadata before calling the handler.
+func UnaryAuthInterceptor(fn AuthFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := fn(ctx, getToken(ctx), info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func getToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimPrefix(values[0], "Bearer ")
+}
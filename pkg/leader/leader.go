@@ -0,0 +1,115 @@
+// Package leader is a leader election using database lock.
+//
+// The leader holds a lease row and renews it periodically.
+// Other processes can only take over after the lease expired.
+package leader
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLost is error when the lock is taken by other process.
+var ErrLost = errors.New("leader lock lost")
+
+// Lock is leader lock database model.
+type Lock struct {
+	Name      string `gorm:"primaryKey;size:255"`
+	Owner     string
+	ExpiredAt time.Time
+}
+
+// Leader is database leader election.
+type Leader struct {
+	db    *gorm.DB
+	name  string
+	owner string
+	ttl   time.Duration
+}
+
+// New to create new leader election.
+// Processes with the same name compete for the same lock.
+// Owner should be unique per process.
+func New(db *gorm.DB, name, owner string, ttl time.Duration) *Leader {
+	return &Leader{
+		db:    db,
+		name:  name,
+		owner: owner,
+		ttl:   ttl,
+	}
+}
+
+// IsLeader to acquire or renew the lock.
+// Will return true if this process holds the lock.
+func (l *Leader) IsLeader(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	res := l.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&Lock{
+		Name:      l.name,
+		Owner:     l.owner,
+		ExpiredAt: now.Add(l.ttl),
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	res = l.db.WithContext(ctx).Model(&Lock{}).
+		Where("name = ? and (owner = ? or expired_at < ?)", l.name, l.owner, now).
+		Updates(map[string]interface{}{
+			"owner":      l.owner,
+			"expired_at": now.Add(l.ttl),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// Hold to keep renewing the lock in the background every
+// third of ttl until stop is called. The returned context is
+// cancelled if the lock can't be renewed, so the caller can
+// stop before other process takes over. Use context.Cause
+// to get the reason.
+func (l *Leader) Hold(ctx context.Context) (_ context.Context, stop func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	go func() {
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				isLeader, err := l.IsLeader(ctx)
+				if err != nil {
+					cancel(err)
+					return
+				}
+
+				if !isLeader {
+					cancel(ErrLost)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() { cancel(nil) }
+}
+
+// Release to release the lock so other process
+// can take over immediately.
+func (l *Leader) Release(ctx context.Context) error {
+	return l.db.WithContext(ctx).Where("name = ? and owner = ?", l.name, l.owner).Delete(&Lock{}).Error
+}
@@ -0,0 +1,96 @@
+package leader_test
+
+import (
+	"context"
+	_errors "errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rl404/akatsuki/pkg/leader"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+type testSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	dbMock sqlmock.Sqlmock
+}
+
+func TestLeader(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (suite *testSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	suite.Require().Nil(err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	suite.Require().Nil(err)
+
+	suite.db, suite.dbMock = gormDB, mock
+}
+
+func (suite *testSuite) TearDownTest() {
+	db, err := suite.db.DB()
+	suite.Require().Nil(err)
+	db.Close()
+}
+
+func (suite *testSuite) TestHoldLost() {
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`INSERT INTO "lock"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectCommit()
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`UPDATE "lock"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectCommit()
+
+	ctx, stop := leader.New(suite.db, "name", "owner", 30*time.Millisecond).Hold(context.Background())
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		suite.FailNow("lock is not renewed")
+	}
+
+	suite.ErrorIs(context.Cause(ctx), leader.ErrLost)
+	suite.Nil(suite.dbMock.ExpectationsWereMet())
+}
+
+func (suite *testSuite) TestHoldError() {
+	errDummy := _errors.New("dummy error")
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`INSERT INTO "lock"`).WillReturnError(errDummy)
+	suite.dbMock.ExpectRollback()
+
+	ctx, stop := leader.New(suite.db, "name", "owner", 30*time.Millisecond).Hold(context.Background())
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		suite.FailNow("lock is not renewed")
+	}
+
+	suite.ErrorIs(context.Cause(ctx), errDummy)
+}
+
+func (suite *testSuite) TestHoldStop() {
+	ctx, stop := leader.New(suite.db, "name", "owner", time.Hour).Hold(context.Background())
+	stop()
+
+	<-ctx.Done()
+	suite.ErrorIs(context.Cause(ctx), context.Canceled)
+}
@@ -0,0 +1,94 @@
+// Package bucket is a per-process token bucket limiter
+// keyed by name. Unlike other limiters, it does not block
+// and tells how long to wait instead.
+//
+// Buckets are not shared between processes, so running
+// multiple replicas multiplies the effective limit.
+package bucket
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// How often idle buckets are removed.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	limiter *rate.Limiter
+	// Time the bucket will be full again.
+	fullAt time.Time
+}
+
+// Bucket is keyed token bucket limiter.
+type Bucket struct {
+	sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+// New to create new keyed limiter.
+func New() *Bucket {
+	return &Bucket{
+		buckets: make(map[string]*bucket),
+		sweptAt: time.Now(),
+	}
+}
+
+// Allow to take a token from key's bucket.
+// Rate is request per second. Will return how long
+// to wait before retrying if there is no token left.
+func (b *Bucket) Allow(key string, r float64, burst int) (bool, time.Duration) {
+	now := time.Now()
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.sweep(now)
+
+	bb, ok := b.buckets[key]
+	if !ok {
+		bb = &bucket{limiter: rate.NewLimiter(rate.Limit(r), burst)}
+		b.buckets[key] = bb
+	}
+
+	// Apply updated limit.
+	if bb.limiter.Limit() != rate.Limit(r) {
+		bb.limiter.SetLimitAt(now, rate.Limit(r))
+	}
+	if bb.limiter.Burst() != burst {
+		bb.limiter.SetBurstAt(now, burst)
+	}
+
+	bb.fullAt = now.Add(time.Duration(float64(burst) / r * float64(time.Second)))
+
+	res := bb.limiter.ReserveN(now, 1)
+	if !res.OK() {
+		return false, time.Second
+	}
+
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return false, delay
+	}
+
+	return true, 0
+}
+
+// sweep to remove buckets that are full again. They
+// behave the same as new buckets so nothing is lost.
+func (b *Bucket) sweep(now time.Time) {
+	if now.Sub(b.sweptAt) < sweepInterval {
+		return
+	}
+
+	for key, bb := range b.buckets {
+		if !now.Before(bb.fullAt) {
+			delete(b.buckets, key)
+		}
+	}
+
+	b.sweptAt = now
+}
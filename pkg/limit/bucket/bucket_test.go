@@ -0,0 +1,27 @@
+package bucket_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/pkg/limit/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	b := bucket.New()
+
+	for i := 0; i < 2; i++ {
+		ok, _ := b.Allow("a", 1, 2)
+		assert.True(t, ok)
+	}
+
+	ok, wait := b.Allow("a", 1, 2)
+	assert.False(t, ok)
+	assert.Greater(t, wait, time.Duration(0))
+	assert.LessOrEqual(t, wait, time.Second)
+
+	// Other key has its own bucket.
+	ok, _ = b.Allow("b", 1, 2)
+	assert.True(t, ok)
+}
@@ -0,0 +1,21 @@
+package bucket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweep(t *testing.T) {
+	b := New()
+
+	b.Allow("a", 1, 1)
+	b.Allow("b", 0.01, 1)
+	assert.Len(t, b.buckets, 2)
+
+	// Only full bucket is removed.
+	b.sweep(time.Now().Add(sweepInterval + time.Second))
+	assert.Len(t, b.buckets, 1)
+	assert.Contains(t, b.buckets, "b")
+}
@@ -0,0 +1,9 @@
+// Package limit contains rate limiters that can be shared
+// between multiple processes.
+package limit
+
+// Limiter is rate limiter interface.
+type Limiter interface {
+	// Take blocks until the next request is allowed.
+	Take()
+}
@@ -0,0 +1,27 @@
+// Package local is a per-process rate limiter based on
+// "golang.org/x/time/rate" library.
+package local
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// Local is per-process token bucket limiter.
+type Local struct {
+	limiter *rate.Limiter
+}
+
+// New to create new local limiter.
+// Rate is request per second.
+func New(r float64, burst int) *Local {
+	return &Local{
+		limiter: rate.NewLimiter(rate.Limit(r), burst),
+	}
+}
+
+// Take blocks until the next request is allowed.
+func (l *Local) Take() {
+	_ = l.limiter.Wait(context.Background())
+}
@@ -0,0 +1,92 @@
+// Package redis is a token bucket rate limiter shared
+// between processes through redis.
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rl404/akatsuki/pkg/limit"
+)
+
+// Reserve a token and return how long the caller should
+// wait (in microseconds). Tokens may go negative so waiting
+// callers are served in order. Redis server time is used
+// so clock skew between processes does not matter.
+var script = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(data[1]) or burst
+local last = tonumber(data[2]) or now
+
+tokens = math.min(burst, tokens + (now - last) * rate) - 1
+
+local wait = 0
+if tokens < 0 then
+	wait = -tokens / rate
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+
+return math.ceil(wait * 1000000)
+`)
+
+// Redis is redis token bucket limiter.
+type Redis struct {
+	client   *redis.Client
+	key      string
+	rate     float64
+	burst    int
+	fallback limit.Limiter
+	onError  func(error)
+}
+
+// New to create new redis limiter.
+// Rate is request per second. Limiters with the same
+// key share the same bucket. Fallback limiter will be
+// used when redis is unreachable.
+func New(address, password, key string, rate float64, burst int, fallback limit.Limiter, onError func(error)) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: password,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return &Redis{
+		client:   client,
+		key:      key,
+		rate:     rate,
+		burst:    burst,
+		fallback: fallback,
+		onError:  onError,
+	}, nil
+}
+
+// Take blocks until the next request is allowed.
+func (r *Redis) Take() {
+	wait, err := script.Run(context.Background(), r.client, []string{r.key}, r.rate, r.burst).Int64()
+	if err != nil {
+		if r.onError != nil {
+			r.onError(err)
+		}
+		r.fallback.Take()
+		return
+	}
+
+	time.Sleep(time.Duration(wait) * time.Microsecond)
+}
+
+// Close to close redis connection.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
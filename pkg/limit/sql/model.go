@@ -0,0 +1,10 @@
+package sql
+
+import "time"
+
+// RateLimit is rate limit bucket model.
+type RateLimit struct {
+	Name      string `gorm:"primaryKey;size:255"`
+	Tokens    float64
+	UpdatedAt time.Time
+}
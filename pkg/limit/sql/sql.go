@@ -0,0 +1,87 @@
+// Package sql is a token bucket rate limiter shared
+// between processes through database row lock.
+package sql
+
+import (
+	"math"
+	"time"
+
+	"github.com/rl404/akatsuki/pkg/limit"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQL is database token bucket limiter.
+type SQL struct {
+	db       *gorm.DB
+	name     string
+	rate     float64
+	burst    int
+	fallback limit.Limiter
+	onError  func(error)
+}
+
+// New to create new database limiter.
+// Rate is request per second. Limiters with the same
+// name share the same bucket. Fallback limiter will be
+// used when database is unreachable.
+func New(db *gorm.DB, name string, rate float64, burst int, fallback limit.Limiter, onError func(error)) *SQL {
+	return &SQL{
+		db:       db,
+		name:     name,
+		rate:     rate,
+		burst:    burst,
+		fallback: fallback,
+		onError:  onError,
+	}
+}
+
+// Take blocks until the next request is allowed.
+func (sql *SQL) Take() {
+	wait, err := sql.reserve()
+	if err != nil {
+		if sql.onError != nil {
+			sql.onError(err)
+		}
+		sql.fallback.Take()
+		return
+	}
+
+	time.Sleep(wait)
+}
+
+// reserve a token. Tokens may go negative so waiting
+// callers are served in order.
+func (sql *SQL) reserve() (wait time.Duration, err error) {
+	// Make sure the row exists before locking it.
+	if err := sql.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&RateLimit{
+		Name:      sql.name,
+		Tokens:    float64(sql.burst),
+		UpdatedAt: time.Now(),
+	}).Error; err != nil {
+		return 0, err
+	}
+
+	err = sql.db.Transaction(func(tx *gorm.DB) error {
+		var r RateLimit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", sql.name).First(&r).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		elapsed := math.Max(0, now.Sub(r.UpdatedAt).Seconds())
+		r.Tokens = math.Min(float64(sql.burst), r.Tokens+elapsed*sql.rate) - 1
+		r.UpdatedAt = now
+
+		if r.Tokens < 0 {
+			wait = time.Duration(-r.Tokens / sql.rate * float64(time.Second))
+		}
+
+		return tx.Model(&RateLimit{}).Where("name = ?", sql.name).Updates(map[string]interface{}{
+			"tokens":     r.Tokens,
+			"updated_at": r.UpdatedAt,
+		}).Error
+	})
+
+	return wait, err
+}
@@ -0,0 +1,100 @@
+// Package inmemory is an in-process pubsub. Messages are
+// only delivered to subscribers in the same process.
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rl404/fairy/pubsub"
+)
+
+// Client is in-memory pubsub client.
+type Client struct {
+	sync.RWMutex
+	middlewares []func(pubsub.HandlerFunc) pubsub.HandlerFunc
+	subscribers map[string][]*queue
+	closed      bool
+}
+
+// New to create new in-memory pubsub client.
+func New() *Client {
+	return &Client{
+		subscribers: make(map[string][]*queue),
+	}
+}
+
+// Use to add pubsub middlewares.
+func (c *Client) Use(middlewares ...func(pubsub.HandlerFunc) pubsub.HandlerFunc) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+func (c *Client) applyMiddlewares(handlerFunc pubsub.HandlerFunc) pubsub.HandlerFunc {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handlerFunc = c.middlewares[i](handlerFunc)
+	}
+	return handlerFunc
+}
+
+// Publish to publish message.
+// Messages published before any subscriber exists are dropped.
+func (c *Client) Publish(ctx context.Context, topic string, data []byte) error {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	for _, q := range c.subscribers[topic] {
+		q.push(data)
+	}
+
+	return nil
+}
+
+// Subscribe to subscribe topic.
+func (c *Client) Subscribe(ctx context.Context, topic string, handlerFunc pubsub.HandlerFunc) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	q := newQueue()
+	c.subscribers[topic] = append(c.subscribers[topic], q)
+
+	go func(h pubsub.HandlerFunc) {
+		h = c.applyMiddlewares(h)
+
+		for {
+			data, ok := q.pop()
+			if !ok {
+				return
+			}
+			h(ctx, data)
+		}
+	}(handlerFunc)
+
+	return nil
+}
+
+// Close to stop all subscribers.
+func (c *Client) Close() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	for _, qs := range c.subscribers {
+		for _, q := range qs {
+			q.close()
+		}
+	}
+
+	return nil
+}
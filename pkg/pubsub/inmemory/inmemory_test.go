@@ -0,0 +1,42 @@
+package inmemory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rl404/akatsuki/pkg/pubsub/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishFromHandler(t *testing.T) {
+	ps := inmemory.New()
+	defer ps.Close()
+
+	got := make(chan string, 10)
+	assert.Nil(t, ps.Subscribe(context.Background(), "topic", func(ctx context.Context, message []byte) error {
+		got <- string(message)
+		if string(message) == "1" {
+			// Should not block the subscriber.
+			return ps.Publish(ctx, "topic", []byte("2"))
+		}
+		return nil
+	}))
+
+	assert.Nil(t, ps.Publish(context.Background(), "topic", []byte("1")))
+
+	for _, want := range []string{"1", "2"} {
+		select {
+		case msg := <-got:
+			assert.Equal(t, want, msg)
+		case <-time.After(time.Second):
+			t.Fatal("message not received")
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	ps := inmemory.New()
+	assert.Nil(t, ps.Close())
+	assert.ErrorIs(t, ps.Publish(context.Background(), "topic", nil), inmemory.ErrClosed)
+}
@@ -0,0 +1,57 @@
+package inmemory
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrClosed is error for closed pubsub client.
+var ErrClosed = errors.New("pubsub client closed")
+
+// queue is unbounded message queue so publishing
+// from inside a handler will never block.
+type queue struct {
+	sync.Mutex
+	cond     *sync.Cond
+	messages [][]byte
+	closed   bool
+}
+
+func newQueue() *queue {
+	q := &queue{}
+	q.cond = sync.NewCond(&q.Mutex)
+	return q
+}
+
+func (q *queue) push(data []byte) {
+	q.Lock()
+	defer q.Unlock()
+	q.messages = append(q.messages, data)
+	q.cond.Signal()
+}
+
+func (q *queue) pop() ([]byte, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	for len(q.messages) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+
+	if q.closed {
+		return nil, false
+	}
+
+	data := q.messages[0]
+	q.messages[0] = nil
+	q.messages = q.messages[1:]
+
+	return data, true
+}
+
+func (q *queue) close() {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
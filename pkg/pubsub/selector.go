@@ -3,6 +3,7 @@ package pubsub
 import (
 	"errors"
 
+	"github.com/rl404/akatsuki/pkg/pubsub/inmemory"
 	"github.com/rl404/fairy/pubsub"
 	"github.com/rl404/fairy/pubsub/google"
 	"github.com/rl404/fairy/pubsub/rabbitmq"
@@ -17,6 +18,7 @@ const (
 	Redis PubsubType = iota + 1
 	RabbitMQ
 	Google
+	InMemory
 )
 
 // ErrInvalidPubsubType is error for invalid pubsub type.
@@ -31,6 +33,8 @@ func New(pubsubType PubsubType, address string, password string) (pubsub.PubSub,
 		return rabbitmq.New(address)
 	case Google:
 		return google.New(address, password)
+	case InMemory:
+		return inmemory.New(), nil
 	default:
 		return nil, ErrInvalidPubsubType
 	}
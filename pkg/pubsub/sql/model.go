@@ -0,0 +1,11 @@
+package sql
+
+import "time"
+
+// PubsubMessage is pubsub message model.
+type PubsubMessage struct {
+	ID        int64  `gorm:"primaryKey"`
+	Topic     string `gorm:"index;size:255"`
+	Data      []byte
+	CreatedAt time.Time
+}
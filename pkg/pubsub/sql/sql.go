@@ -0,0 +1,123 @@
+// Package sql is a pubsub backed by database table.
+//
+// Each message is consumed by only one subscriber so
+// multiple processes can share the same topic as a queue.
+package sql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rl404/fairy/pubsub"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Client is database pubsub client.
+type Client struct {
+	db          *gorm.DB
+	interval    time.Duration
+	batch       int
+	middlewares []func(pubsub.HandlerFunc) pubsub.HandlerFunc
+	stop        chan struct{}
+	once        sync.Once
+}
+
+// New to create new database pubsub client.
+// Subscribers will poll new messages every interval.
+func New(db *gorm.DB, interval time.Duration) *Client {
+	return &Client{
+		db:       db,
+		interval: interval,
+		batch:    10,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Use to add pubsub middlewares.
+func (c *Client) Use(middlewares ...func(pubsub.HandlerFunc) pubsub.HandlerFunc) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+func (c *Client) applyMiddlewares(handlerFunc pubsub.HandlerFunc) pubsub.HandlerFunc {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handlerFunc = c.middlewares[i](handlerFunc)
+	}
+	return handlerFunc
+}
+
+// Publish to publish message.
+func (c *Client) Publish(ctx context.Context, topic string, data []byte) error {
+	return c.db.WithContext(ctx).Create(&PubsubMessage{
+		Topic: topic,
+		Data:  data,
+	}).Error
+}
+
+// Subscribe to subscribe topic.
+func (c *Client) Subscribe(ctx context.Context, topic string, handlerFunc pubsub.HandlerFunc) error {
+	go func(h pubsub.HandlerFunc) {
+		h = c.applyMiddlewares(h)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			messages, err := c.pop(ctx, topic)
+			if err == nil && len(messages) > 0 {
+				for _, msg := range messages {
+					h(ctx, msg.Data)
+				}
+
+				// Poll again immediately if there are
+				// probably more messages waiting.
+				if len(messages) == c.batch {
+					continue
+				}
+			}
+
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(handlerFunc)
+
+	return nil
+}
+
+// pop will take oldest messages and delete them.
+// Locked rows are skipped so subscribers in other
+// processes will not get the same messages.
+func (c *Client) pop(ctx context.Context, topic string) ([]PubsubMessage, error) {
+	var messages []PubsubMessage
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("topic = ?", topic).
+			Order("id asc").
+			Limit(c.batch).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+
+		return tx.Where("id in ?", ids).Delete(&PubsubMessage{}).Error
+	})
+	return messages, err
+}
+
+// Close to stop all subscribers.
+func (c *Client) Close() error {
+	c.once.Do(func() { close(c.stop) })
+	return nil
+}
@@ -0,0 +1,69 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+)
+
+// TracerType is type for tracer exporter.
+type TracerType int8
+
+// Available types for tracer exporter.
+const (
+	NOP TracerType = iota
+	Stdout
+	OTLPGRPC
+	OTLPHTTP
+)
+
+// ErrInvalidTracerType is error for invalid tracer type.
+var ErrInvalidTracerType = errors.New("invalid tracer type")
+
+// New to create new tracer provider depends on the exporter type.
+// Address is the collector url (e.g. http://localhost:4317) and
+// only used by otlp exporters.
+func New(tracerType TracerType, address string, name string) (*trace.TracerProvider, error) {
+	var exporter trace.SpanExporter
+	var err error
+
+	switch tracerType {
+	case NOP:
+	case Stdout:
+		exporter, err = stdouttrace.New()
+	case OTLPGRPC:
+		exporter, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpointURL(address))
+	case OTLPHTTP:
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(address))
+	default:
+		return nil, ErrInvalidTracerType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithExporter(name, exporter), nil
+}
+
+// NewWithExporter to create new tracer provider with custom exporter.
+// Spans will not be exported if exporter is nil.
+//
+// Useful for testing with in-memory exporter.
+func NewWithExporter(name string, exporter trace.SpanExporter) *trace.TracerProvider {
+	opts := []trace.TracerProviderOption{
+		trace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(name))),
+	}
+
+	if exporter != nil {
+		opts = append(opts, trace.WithBatcher(exporter))
+	}
+
+	return trace.NewTracerProvider(opts...)
+}
@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/rl404/akatsuki/internal/domain/anime/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -86,6 +88,80 @@ func (_m *Repository) Get(ctx context.Context, data entity.GetRequest) ([]*entit
 	return r0, r1, r2, r3
 }
 
+// GetAiredIDs provides a mock function with given fields: ctx, since
+func (_m *Repository) GetAiredIDs(ctx context.Context, since time.Time) ([]int64, int, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAiredIDs")
+	}
+
+	var r0 []int64
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]int64, int, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []int64); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) int); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllRelated provides a mock function with given fields: ctx
+func (_m *Repository) GetAllRelated(ctx context.Context) ([]*entity.AnimeRelated, int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllRelated")
+	}
+
+	var r0 []*entity.AnimeRelated
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.AnimeRelated, int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.AnimeRelated); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.AnimeRelated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) int); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *Repository) GetByID(ctx context.Context, id int64) (*entity.Anime, int, error) {
 	ret := _m.Called(ctx, id)
@@ -160,6 +236,43 @@ func (_m *Repository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Anim
 	return r0, r1, r2
 }
 
+// GetGenresByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetGenresByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeGenre, int, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGenresByIDs")
+	}
+
+	var r0 []*entity.AnimeGenre
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]*entity.AnimeGenre, int, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []*entity.AnimeGenre); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.AnimeGenre)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) int); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []int64) error); ok {
+		r2 = rf(ctx, ids)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetHistories provides a mock function with given fields: ctx, data
 func (_m *Repository) GetHistories(ctx context.Context, data entity.GetHistoriesRequest) ([]entity.History, int, error) {
 	ret := _m.Called(ctx, data)
@@ -269,36 +382,36 @@ func (_m *Repository) GetMaxID(ctx context.Context) (int64, int, error) {
 	return r0, r1, r2
 }
 
-// GetOldFinishedIDs provides a mock function with given fields: ctx
-func (_m *Repository) GetOldFinishedIDs(ctx context.Context) ([]int64, int, error) {
-	ret := _m.Called(ctx)
+// GetOldIDs provides a mock function with given fields: ctx, limit
+func (_m *Repository) GetOldIDs(ctx context.Context, limit int) ([]int64, int, error) {
+	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOldFinishedIDs")
+		panic("no return value specified for GetOldIDs")
 	}
 
 	var r0 []int64
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, int, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int64, int, error)); ok {
+		return rf(ctx, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int64); ok {
+		r0 = rf(ctx, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]int64)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) int); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int) int); ok {
+		r1 = rf(ctx, limit)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
-		r2 = rf(ctx)
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -306,36 +419,36 @@ func (_m *Repository) GetOldFinishedIDs(ctx context.Context) ([]int64, int, erro
 	return r0, r1, r2
 }
 
-// GetOldNotYetIDs provides a mock function with given fields: ctx
-func (_m *Repository) GetOldNotYetIDs(ctx context.Context) ([]int64, int, error) {
-	ret := _m.Called(ctx)
+// GetRelatedByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetRelatedByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeRelated, int, error) {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOldNotYetIDs")
+		panic("no return value specified for GetRelatedByIDs")
 	}
 
-	var r0 []int64
+	var r0 []*entity.AnimeRelated
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, int, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]*entity.AnimeRelated, int, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []*entity.AnimeRelated); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int64)
+			r0 = ret.Get(0).([]*entity.AnimeRelated)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) int); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) int); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
-		r2 = rf(ctx)
+	if rf, ok := ret.Get(2).(func(context.Context, []int64) error); ok {
+		r2 = rf(ctx, ids)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -343,25 +456,25 @@ func (_m *Repository) GetOldNotYetIDs(ctx context.Context) ([]int64, int, error)
 	return r0, r1, r2
 }
 
-// GetOldReleasingIDs provides a mock function with given fields: ctx
-func (_m *Repository) GetOldReleasingIDs(ctx context.Context) ([]int64, int, error) {
+// GetSeasons provides a mock function with given fields: ctx
+func (_m *Repository) GetSeasons(ctx context.Context) ([]*entity.SeasonCount, int, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOldReleasingIDs")
+		panic("no return value specified for GetSeasons")
 	}
 
-	var r0 []int64
+	var r0 []*entity.SeasonCount
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.SeasonCount, int, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.SeasonCount); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int64)
+			r0 = ret.Get(0).([]*entity.SeasonCount)
 		}
 	}
 
@@ -380,25 +493,62 @@ func (_m *Repository) GetOldReleasingIDs(ctx context.Context) ([]int64, int, err
 	return r0, r1, r2
 }
 
-// GetRelatedByIDs provides a mock function with given fields: ctx, ids
-func (_m *Repository) GetRelatedByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeRelated, int, error) {
+// GetStatsChanges provides a mock function with given fields: ctx, since
+func (_m *Repository) GetStatsChanges(ctx context.Context, since time.Time) ([]*entity.StatsChange, int, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatsChanges")
+	}
+
+	var r0 []*entity.StatsChange
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entity.StatsChange, int, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entity.StatsChange); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.StatsChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) int); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetStudiosByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetStudiosByIDs(ctx context.Context, ids []int64) ([]*entity.AnimeStudio, int, error) {
 	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRelatedByIDs")
+		panic("no return value specified for GetStudiosByIDs")
 	}
 
-	var r0 []*entity.AnimeRelated
+	var r0 []*entity.AnimeStudio
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]*entity.AnimeRelated, int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]*entity.AnimeStudio, int, error)); ok {
 		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []int64) []*entity.AnimeRelated); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []*entity.AnimeStudio); ok {
 		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.AnimeRelated)
+			r0 = ret.Get(0).([]*entity.AnimeStudio)
 		}
 	}
 
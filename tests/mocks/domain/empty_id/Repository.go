@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/rl404/akatsuki/internal/domain/empty_id/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -69,6 +72,43 @@ func (_m *Repository) Delete(ctx context.Context, id int64) (int, error) {
 	return r0, r1
 }
 
+// DeleteOlderThan provides a mock function with given fields: ctx, t
+func (_m *Repository) DeleteOlderThan(ctx context.Context, t time.Time) ([]int64, int, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOlderThan")
+	}
+
+	var r0 []int64
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]int64, int, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []int64); ok {
+		r0 = rf(ctx, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) int); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, t)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *Repository) Get(ctx context.Context, id int64) (int64, int, error) {
 	ret := _m.Called(ctx, id)
@@ -141,6 +181,50 @@ func (_m *Repository) GetIDs(ctx context.Context) ([]int64, int, error) {
 	return r0, r1, r2
 }
 
+// GetList provides a mock function with given fields: ctx, data
+func (_m *Repository) GetList(ctx context.Context, data entity.GetRequest) ([]*entity.EmptyID, int, int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 []*entity.EmptyID
+	var r1 int
+	var r2 int
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) ([]*entity.EmptyID, int, int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) []*entity.EmptyID); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.EmptyID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.GetRequest) int); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.GetRequest) int); ok {
+		r2 = rf(ctx, data)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, entity.GetRequest) error); ok {
+		r3 = rf(ctx, data)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -0,0 +1,175 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/rl404/akatsuki/internal/domain/franchise/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, data
+func (_m *Repository) Get(ctx context.Context, data entity.GetRequest) ([]*entity.Franchise, int, int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []*entity.Franchise
+	var r1 int
+	var r2 int
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) ([]*entity.Franchise, int, int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) []*entity.Franchise); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Franchise)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.GetRequest) int); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.GetRequest) int); ok {
+		r2 = rf(ctx, data)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, entity.GetRequest) error); ok {
+		r3 = rf(ctx, data)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *Repository) GetByID(ctx context.Context, id int64) (*entity.Franchise, int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Franchise
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.Franchise, int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Franchise); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Franchise)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) int); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetIDsByAnimeIDs provides a mock function with given fields: ctx, animeIDs
+func (_m *Repository) GetIDsByAnimeIDs(ctx context.Context, animeIDs []int64) (map[int64]int64, int, error) {
+	ret := _m.Called(ctx, animeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIDsByAnimeIDs")
+	}
+
+	var r0 map[int64]int64
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]int64, int, error)); ok {
+		return rf(ctx, animeIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]int64); ok {
+		r0 = rf(ctx, animeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) int); ok {
+		r1 = rf(ctx, animeIDs)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []int64) error); ok {
+		r2 = rf(ctx, animeIDs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Replace provides a mock function with given fields: ctx, data
+func (_m *Repository) Replace(ctx context.Context, data []entity.Franchise) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Franchise) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Franchise) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Franchise) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
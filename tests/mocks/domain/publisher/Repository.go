@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/rl404/akatsuki/internal/domain/publisher/entity"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,35 +14,73 @@ type Repository struct {
 	mock.Mock
 }
 
-// PublishParseAnime provides a mock function with given fields: ctx, id, forced
-func (_m *Repository) PublishParseAnime(ctx context.Context, id int64, forced bool) error {
-	ret := _m.Called(ctx, id, forced)
+// PublishParseAnime provides a mock function with given fields: ctx, id, forced, priority
+func (_m *Repository) PublishParseAnime(ctx context.Context, id int64, forced bool, priority entity.Priority) (bool, error) {
+	ret := _m.Called(ctx, id, forced, priority)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PublishParseAnime")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
-		r0 = rf(ctx, id, forced)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, entity.Priority) (bool, error)); ok {
+		return rf(ctx, id, forced, priority)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, entity.Priority) bool); ok {
+		r0 = rf(ctx, id, forced, priority)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool, entity.Priority) error); ok {
+		r1 = rf(ctx, id, forced, priority)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// PublishParseUserAnime provides a mock function with given fields: ctx, username, status, forced
-func (_m *Repository) PublishParseUserAnime(ctx context.Context, username string, status string, forced bool) error {
-	ret := _m.Called(ctx, username, status, forced)
+// PublishParseUserAnime provides a mock function with given fields: ctx, username, status, forced, priority
+func (_m *Repository) PublishParseUserAnime(ctx context.Context, username string, status string, forced bool, priority entity.Priority) (bool, error) {
+	ret := _m.Called(ctx, username, status, forced, priority)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PublishParseUserAnime")
 	}
 
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, entity.Priority) (bool, error)); ok {
+		return rf(ctx, username, status, forced, priority)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, entity.Priority) bool); ok {
+		r0 = rf(ctx, username, status, forced, priority)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, entity.Priority) error); ok {
+		r1 = rf(ctx, username, status, forced, priority)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishRetry provides a mock function with given fields: ctx, msg
+func (_m *Repository) PublishRetry(ctx context.Context, msg entity.Message) error {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRetry")
+	}
+
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
-		r0 = rf(ctx, username, status, forced)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Message) error); ok {
+		r0 = rf(ctx, msg)
 	} else {
 		r0 = ret.Error(0)
 	}
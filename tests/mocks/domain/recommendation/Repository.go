@@ -0,0 +1,94 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/rl404/akatsuki/internal/domain/recommendation/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetSimilarities provides a mock function with given fields: ctx, animeIDs
+func (_m *Repository) GetSimilarities(ctx context.Context, animeIDs []int64) ([]*entity.Similarity, int, error) {
+	ret := _m.Called(ctx, animeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilarities")
+	}
+
+	var r0 []*entity.Similarity
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]*entity.Similarity, int, error)); ok {
+		return rf(ctx, animeIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []*entity.Similarity); ok {
+		r0 = rf(ctx, animeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Similarity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) int); ok {
+		r1 = rf(ctx, animeIDs)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []int64) error); ok {
+		r2 = rf(ctx, animeIDs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReplaceSimilarities provides a mock function with given fields: ctx, data
+func (_m *Repository) ReplaceSimilarities(ctx context.Context, data []entity.Similarity) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSimilarities")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Similarity) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Similarity) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Similarity) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/rl404/akatsuki/internal/domain/sync_status/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetByKey provides a mock function with given fields: ctx, _type, key
+func (_m *Repository) GetByKey(ctx context.Context, _type entity.Type, key string) (*entity.SyncStatus, int, error) {
+	ret := _m.Called(ctx, _type, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByKey")
+	}
+
+	var r0 *entity.SyncStatus
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Type, string) (*entity.SyncStatus, int, error)); ok {
+		return rf(ctx, _type, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Type, string) *entity.SyncStatus); ok {
+		r0 = rf(ctx, _type, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.SyncStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Type, string) int); ok {
+		r1 = rf(ctx, _type, key)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Type, string) error); ok {
+		r2 = rf(ctx, _type, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *Repository) GetStats(ctx context.Context) ([]entity.Stats, int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 []entity.Stats
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Stats, int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) int); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Record provides a mock function with given fields: ctx, _type, key, syncErr
+func (_m *Repository) Record(ctx context.Context, _type entity.Type, key string, syncErr error) (int, error) {
+	ret := _m.Called(ctx, _type, key, syncErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Type, string, error) (int, error)); ok {
+		return rf(ctx, _type, key, syncErr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Type, string, error) int); ok {
+		r0 = rf(ctx, _type, key, syncErr)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Type, string, error) error); ok {
+		r1 = rf(ctx, _type, key, syncErr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/rl404/akatsuki/internal/domain/trending/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, data
+func (_m *Repository) Get(ctx context.Context, data entity.GetRequest) ([]*entity.Trending, int, int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []*entity.Trending
+	var r1 int
+	var r2 int
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) ([]*entity.Trending, int, int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.GetRequest) []*entity.Trending); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Trending)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.GetRequest) int); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.GetRequest) int); ok {
+		r2 = rf(ctx, data)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, entity.GetRequest) error); ok {
+		r3 = rf(ctx, data)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// Replace provides a mock function with given fields: ctx, window, data
+func (_m *Repository) Replace(ctx context.Context, window entity.Window, data []entity.Trending) (int, error) {
+	ret := _m.Called(ctx, window, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Window, []entity.Trending) (int, error)); ok {
+		return rf(ctx, window, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Window, []entity.Trending) int); ok {
+		r0 = rf(ctx, window, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Window, []entity.Trending) error); ok {
+		r1 = rf(ctx, window, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
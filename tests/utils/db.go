@@ -6,7 +6,9 @@ import (
 
 	animeSQL "github.com/rl404/akatsuki/internal/domain/anime/repository/sql"
 	emptyIDSQL "github.com/rl404/akatsuki/internal/domain/empty_id/repository/sql"
+	franchiseSQL "github.com/rl404/akatsuki/internal/domain/franchise/repository/sql"
 	genreSQL "github.com/rl404/akatsuki/internal/domain/genre/repository/sql"
+	outboxSQL "github.com/rl404/akatsuki/internal/domain/outbox/repository/sql"
 	studioSQL "github.com/rl404/akatsuki/internal/domain/studio/repository/sql"
 	userAnimeSQL "github.com/rl404/akatsuki/internal/domain/user_anime/repository/sql"
 	"github.com/rl404/akatsuki/internal/errors"
@@ -68,6 +70,9 @@ func migrateDB(db *gorm.DB) error {
 		studioSQL.Studio{},
 		userAnimeSQL.UserAnime{},
 		emptyIDSQL.EmptyID{},
+		outboxSQL.Outbox{},
+		franchiseSQL.Franchise{},
+		franchiseSQL.FranchiseAnime{},
 	)
 }
 
@@ -110,5 +115,9 @@ func TruncateDB(db *gorm.DB) error {
 		return err
 	}
 
+	if err := tx.Unscoped().Delete(&outboxSQL.Outbox{}).Error; err != nil {
+		return err
+	}
+
 	return tx.Commit().Error
 }
@@ -9,6 +9,7 @@ var pubsubType = map[string]pubsub.PubsubType{
 	"rabbitmq": pubsub.RabbitMQ,
 	"redis":    pubsub.Redis,
 	"google":   pubsub.Google,
+	"inmemory": pubsub.InMemory,
 }
 
 func GetPubsub(cfg *config) (_pubsub.PubSub, error) {
@@ -7,6 +7,8 @@ import (
 	emptyIDRepository "github.com/rl404/akatsuki/internal/domain/empty_id/repository"
 	emptyIDCache "github.com/rl404/akatsuki/internal/domain/empty_id/repository/cache"
 	emptyIDSQL "github.com/rl404/akatsuki/internal/domain/empty_id/repository/sql"
+	franchiseRepository "github.com/rl404/akatsuki/internal/domain/franchise/repository"
+	franchiseSQL "github.com/rl404/akatsuki/internal/domain/franchise/repository/sql"
 	genreRepository "github.com/rl404/akatsuki/internal/domain/genre/repository"
 	genreCache "github.com/rl404/akatsuki/internal/domain/genre/repository/cache"
 	genreSQL "github.com/rl404/akatsuki/internal/domain/genre/repository/sql"
@@ -42,8 +44,18 @@ func GetService(cfg *config, db *gorm.DB, c cache.Cacher, ps pubsub.PubSub) serv
 	emptyID = emptyIDSQL.New(db)
 	emptyID = emptyIDCache.New(c, emptyID)
 
+	// Init franchise.
+	var franchise franchiseRepository.Repository = franchiseSQL.New(db)
+
 	// Init publisher.
-	var publisher publisherRepository.Repository = publisherPubsub.New(ps, pubsubTopic)
+	var publisher publisherRepository.Repository = publisherPubsub.New(ps, pubsubTopic, pubsubHighTopic)
 
-	return service.New(anime, genre, studio, nil, emptyID, publisher, nil)
+	return service.New(service.Deps{
+		Anime:     anime,
+		Genre:     genre,
+		Studio:    studio,
+		EmptyID:   emptyID,
+		Publisher: publisher,
+		Franchise: franchise,
+	})
 }